@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/venkytv/tts-cached/internal/cli"
+	"github.com/venkytv/tts-cached/internal/stats"
 )
 
 type ttsResponse struct {
@@ -25,7 +28,13 @@ func main() {
 	var filePath string
 	flag.StringVar(&filePath, "file", "", "text file to read ('-' for stdin)")
 	flag.StringVar(&filePath, "f", "", "text file to read ('-' for stdin)")
+	format := flag.String("format", "", "input format: plain, markdown or html (default: from the -f file extension, else plain)")
 	serverURL := flag.String("server", defaultServer, "tts-cached /tts endpoint URL")
+	var outputPath string
+	flag.StringVar(&outputPath, "output", "", "write audio to this file ('-' for stdout) instead of playing on the server")
+	flag.StringVar(&outputPath, "o", "", "write audio to this file ('-' for stdout) instead of playing on the server")
+	showStats := flag.Bool("stats", false, "print cache statistics instead of submitting text")
+	top := flag.Int("top", 10, "number of top phrases shown with -stats")
 
 	flag.Usage = func() {
 		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [options] <text>\n\n", os.Args[0])
@@ -34,19 +43,38 @@ func main() {
 		fmt.Fprintln(flag.CommandLine.Output(), "  -f <path>   read text from file")
 		fmt.Fprintln(flag.CommandLine.Output(), "  -f -        read text from stdin")
 		fmt.Fprintln(flag.CommandLine.Output(), "  <text>      provide text as args when no -f is set")
+		fmt.Fprintln(flag.CommandLine.Output(), "  -format <f> convert markdown or html input to speakable text")
+		fmt.Fprintln(flag.CommandLine.Output(), "  -o <path>   fetch audio into a file ('-' for stdout) via /tts/stream")
+		fmt.Fprintln(flag.CommandLine.Output(), "  -stats      show cache statistics")
 		fmt.Fprintln(flag.CommandLine.Output())
 		flag.PrintDefaults()
 	}
 
 	flag.Parse()
 
-	text, err := cli.ResolveText(filePath, flag.Args(), os.Stdin)
+	if *showStats {
+		if err := printStats(*serverURL, *top, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "stats failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	text, err := cli.ResolveText(filePath, *format, flag.Args(), os.Stdin)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n\n", err)
 		flag.Usage()
 		os.Exit(1)
 	}
 
+	if outputPath != "" {
+		if err := fetchAudio(*serverURL, text, outputPath); err != nil {
+			fmt.Fprintf(os.Stderr, "fetch failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if err := submit(*serverURL, text, os.Stdout); err != nil {
 		fmt.Fprintf(os.Stderr, "submit failed: %v\n", err)
 		os.Exit(1)
@@ -91,6 +119,100 @@ func submit(serverURL, text string, out io.Writer) error {
 	return nil
 }
 
+// fetchAudio posts text to /tts/stream and copies the wav to outPath as it arrives.
+func fetchAudio(serverURL, text, outPath string) error {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return fmt.Errorf("parse server url: %w", err)
+	}
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/tts") + "/tts/stream"
+
+	data, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{Text: text})
+	if err != nil {
+		return fmt.Errorf("encode payload: %w", err)
+	}
+
+	// No overall timeout: long texts stream for as long as synthesis runs.
+	resp, err := http.Post(u.String(), "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("post to server: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+		return fmt.Errorf("server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var out io.Writer = os.Stdout
+	if outPath != "-" {
+		f, err := os.Create(outPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return fmt.Errorf("read audio: %w", err)
+	}
+	return nil
+}
+
+func printStats(serverURL string, top int, out io.Writer) error {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return fmt.Errorf("parse server url: %w", err)
+	}
+	u.Path = strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/tts") + "/cache/stats"
+	u.RawQuery = url.Values{"top": {strconv.Itoa(top)}}.Encode()
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return fmt.Errorf("get stats: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var rep stats.Report
+	if err := json.Unmarshal(body, &rep); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	pct := 0.0
+	if rep.MaxBytes > 0 {
+		pct = 100 * float64(rep.TotalBytes) / float64(rep.MaxBytes)
+	}
+	fmt.Fprintf(out, "size:       %d / %d bytes (%.1f%%)\n", rep.TotalBytes, rep.MaxBytes, pct)
+	fmt.Fprintf(out, "entries:    %d\n", rep.Entries)
+	for _, name := range []string{"1h", "24h", "all"} {
+		win := rep.HitRatio[name]
+		fmt.Fprintf(out, "hit ratio:  %-4s %5.1f%% (%d hits, %d misses)\n", name, 100*win.Ratio, win.Hits, win.Misses)
+	}
+	fmt.Fprintf(out, "single-hit: %d entries, %d bytes\n", rep.SingleHitEntries, rep.SingleHitBytes)
+
+	if len(rep.TopPhrases) > 0 {
+		fmt.Fprintln(out, "\ntop phrases:")
+		for _, p := range rep.TopPhrases {
+			fmt.Fprintf(out, "  %6d  %s\n", p.Plays, p.Text)
+		}
+	}
+	if len(rep.Evictions) > 0 {
+		fmt.Fprintln(out, "\nevictions:")
+		for _, d := range rep.Evictions {
+			fmt.Fprintf(out, "  %s  %d entries, %d bytes\n", d.Day, d.Count, d.Bytes)
+		}
+	}
+	return nil
+}
+
 func env(key, def string) string {
 	if v := strings.TrimSpace(os.Getenv(key)); v != "" {
 		return v
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/venkytv/tts-cached/internal/cache"
+	"github.com/venkytv/tts-cached/internal/config"
+	"github.com/venkytv/tts-cached/internal/engine"
+)
+
+// runCacheCommand implements `tts-cached cache <export|import>` and returns the exit code.
+func runCacheCommand(args []string) int {
+	if len(args) == 0 {
+		cacheUsage(os.Stderr)
+		return 2
+	}
+
+	switch args[0] {
+	case "export":
+		return runCacheExport(args[1:])
+	case "import":
+		return runCacheImport(args[1:])
+	case "-h", "-help", "--help", "help":
+		cacheUsage(os.Stdout)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown cache command %q\n\n", args[0])
+		cacheUsage(os.Stderr)
+		return 2
+	}
+}
+
+func cacheUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  tts-cached cache export [options] -o <archive.tar[.gz]|->")
+	fmt.Fprintln(w, "  tts-cached cache import [options] <archive.tar[.gz]|->")
+}
+
+// cacheFlags registers the settings needed to locate the local cache and
+// identify its voices.
+func cacheFlags(fs *flag.FlagSet) func() (config.Config, *engine.Registry, error) {
+	cacheDir := fs.String("cache-dir", envOr("CACHE_DIR", config.DefaultCacheDir()), "cache directory for wav files (env CACHE_DIR)")
+	piperModel := fs.String("piper-model", os.Getenv("PIPER_MODEL"), "path to piper model file (env PIPER_MODEL, required without VOICES_FILE)")
+	voiceID := fs.String("voice-id", os.Getenv("VOICE_ID"), "default voice identifier (env VOICE_ID)")
+	voicesFile := fs.String("voices-file", os.Getenv("VOICES_FILE"), "JSON file defining engines and voices (env VOICES_FILE)")
+
+	return func() (config.Config, *engine.Registry, error) {
+		cfg, err := config.LoadWithOverrides(config.Config{
+			CacheDir:   strings.TrimSpace(*cacheDir),
+			PiperModel: strings.TrimSpace(*piperModel),
+			VoiceID:    strings.TrimSpace(*voiceID),
+			VoicesFile: strings.TrimSpace(*voicesFile),
+		})
+		if err != nil {
+			return cfg, nil, err
+		}
+		engines, err := buildEngines(cfg, false, log.Default())
+		return cfg, engines, err
+	}
+}
+
+func runCacheExport(args []string) int {
+	fs := flag.NewFlagSet("cache export", flag.ExitOnError)
+	load := cacheFlags(fs)
+	outPath := fs.String("o", "", "archive to write ('-' for stdout)")
+	compress := fs.Bool("gzip", false, "gzip the archive (implied by a .gz or .tgz suffix)")
+	_ = fs.Parse(args)
+
+	if *outPath == "" {
+		fmt.Fprintln(os.Stderr, "error: -o is required")
+		return 2
+	}
+	cfg, _, err := load()
+	if err != nil {
+		log.Printf("ERROR: %v", err)
+		return 1
+	}
+
+	var out io.Writer = os.Stdout
+	var f *os.File
+	if *outPath != "-" {
+		if f, err = os.Create(*outPath); err != nil {
+			log.Printf("ERROR: create archive: %v", err)
+			return 1
+		}
+		out = f
+		if strings.HasSuffix(*outPath, ".gz") || strings.HasSuffix(*outPath, ".tgz") {
+			*compress = true
+		}
+	}
+
+	mgr := cache.NewManager(cfg.CacheDir, cfg.CacheMaxBytes, log.Default())
+	exported, skipped, err := mgr.Export(out, *compress)
+	if f != nil {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		log.Printf("ERROR: export failed: %v", err)
+		return 1
+	}
+	log.Printf("INFO: exported %d entries from %s (%d without metadata skipped)", exported, cfg.CacheDir, skipped)
+	return 0
+}
+
+func runCacheImport(args []string) int {
+	fs := flag.NewFlagSet("cache import", flag.ExitOnError)
+	load := cacheFlags(fs)
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "error: expected exactly one archive path")
+		return 2
+	}
+	cfg, engines, err := load()
+	if err != nil {
+		log.Printf("ERROR: %v", err)
+		return 1
+	}
+
+	var in io.Reader = os.Stdin
+	if path := fs.Arg(0); path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			log.Printf("ERROR: open archive: %v", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+
+	mgr := cache.NewManager(cfg.CacheDir, cfg.CacheMaxBytes, log.Default())
+	res, err := mgr.Import(context.Background(), in, func(meta cache.Meta) error {
+		if meta.Voice == cache.DialogueVoice {
+			for name, model := range cache.ParseDialogueModel(meta.Model) {
+				if err := checkModel(engines, name, model); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return checkModel(engines, meta.Voice, meta.Model)
+	})
+	if err != nil {
+		log.Printf("ERROR: import failed: %v", err)
+		return 1
+	}
+	if err := mgr.EnforceLimit(); err != nil {
+		log.Printf("ERROR: enforce cache limit failed: %v", err)
+	}
+	log.Printf("INFO: imported %d entries into %s (%d already present, %d skipped)", res.Imported, cfg.CacheDir, res.Existing, res.Skipped)
+	return 0
+}
+
+// checkModel accepts an imported entry only if voice is configured locally with
+// the model the entry was synthesized with.
+func checkModel(engines *engine.Registry, name, model string) error {
+	voice, _, err := engines.Resolve(name)
+	if err != nil || name == "" {
+		return fmt.Errorf("voice %q is not configured locally", name)
+	}
+	if voice.Fingerprint == "" || model != voice.Fingerprint {
+		return fmt.Errorf("model %q does not match local model %q", model, voice.Fingerprint)
+	}
+	return nil
+}
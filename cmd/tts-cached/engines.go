@@ -0,0 +1,147 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/venkytv/tts-cached/internal/cache"
+	"github.com/venkytv/tts-cached/internal/config"
+	"github.com/venkytv/tts-cached/internal/engine"
+	"github.com/venkytv/tts-cached/internal/espeakexec"
+	"github.com/venkytv/tts-cached/internal/exectmpl"
+	"github.com/venkytv/tts-cached/internal/piperexec"
+	"github.com/venkytv/tts-cached/internal/pipermodel"
+	"github.com/venkytv/tts-cached/internal/plugin"
+)
+
+// buildEngines constructs the configured engines and registers each voice with
+// a fingerprint of its model for cache metadata. Plugins are started right away
+// when start is set; other processes start lazily.
+func buildEngines(cfg config.Config, start bool, logger *log.Logger) (*engine.Registry, error) {
+	reg := engine.NewRegistry(cfg.VoiceID)
+	fingerprints := make(map[string]string, len(cfg.Engines))
+
+	names := make([]string, 0, len(cfg.Engines))
+	for name := range cfg.Engines {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ec := cfg.Engines[name]
+		switch ec.Type {
+		case config.EnginePiper:
+			meta, err := pipermodel.Load(ec.Model)
+			if err != nil {
+				if start {
+					return nil, fmt.Errorf("engine %s: %w", name, err)
+				}
+				logger.Printf("ERROR: engine %s: %v", name, err)
+			} else if meta.Audio.SampleRate != ec.SampleRate {
+				logger.Printf("INFO: engine %s: using model sample rate %d instead of %d", name, meta.Audio.SampleRate, ec.SampleRate)
+			}
+			if ec.Workers > 0 {
+				pool := piperexec.NewPool(ec.Exec, ec.Model, ec.Flags, ec.SampleRate, ec.Workers, logger)
+				if err == nil {
+					pool = pool.WithMetadata(meta)
+				}
+				reg.AddEngine(name, pool)
+				if cfg.StreamPlayback {
+					logger.Printf("INFO: stream playback is not available with resident piper workers (engine %s); playing after synthesis", name)
+				}
+			} else {
+				r := piperexec.New(ec.Exec, ec.Model, ec.Flags, ec.SampleRate, logger)
+				if err == nil {
+					r = r.WithMetadata(meta)
+				}
+				reg.AddEngine(name, r)
+			}
+			fp, err := cache.ModelFingerprint(ec.Model)
+			if err != nil {
+				logger.Printf("ERROR: fingerprint model for engine %s failed: %v", name, err)
+			}
+			fingerprints[name] = fp
+		case config.EngineEspeak:
+			reg.AddEngine(name, espeakexec.New(ec.Exec, ec.Flags, ec.Languages, logger))
+			fingerprints[name] = config.EngineEspeak
+		case config.EngineExec:
+			r, err := exectmpl.New(exectmpl.Spec{
+				Command:    ec.Exec,
+				Args:       ec.Args,
+				Input:      ec.Input,
+				Output:     ec.Output,
+				SampleRate: ec.SampleRate,
+				Languages:  ec.Languages,
+				Speakers:   ec.Speakers,
+			}, logger)
+			if err != nil {
+				return nil, fmt.Errorf("engine %s: %w", name, err)
+			}
+			reg.AddEngine(name, r)
+			fingerprints[name] = commandFingerprint(ec.Exec, ec.Args)
+		case config.EnginePlugin:
+			ping, err := ec.PluginPingInterval()
+			if err != nil {
+				return nil, fmt.Errorf("engine %s: %w", name, err)
+			}
+			p := plugin.New(ec.Exec, ec.Args, ping, logger)
+			if start {
+				// Learn capabilities up front; a failing plugin is retried on use.
+				if err := p.Start(); err != nil {
+					logger.Printf("ERROR: engine %s: %v", name, err)
+				}
+			}
+			reg.AddEngine(name, p)
+			fingerprints[name] = commandFingerprint(ec.Exec, ec.Args)
+		case config.EngineClip:
+			clip, err := engine.NewClip(ec.File)
+			if err != nil {
+				return nil, fmt.Errorf("engine %s: %w", name, err)
+			}
+			reg.AddEngine(name, clip)
+			fp, err := cache.ModelFingerprint(ec.File)
+			if err != nil {
+				return nil, fmt.Errorf("engine %s: %w", name, err)
+			}
+			fingerprints[name] = fp
+		default:
+			return nil, fmt.Errorf("engine %s: unknown type %q", name, ec.Type)
+		}
+	}
+
+	for name, vc := range cfg.Voices {
+		var ranges map[string]engine.Range
+		if len(vc.Ranges) > 0 {
+			ranges = make(map[string]engine.Range, len(vc.Ranges))
+			for param, r := range vc.Ranges {
+				ranges[param] = engine.Range{Min: r.Min, Max: r.Max}
+			}
+		}
+		err := reg.AddVoice(engine.Voice{
+			Name:        name,
+			Engine:      vc.Engine,
+			EngineVoice: vc.Voice,
+			Speaker:     vc.Speaker,
+			Language:    vc.Language,
+			Fingerprint: fingerprints[vc.Engine],
+			Fallback:    vc.Fallback,
+			Speakers:    vc.Speakers,
+			Ranges:      ranges,
+			Emoji:       vc.Emoji,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return reg, nil
+}
+
+// commandFingerprint identifies an exec template engine by its command line.
+func commandFingerprint(command string, args []string) string {
+	sum := sha256.Sum256([]byte(command + "\x00" + strings.Join(args, "\x00")))
+	return "exec:" + hex.EncodeToString(sum[:8])
+}
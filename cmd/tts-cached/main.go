@@ -16,39 +16,94 @@ import (
 	"github.com/venkytv/tts-cached/internal/audio"
 	"github.com/venkytv/tts-cached/internal/cache"
 	"github.com/venkytv/tts-cached/internal/config"
-	"github.com/venkytv/tts-cached/internal/piperexec"
+	"github.com/venkytv/tts-cached/internal/redact"
 	"github.com/venkytv/tts-cached/internal/server"
+	"github.com/venkytv/tts-cached/internal/warmup"
 )
 
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
 
-	env := func(key, def string) string {
-		if v := os.Getenv(key); strings.TrimSpace(v) != "" {
-			return v
-		}
-		return def
+	if len(os.Args) > 1 && os.Args[1] == "cache" {
+		os.Exit(runCacheCommand(os.Args[2:]))
 	}
 
+	env := envOr
+
 	piperExec := flag.String("piper-exec", env("PIPER_EXEC", config.DefaultPiperExec()), "path to piper executable (env PIPER_EXEC)")
-	piperModel := flag.String("piper-model", os.Getenv("PIPER_MODEL"), "path to piper model file (env PIPER_MODEL, required)")
+	piperModel := flag.String("piper-model", os.Getenv("PIPER_MODEL"), "path to piper model file (env PIPER_MODEL, required without VOICES_FILE)")
 	piperFlags := flag.String("piper-flags", os.Getenv("PIPER_FLAGS"), "additional piper flags (space-separated, env PIPER_FLAGS)")
+	piperWorkers := flag.Int("piper-workers", 0, "resident piper processes using --json-input; 0 runs piper per request (env PIPER_WORKERS)")
 	cacheDir := flag.String("cache-dir", env("CACHE_DIR", config.DefaultCacheDir()), "cache directory for wav files (env CACHE_DIR)")
 	listenAddr := flag.String("listen-addr", env("LISTEN_ADDR", config.DefaultListenAddr()), "HTTP listen address (env LISTEN_ADDR)")
 	playCmd := flag.String("play-cmd", env("PLAY_CMD", config.DefaultPlayCmd()), "playback command (env PLAY_CMD)")
 	playArgs := flag.String("play-args", os.Getenv("PLAY_ARGS"), "playback extra args (space-separated, env PLAY_ARGS)")
-	voiceID := flag.String("voice-id", env("VOICE_ID", "default"), "voice identifier used in cache key (env VOICE_ID)")
+	streamPlayback := flag.Bool("stream-playback", false, "play audio while piper is still synthesizing (env STREAM_PLAYBACK)")
+	streamPlayCmd := flag.String("stream-play-cmd", os.Getenv("STREAM_PLAY_CMD"), "command reading raw PCM on stdin (env STREAM_PLAY_CMD, default PLAY_CMD default)")
+	streamPlayArgs := flag.String("stream-play-args", os.Getenv("STREAM_PLAY_ARGS"), "stream command args, {rate} is replaced by the sample rate (env STREAM_PLAY_ARGS)")
+	piperSampleRate := flag.Int("piper-sample-rate", 0, "sample rate of the piper model's raw output (env PIPER_SAMPLE_RATE, default 22050)")
+	voiceID := flag.String("voice-id", os.Getenv("VOICE_ID"), "default voice; identifier used in cache key (env VOICE_ID, default \"default\" or the voices file's default)")
+	voicesFile := flag.String("voices-file", os.Getenv("VOICES_FILE"), "JSON file defining engines and voices (env VOICES_FILE)")
 	cacheMaxBytes := flag.String("cache-max-bytes", os.Getenv("CACHE_MAX_BYTES"), "max cache size in bytes (env CACHE_MAX_BYTES, default 536870912)")
+	peers := flag.String("peers", os.Getenv("PEERS"), "peer tts-cached base URLs to query on cache miss (comma-separated, env PEERS)")
+	peerTimeout := flag.String("peer-timeout", os.Getenv("PEER_TIMEOUT"), "per-peer fetch timeout (env PEER_TIMEOUT, default 2s)")
+	peerMaxBytes := flag.String("peer-max-bytes", os.Getenv("PEER_MAX_BYTES"), "max wav size accepted from a peer (env PEER_MAX_BYTES, default 16777216)")
+	synthConcurrency := flag.Int("synth-concurrency", 0, "max concurrent syntheses (env SYNTH_CONCURRENCY, default PIPER_WORKERS or 1)")
+	synthQueue := flag.String("synth-queue", os.Getenv("SYNTH_QUEUE"), "max requests waiting for a synthesis slot before 503 (env SYNTH_QUEUE, default 8)")
+	sentenceCache := flag.Bool("sentence-cache", false, "cache long texts per sentence and reuse unchanged sentences (env SENTENCE_CACHE)")
+	sentenceGap := flag.String("sentence-gap", os.Getenv("SENTENCE_GAP"), "silence inserted between cached sentences (env SENTENCE_GAP, default 250ms)")
+	maxBodyBytes := flag.String("max-body-bytes", os.Getenv("MAX_BODY_BYTES"), "max request body size in bytes (env MAX_BODY_BYTES, default 1048576)")
+	maxTextChars := flag.Int("max-text-chars", 0, "max characters of text per request (env MAX_TEXT_CHARS, default 20000)")
+	synthTimeout := flag.String("synth-timeout", os.Getenv("SYNTH_TIMEOUT"), "time limit for one engine run (env SYNTH_TIMEOUT, default 60s)")
+	maxUnitChars := flag.Int("max-unit-chars", 0, "split longer texts into synthesis units of at most this many characters (env MAX_UNIT_CHARS, default 15 per second of SYNTH_TIMEOUT)")
+	langDetect := flag.Bool("lang-detect", false, "choose a voice by Accept-Language or detected text language when a request names none (env LANG_DETECT)")
+	breakerThreshold := flag.Int("breaker-threshold", 0, "consecutive failures that open an engine's circuit breaker (env BREAKER_THRESHOLD, default 3)")
+	breakerProbe := flag.String("breaker-probe-interval", os.Getenv("BREAKER_PROBE_INTERVAL"), "how long an open breaker waits before probing its engine (env BREAKER_PROBE_INTERVAL, default 30s)")
+	warmupFile := flag.String("warmup-file", os.Getenv("WARMUP_FILE"), "phrasebook to precompute at startup (env WARMUP_FILE)")
+	warmupConcurrency := flag.Int("warmup-concurrency", 0, "concurrent syntheses during warmup (env WARMUP_CONCURRENCY, default 2)")
+	warmupBlock := flag.Bool("warmup-block", false, "finish warmup before accepting requests (env WARMUP_BLOCK)")
+	filterCmd := flag.String("filter-cmd", os.Getenv("FILTER_CMD"), "command rewriting request text from stdin to stdout before synthesis (env FILTER_CMD)")
+	filterArgs := flag.String("filter-args", os.Getenv("FILTER_ARGS"), "filter command args (space-separated, env FILTER_ARGS)")
+	filterTimeout := flag.String("filter-timeout", os.Getenv("FILTER_TIMEOUT"), "filter command timeout (env FILTER_TIMEOUT, default 5s)")
+	filterFailure := flag.String("filter-failure", os.Getenv("FILTER_FAILURE"), "when the filter fails: skip it or reject the request (env FILTER_FAILURE, default skip)")
+	redactList := flag.String("redact", os.Getenv("REDACT"), "built-in redaction detectors (comma-separated: jwt,api_key,email,ip,hex or all, env REDACT)")
+	redactFile := flag.String("redact-file", os.Getenv("REDACT_FILE"), "file of extra redaction regexes, one per line (env REDACT_FILE)")
+	redactPlaceholder := flag.String("redact-placeholder", os.Getenv("REDACT_PLACEHOLDER"), "spoken in place of redacted text (env REDACT_PLACEHOLDER, default \"redacted\")")
+	emoji := flag.String("emoji", os.Getenv("EMOJI"), "how emoji are spoken: verbalize, strip or keep (env EMOJI, default verbalize)")
+	lexiconFile := flag.String("lexicon-file", os.Getenv("LEXICON_FILE"), "JSON pronunciation lexicon, reloaded on change (env LEXICON_FILE)")
 
 	flag.Parse()
 
 	override := config.Config{
-		PiperExec:  strings.TrimSpace(*piperExec),
-		PiperModel: strings.TrimSpace(*piperModel),
-		CacheDir:   strings.TrimSpace(*cacheDir),
-		ListenAddr: strings.TrimSpace(*listenAddr),
-		PlayCmd:    strings.TrimSpace(*playCmd),
-		VoiceID:    strings.TrimSpace(*voiceID),
+		PiperExec:    strings.TrimSpace(*piperExec),
+		PiperModel:   strings.TrimSpace(*piperModel),
+		PiperWorkers: *piperWorkers,
+		CacheDir:     strings.TrimSpace(*cacheDir),
+		ListenAddr:   strings.TrimSpace(*listenAddr),
+		PlayCmd:      strings.TrimSpace(*playCmd),
+		VoiceID:      strings.TrimSpace(*voiceID),
+		VoicesFile:   strings.TrimSpace(*voicesFile),
+
+		SynthConcurrency: *synthConcurrency,
+
+		MaxTextChars: *maxTextChars,
+		MaxUnitChars: *maxUnitChars,
+
+		BreakerThreshold: *breakerThreshold,
+
+		WarmupFile:        strings.TrimSpace(*warmupFile),
+		WarmupConcurrency: *warmupConcurrency,
+		WarmupBlock:       *warmupBlock,
+
+		LexiconFile: strings.TrimSpace(*lexiconFile),
+
+		FilterCmd:     strings.TrimSpace(*filterCmd),
+		FilterFailure: strings.TrimSpace(*filterFailure),
+
+		Emoji: strings.TrimSpace(*emoji),
+
+		RedactFile:        strings.TrimSpace(*redactFile),
+		RedactPlaceholder: strings.TrimSpace(*redactPlaceholder),
 	}
 
 	if strings.TrimSpace(*piperFlags) != "" {
@@ -65,18 +120,150 @@ func main() {
 		override.CacheMaxBytes = val
 	}
 
+	if strings.TrimSpace(*synthQueue) != "" {
+		val, err := strconv.Atoi(strings.TrimSpace(*synthQueue))
+		if err != nil || val < 0 {
+			log.Fatalf("invalid synth-queue: %q", *synthQueue)
+		}
+		override.SynthQueue = val
+		if val == 0 {
+			override.SynthQueue = config.NoSynthQueue
+		}
+	}
+	override.SentenceCache = *sentenceCache
+	override.LangDetect = *langDetect
+	if strings.TrimSpace(*sentenceGap) != "" {
+		val, err := time.ParseDuration(strings.TrimSpace(*sentenceGap))
+		if err != nil || val < 0 {
+			log.Fatalf("invalid sentence-gap: %q", *sentenceGap)
+		}
+		override.SentenceGap = val
+	}
+	if strings.TrimSpace(*maxBodyBytes) != "" {
+		val, err := strconv.ParseInt(strings.TrimSpace(*maxBodyBytes), 10, 64)
+		if err != nil || val <= 0 {
+			log.Fatalf("invalid max-body-bytes: %q", *maxBodyBytes)
+		}
+		override.MaxBodyBytes = val
+	}
+	if strings.TrimSpace(*synthTimeout) != "" {
+		val, err := time.ParseDuration(strings.TrimSpace(*synthTimeout))
+		if err != nil || val <= 0 {
+			log.Fatalf("invalid synth-timeout: %q", *synthTimeout)
+		}
+		override.SynthTimeout = val
+	}
+	if strings.TrimSpace(*breakerProbe) != "" {
+		val, err := time.ParseDuration(strings.TrimSpace(*breakerProbe))
+		if err != nil || val <= 0 {
+			log.Fatalf("invalid breaker-probe-interval: %q", *breakerProbe)
+		}
+		override.BreakerProbeInterval = val
+	}
+	if strings.TrimSpace(*redactList) != "" {
+		override.Redact = config.SplitList(*redactList)
+	}
+	if strings.TrimSpace(*filterArgs) != "" {
+		override.FilterArgs = strings.Fields(*filterArgs)
+	}
+	if strings.TrimSpace(*filterTimeout) != "" {
+		val, err := time.ParseDuration(strings.TrimSpace(*filterTimeout))
+		if err != nil || val <= 0 {
+			log.Fatalf("invalid filter-timeout: %q", *filterTimeout)
+		}
+		override.FilterTimeout = val
+	}
+	override.StreamPlayback = *streamPlayback
+	override.StreamPlayCmd = strings.TrimSpace(*streamPlayCmd)
+	if strings.TrimSpace(*streamPlayArgs) != "" {
+		override.StreamPlayArgs = strings.Fields(*streamPlayArgs)
+	}
+	override.PiperSampleRate = *piperSampleRate
+
+	if strings.TrimSpace(*peers) != "" {
+		override.Peers = config.SplitList(*peers)
+	}
+	if strings.TrimSpace(*peerTimeout) != "" {
+		val, err := time.ParseDuration(strings.TrimSpace(*peerTimeout))
+		if err != nil || val <= 0 {
+			log.Fatalf("invalid peer-timeout: %q", *peerTimeout)
+		}
+		override.PeerTimeout = val
+	}
+	if strings.TrimSpace(*peerMaxBytes) != "" {
+		val, err := strconv.ParseInt(strings.TrimSpace(*peerMaxBytes), 10, 64)
+		if err != nil || val <= 0 {
+			log.Fatalf("invalid peer-max-bytes: %v", err)
+		}
+		override.PeerMaxBytes = val
+	}
+
 	cfg, err := config.LoadWithOverrides(override)
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	log.Printf("INFO: starting tts-cached with config: PIPER_EXEC=%s PIPER_MODEL=%s PIPER_FLAGS=%v CACHE_DIR=%s LISTEN_ADDR=%s PLAY_CMD=%s VOICE_ID=%s CACHE_MAX_BYTES=%d",
-		cfg.PiperExec, cfg.PiperModel, cfg.PiperFlags, cfg.CacheDir, cfg.ListenAddr, cfg.PlayCmd, cfg.VoiceID, cfg.CacheMaxBytes)
+	log.Printf("INFO: starting tts-cached with config: PIPER_EXEC=%s PIPER_MODEL=%s PIPER_FLAGS=%v PIPER_WORKERS=%d VOICES_FILE=%s SYNTH_CONCURRENCY=%d SYNTH_QUEUE=%d CACHE_DIR=%s LISTEN_ADDR=%s PLAY_CMD=%s VOICE_ID=%s CACHE_MAX_BYTES=%d PEERS=%v",
+		cfg.PiperExec, cfg.PiperModel, cfg.PiperFlags, cfg.PiperWorkers, cfg.VoicesFile, cfg.SynthConcurrency, cfg.SynthQueue, cfg.CacheDir, cfg.ListenAddr, cfg.PlayCmd, cfg.VoiceID, cfg.CacheMaxBytes, cfg.Peers)
+
+	engines, err := buildEngines(cfg, true, log.Default())
+	if err != nil {
+		log.Fatalf("failed to set up engines: %v", err)
+	}
+	for _, v := range engines.Voices() {
+		if len(v.Fallback) > 0 {
+			log.Printf("INFO: voice %s -> engine %s (fallback %v)", v.Name, v.Engine, v.Fallback)
+		} else {
+			log.Printf("INFO: voice %s -> engine %s", v.Name, v.Engine)
+		}
+	}
 
 	cacheMgr := cache.NewManager(cfg.CacheDir, cfg.CacheMaxBytes, log.Default())
 	player := audio.NewPlayer(cfg.PlayCmd, cfg.PlayArgs, log.Default())
-	piper := piperexec.New(cfg.PiperExec, cfg.PiperModel, cfg.PiperFlags, log.Default())
-	srv := server.New(cfg, cacheMgr, piper, player, log.Default())
+	if cfg.StreamPlayback {
+		player = player.WithStream(cfg.StreamPlayCmd, cfg.StreamPlayArgs)
+	}
+	srv := server.New(cfg, cacheMgr, engines, player, log.Default())
+
+	if len(cfg.Redact) > 0 || cfg.RedactFile != "" {
+		var patterns []string
+		if cfg.RedactFile != "" {
+			if patterns, err = redact.LoadPatterns(cfg.RedactFile); err != nil {
+				log.Fatalf("failed to load redaction patterns: %v", err)
+			}
+		}
+		redactor, err := redact.New(cfg.Redact, patterns, cfg.RedactPlaceholder)
+		if err != nil {
+			log.Fatalf("failed to set up redaction: %v", err)
+		}
+		srv.SetRedactor(redactor)
+		log.Printf("INFO: redacting %v and %d custom patterns", cfg.Redact, len(patterns))
+	}
+
+	if cfg.LexiconFile != "" {
+		if err := srv.WatchLexicon(cfg.LexiconFile); err != nil {
+			log.Fatalf("failed to load lexicon: %v", err)
+		}
+	}
+
+	if cfg.WarmupFile != "" {
+		entries, err := warmup.LoadPhrasebook(cfg.WarmupFile)
+		if err != nil {
+			log.Fatalf("failed to load warmup phrasebook: %v", err)
+		}
+		if n, err := srv.PrunePins(context.Background(), entries); err != nil {
+			log.Printf("ERROR: prune warmup pins failed: %v", err)
+		} else if n > 0 {
+			log.Printf("INFO: unpinned %d entries no longer pinned by %s", n, cfg.WarmupFile)
+		}
+		if cfg.WarmupBlock {
+			if _, err := srv.RunWarmup(context.Background(), entries); err != nil {
+				log.Printf("ERROR: warmup failed: %v", err)
+			}
+		} else if err := srv.StartWarmup(entries); err != nil {
+			log.Printf("ERROR: warmup failed: %v", err)
+		}
+	}
 
 	httpServer := &http.Server{
 		Addr:    cfg.ListenAddr,
@@ -101,5 +288,16 @@ func main() {
 	if err := httpServer.Shutdown(ctx); err != nil {
 		log.Printf("ERROR: graceful shutdown failed: %v", err)
 	}
+	srv.Close()
+	if err := engines.Close(); err != nil {
+		log.Printf("ERROR: stopping engines failed: %v", err)
+	}
 	log.Printf("INFO: shutdown complete")
 }
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); strings.TrimSpace(v) != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,26 @@
+// Command tts-plugin-tone is the reference tts-cached engine plugin. It speaks
+// each word as a beep, and is meant as a protocol test and a starting point for
+// wrapping real engines.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/plugin"
+)
+
+func main() {
+	rate := flag.Int("rate", 16000, "output sample rate")
+	word := flag.Duration("word", 120*time.Millisecond, "beep length per word")
+	gap := flag.Duration("gap", 40*time.Millisecond, "silence after each word")
+	flag.Parse()
+
+	// Stdout carries the protocol; logs go to stderr, which the daemon relays.
+	log.SetOutput(os.Stderr)
+	if err := plugin.Serve(os.Stdin, os.Stdout, plugin.Tone{SampleRate: *rate, Word: *word, Gap: *gap}); err != nil {
+		log.Fatalf("plugin: %v", err)
+	}
+}
@@ -2,16 +2,27 @@ package audio
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log"
+	"os"
 	"os/exec"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// playMargin is how long playback may run beyond the length of its audio,
+// and how long a stream may wait for its next write, before it is killed.
+const playMargin = 60 * time.Second
+
 // Player executes an external command to play wav files.
 type Player struct {
-	cmd    string
-	args   []string
-	logger *log.Logger
+	cmd        string
+	args       []string
+	streamCmd  string
+	streamArgs []string
+	logger     *log.Logger
 }
 
 // NewPlayer constructs a Player.
@@ -22,9 +33,10 @@ func NewPlayer(cmd string, args []string, logger *log.Logger) Player {
 	return Player{cmd: cmd, args: args, logger: logger}
 }
 
-// PlayWav runs the playback command with a timeout, logging start/end/errors.
+// PlayWav runs the playback command with a timeout of the clip's length plus
+// playMargin, logging start/end/errors.
 func (p Player) PlayWav(path string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), wavPlayTimeout(path))
 	defer cancel()
 
 	fullArgs := append(append([]string{}, p.args...), path)
@@ -36,3 +48,103 @@ func (p Player) PlayWav(path string) {
 	}
 	p.logger.Printf("INFO: playback finished for %s", path)
 }
+
+// wavPlayTimeout returns the length of the wav at path plus playMargin, or
+// just playMargin if the file cannot be read as a wav.
+func wavPlayTimeout(path string) time.Duration {
+	file, err := os.Open(path)
+	if err != nil {
+		return playMargin
+	}
+	defer file.Close()
+	f, pcm, err := ReadWAV(file)
+	if err != nil {
+		return playMargin
+	}
+	return f.Duration(len(pcm)) + playMargin
+}
+
+// RatePlaceholder in stream args is replaced with the PCM sample rate.
+const RatePlaceholder = "{rate}"
+
+// WithStream returns a copy of p that plays raw PCM by piping it to cmd's stdin.
+func (p Player) WithStream(cmd string, args []string) Player {
+	p.streamCmd = cmd
+	p.streamArgs = args
+	return p
+}
+
+// CanStream reports whether a stream command is configured.
+func (p Player) CanStream() bool {
+	return p.streamCmd != ""
+}
+
+// StartPCM starts the stream command for 16-bit mono PCM at sampleRate. Audio
+// written to the returned writer plays as it arrives; Close waits for playback
+// to drain. Playback is killed once it runs playMargin past both the audio
+// written so far and the last write.
+func (p Player) StartPCM(sampleRate int) (io.WriteCloser, error) {
+	if p.streamCmd == "" {
+		return nil, errors.New("no stream playback command configured")
+	}
+	args := make([]string, len(p.streamArgs))
+	for i, a := range p.streamArgs {
+		args[i] = strings.ReplaceAll(a, RatePlaceholder, strconv.Itoa(sampleRate))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := exec.CommandContext(ctx, p.streamCmd, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	p.logger.Printf("INFO: stream playback start cmd=%s args=%v", p.streamCmd, args)
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, err
+	}
+	s := &pcmStream{stdin: stdin, cmd: cmd, cancel: cancel, logger: p.logger, format: PiperFormat(sampleRate), start: time.Now()}
+	s.timer = time.AfterFunc(playMargin, cancel)
+	return s, nil
+}
+
+type pcmStream struct {
+	stdin   io.WriteCloser
+	cmd     *exec.Cmd
+	cancel  context.CancelFunc
+	timer   *time.Timer
+	logger  *log.Logger
+	format  Format
+	start   time.Time
+	written int
+}
+
+func (s *pcmStream) Write(b []byte) (int, error) {
+	n, err := s.stdin.Write(b)
+	s.written += n
+	s.timer.Reset(time.Until(s.deadline(time.Now())))
+	return n, err
+}
+
+// deadline is when playback is killed after a write at now: playMargin past
+// the later of now and the end of the audio written so far.
+func (s *pcmStream) deadline(now time.Time) time.Time {
+	end := s.start.Add(s.format.Duration(s.written))
+	if now.After(end) {
+		end = now
+	}
+	return end.Add(playMargin)
+}
+
+func (s *pcmStream) Close() error {
+	_ = s.stdin.Close()
+	defer s.cancel()
+	defer s.timer.Stop()
+	if err := s.cmd.Wait(); err != nil {
+		s.logger.Printf("ERROR: stream playback failed: %v", err)
+		return err
+	}
+	s.logger.Printf("INFO: stream playback finished after %s", time.Since(s.start).Round(time.Millisecond))
+	return nil
+}
@@ -0,0 +1,56 @@
+package audio
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var logDiscard = log.New(io.Discard, "", 0)
+
+func TestWavPlayTimeoutCoversLongClips(t *testing.T) {
+	f := PiperFormat(16000)
+	path := filepath.Join(t.TempDir(), "long.wav")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteWAV(file, f, Silence(f, 90*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	if got, want := wavPlayTimeout(path), 90*time.Second+playMargin; got != want {
+		t.Fatalf("timeout = %s, want %s", got, want)
+	}
+	if got := wavPlayTimeout(filepath.Join(t.TempDir(), "missing.wav")); got != playMargin {
+		t.Fatalf("timeout for unreadable file = %s, want %s", got, playMargin)
+	}
+}
+
+func TestStreamDeadlineFollowsWrittenAudio(t *testing.T) {
+	f := PiperFormat(16000)
+	p := NewPlayer("", nil, logDiscard).WithStream("sh", []string{"-c", "cat >/dev/null"})
+	w, err := p.StartPCM(f.SampleRate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := w.(*pcmStream)
+	if _, err := w.Write(Silence(f, 90*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := s.deadline(s.start), s.start.Add(90*time.Second+playMargin); !got.Equal(want) {
+		t.Fatalf("deadline = %s, want %s", got, want)
+	}
+	late := s.start.Add(2 * time.Minute)
+	if got, want := s.deadline(late), late.Add(playMargin); !got.Equal(want) {
+		t.Fatalf("deadline after the audio = %s, want %s", got, want)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+}
@@ -0,0 +1,227 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"time"
+)
+
+// Format describes linear PCM audio.
+type Format struct {
+	SampleRate    int
+	Channels      int
+	BitsPerSample int
+}
+
+// PiperFormat returns the 16-bit mono format Piper produces at sampleRate.
+func PiperFormat(sampleRate int) Format {
+	return Format{SampleRate: sampleRate, Channels: 1, BitsPerSample: 16}
+}
+
+// BytesPerSecond returns the PCM data rate.
+func (f Format) BytesPerSecond() int {
+	return f.SampleRate * f.Channels * f.BitsPerSample / 8
+}
+
+// Duration returns how long n bytes of PCM in format f play for.
+func (f Format) Duration(n int) time.Duration {
+	bps := f.BytesPerSecond()
+	if bps <= 0 {
+		return 0
+	}
+	return time.Duration(int64(n) * int64(time.Second) / int64(bps))
+}
+
+const wavHeaderSize = 44
+
+// unknownSize marks RIFF/data chunk sizes in streamed WAVs whose length is not
+// known up front; players read until end of stream.
+const unknownSize = 0xFFFFFFFF
+
+// Header returns a canonical 44-byte WAV header for dataSize bytes of PCM.
+func Header(f Format, dataSize uint32) []byte {
+	riffSize := uint32(unknownSize)
+	if dataSize != unknownSize {
+		riffSize = 36 + dataSize
+	}
+	h := make([]byte, wavHeaderSize)
+	copy(h[0:], "RIFF")
+	binary.LittleEndian.PutUint32(h[4:], riffSize)
+	copy(h[8:], "WAVE")
+	copy(h[12:], "fmt ")
+	binary.LittleEndian.PutUint32(h[16:], 16)
+	binary.LittleEndian.PutUint16(h[20:], 1) // PCM
+	binary.LittleEndian.PutUint16(h[22:], uint16(f.Channels))
+	binary.LittleEndian.PutUint32(h[24:], uint32(f.SampleRate))
+	binary.LittleEndian.PutUint32(h[28:], uint32(f.BytesPerSecond()))
+	binary.LittleEndian.PutUint16(h[32:], uint16(f.Channels*f.BitsPerSample/8))
+	binary.LittleEndian.PutUint16(h[34:], uint16(f.BitsPerSample))
+	copy(h[36:], "data")
+	binary.LittleEndian.PutUint32(h[40:], dataSize)
+	return h
+}
+
+// StreamingHeader returns a WAV header for a stream of unknown length.
+func StreamingHeader(f Format) []byte {
+	return Header(f, unknownSize)
+}
+
+// WAVWriter writes PCM to a seekable file behind a placeholder header that Close
+// fills in with the final sizes.
+type WAVWriter struct {
+	w      io.WriteSeeker
+	format Format
+	n      int64
+	err    error
+}
+
+// NewWAVWriter writes a placeholder header to w and returns a writer for PCM data.
+func NewWAVWriter(w io.WriteSeeker, f Format) (*WAVWriter, error) {
+	if _, err := w.Write(Header(f, 0)); err != nil {
+		return nil, err
+	}
+	return &WAVWriter{w: w, format: f}, nil
+}
+
+// Write appends PCM data.
+func (ww *WAVWriter) Write(p []byte) (int, error) {
+	if ww.err != nil {
+		return 0, ww.err
+	}
+	n, err := ww.w.Write(p)
+	ww.n += int64(n)
+	ww.err = err
+	return n, err
+}
+
+// Close patches the header sizes; it does not close the underlying file.
+func (ww *WAVWriter) Close() error {
+	if ww.err != nil {
+		return ww.err
+	}
+	if ww.n > unknownSize-36 {
+		return errors.New("wav data too large")
+	}
+	if _, err := ww.w.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := ww.w.Write(Header(ww.format, uint32(ww.n))); err != nil {
+		return err
+	}
+	_, err := ww.w.Seek(0, io.SeekEnd)
+	return err
+}
+
+// ReadWAV parses a PCM wav, returning its format and sample data. Streamed
+// files whose data size is unknown are read to the end.
+func ReadWAV(r io.Reader) (Format, []byte, error) {
+	var f Format
+	hdr := make([]byte, 12)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return f, nil, errors.New("not a wav file")
+	}
+	if string(hdr[0:4]) != "RIFF" || string(hdr[8:12]) != "WAVE" {
+		return f, nil, errors.New("not a wav file")
+	}
+
+	gotFmt := false
+	chunk := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return f, nil, errors.New("wav has no data chunk")
+		}
+		id := string(chunk[0:4])
+		size := binary.LittleEndian.Uint32(chunk[4:8])
+
+		switch id {
+		case "fmt ":
+			if size < 16 || size > 1<<16 {
+				return f, nil, errors.New("invalid fmt chunk")
+			}
+			body := make([]byte, size+size%2)
+			if _, err := io.ReadFull(r, body); err != nil {
+				return f, nil, err
+			}
+			if tag := binary.LittleEndian.Uint16(body[0:2]); tag != 1 {
+				return f, nil, errors.New("only PCM wav is supported")
+			}
+			f.Channels = int(binary.LittleEndian.Uint16(body[2:4]))
+			f.SampleRate = int(binary.LittleEndian.Uint32(body[4:8]))
+			f.BitsPerSample = int(binary.LittleEndian.Uint16(body[14:16]))
+			gotFmt = true
+		case "data":
+			if !gotFmt {
+				return f, nil, errors.New("wav data before fmt chunk")
+			}
+			var data []byte
+			var err error
+			if size == unknownSize || size == 0 {
+				data, err = io.ReadAll(r)
+			} else {
+				data = make([]byte, size)
+				_, err = io.ReadFull(r, data)
+			}
+			return f, data, err
+		default:
+			if _, err := io.CopyN(io.Discard, r, int64(size+size%2)); err != nil {
+				return f, nil, err
+			}
+		}
+	}
+}
+
+// Silence returns d worth of zeroed PCM in format f.
+func Silence(f Format, d time.Duration) []byte {
+	frame := f.Channels * f.BitsPerSample / 8
+	frames := int(int64(f.SampleRate) * int64(d) / int64(time.Second))
+	return make([]byte, frames*frame)
+}
+
+// WriteWAV writes pcm as a complete wav file.
+func WriteWAV(w io.Writer, f Format, pcm []byte) error {
+	if int64(len(pcm)) > unknownSize-36 {
+		return errors.New("wav data too large")
+	}
+	if _, err := w.Write(Header(f, uint32(len(pcm)))); err != nil {
+		return err
+	}
+	_, err := w.Write(pcm)
+	return err
+}
+
+// Resample converts 16-bit pcm in format f to rate using linear interpolation.
+func Resample(f Format, pcm []byte, rate int) ([]byte, error) {
+	if f.SampleRate == rate {
+		return pcm, nil
+	}
+	if f.BitsPerSample != 16 || f.Channels < 1 || f.SampleRate <= 0 || rate <= 0 {
+		return nil, errors.New("resampling needs 16-bit PCM")
+	}
+	frame := f.Channels * 2
+	in := len(pcm) / frame
+	if in == 0 {
+		return nil, nil
+	}
+	sample := func(i, ch int) float64 {
+		return float64(int16(binary.LittleEndian.Uint16(pcm[i*frame+ch*2:])))
+	}
+
+	out := int(int64(in) * int64(rate) / int64(f.SampleRate))
+	buf := make([]byte, out*frame)
+	step := float64(f.SampleRate) / float64(rate)
+	for o := 0; o < out; o++ {
+		pos := float64(o) * step
+		i := int(pos)
+		frac := pos - float64(i)
+		for ch := 0; ch < f.Channels; ch++ {
+			v := sample(i, ch)
+			if i+1 < in {
+				v += (sample(i+1, ch) - v) * frac
+			}
+			binary.LittleEndian.PutUint16(buf[o*frame+ch*2:], uint16(int16(math.Round(v))))
+		}
+	}
+	return buf, nil
+}
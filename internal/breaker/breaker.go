@@ -0,0 +1,117 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned by callers that skip work because the breaker is open.
+var ErrOpen = errors.New("circuit breaker open")
+
+// States reported by Breaker.Stats.
+const (
+	StateClosed   = "closed"
+	StateOpen     = "open"
+	StateHalfOpen = "half_open"
+)
+
+// Breaker opens after threshold consecutive failures. While open it rejects
+// work; once probeInterval has passed it lets a single probe through, closing
+// again on success or reopening for another interval on failure.
+type Breaker struct {
+	threshold     int
+	probeInterval time.Duration
+	now           func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openedAt  time.Time
+	open      bool
+	probing   bool
+	trips     int64
+	lastError string
+}
+
+// Stats is a point-in-time view of a breaker.
+type Stats struct {
+	State               string    `json:"state"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	Trips               int64     `json:"trips"`
+	OpenedAt            time.Time `json:"opened_at,omitempty"`
+	LastError           string    `json:"last_error,omitempty"`
+}
+
+// New creates a closed breaker.
+func New(threshold int, probeInterval time.Duration) *Breaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &Breaker{threshold: threshold, probeInterval: probeInterval, now: time.Now}
+}
+
+// Allow reports whether work may proceed. When the breaker is open and the
+// probe interval has elapsed, exactly one caller is allowed through as a probe.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return true
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.probeInterval {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// Success records a successful call and closes the breaker.
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.open = false
+	b.probing = false
+}
+
+// Failure records a failed call, opening the breaker at the threshold or
+// reopening it after a failed probe.
+func (b *Breaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if err != nil {
+		b.lastError = err.Error()
+	}
+	if b.probing || (!b.open && b.failures >= b.threshold) {
+		if !b.open {
+			b.trips++
+		}
+		b.open = true
+		b.probing = false
+		b.openedAt = b.now()
+	}
+}
+
+// Release ends a probe that neither succeeded nor failed (e.g. it was
+// cancelled), letting the next caller probe instead.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+// Stats returns the breaker's current state.
+func (b *Breaker) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st := Stats{ConsecutiveFailures: b.failures, Trips: b.trips, LastError: b.lastError, State: StateClosed}
+	if b.open {
+		st.State = StateOpen
+		st.OpenedAt = b.openedAt
+		if b.probing || b.now().Sub(b.openedAt) >= b.probeInterval {
+			st.State = StateHalfOpen
+		}
+	}
+	return st
+}
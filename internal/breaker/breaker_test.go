@@ -0,0 +1,70 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOpensAfterThresholdAndProbes(t *testing.T) {
+	now := time.Unix(1000, 0)
+	b := New(3, time.Minute)
+	b.now = func() time.Time { return now }
+	boom := errors.New("boom")
+
+	for i := 0; i < 2; i++ {
+		if !b.Allow() {
+			t.Fatalf("closed breaker rejected call %d", i)
+		}
+		b.Failure(boom)
+	}
+	if b.Stats().State != StateClosed {
+		t.Fatalf("opened before threshold")
+	}
+	b.Failure(boom)
+	if st := b.Stats(); st.State != StateOpen || st.Trips != 1 || st.LastError != "boom" {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+	if b.Allow() {
+		t.Fatalf("open breaker allowed a call")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.Allow() {
+		t.Fatalf("probe not allowed after interval")
+	}
+	if b.Allow() {
+		t.Fatalf("second concurrent probe allowed")
+	}
+	b.Failure(boom)
+	if b.Allow() {
+		t.Fatalf("failed probe should reopen the breaker")
+	}
+	if st := b.Stats(); st.Trips != 1 {
+		t.Fatalf("reopening counted as a new trip: %+v", st)
+	}
+
+	now = now.Add(time.Minute)
+	if !b.Allow() {
+		t.Fatalf("probe not allowed after second interval")
+	}
+	b.Success()
+	if st := b.Stats(); st.State != StateClosed || st.ConsecutiveFailures != 0 {
+		t.Fatalf("successful probe did not close breaker: %+v", st)
+	}
+}
+
+func TestReleaseFreesProbe(t *testing.T) {
+	now := time.Unix(1000, 0)
+	b := New(1, time.Second)
+	b.now = func() time.Time { return now }
+	b.Failure(nil)
+	now = now.Add(time.Second)
+	if !b.Allow() {
+		t.Fatalf("probe not allowed")
+	}
+	b.Release()
+	if !b.Allow() {
+		t.Fatalf("released probe not handed to next caller")
+	}
+}
@@ -0,0 +1,285 @@
+package cache
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	manifestName    = "manifest.json"
+	manifestVersion = 1
+	archiveWavDir   = "wav/"
+)
+
+// errBadWav marks an archived wav that is not a wav or not the size its
+// manifest entry records.
+var errBadWav = errors.New("invalid wav")
+
+// errExists marks an archived wav whose key was cached while it was read.
+var errExists = errors.New("already cached")
+
+// Manifest lists the entries contained in an exported cache archive.
+type Manifest struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	Entries []Meta    `json:"entries"`
+}
+
+// ImportResult summarises an Import run.
+type ImportResult struct {
+	Imported int
+	Existing int
+	Skipped  int
+}
+
+// Export writes every cache entry that has metadata to w as a tar archive
+// (gzip-compressed when compress is set). The manifest is written first so
+// importers can validate entries as the wav files stream past. It returns the
+// number of exported entries and how many wavs were left out for lacking metadata.
+func (m Manager) Export(w io.Writer, compress bool) (exported, skipped int, err error) {
+	dirEntries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	manifest := Manifest{Version: manifestVersion, Created: time.Now().UTC()}
+	for _, e := range dirEntries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".wav" {
+			continue
+		}
+		key := strings.TrimSuffix(e.Name(), ".wav")
+		meta, err := m.ReadMeta(key)
+		if err != nil || meta.Key != key {
+			skipped++
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			skipped++
+			continue
+		}
+		meta.Size = info.Size()
+		manifest.Entries = append(manifest.Entries, meta)
+	}
+	sort.Slice(manifest.Entries, func(i, j int) bool { return manifest.Entries[i].Key < manifest.Entries[j].Key })
+
+	if compress {
+		gz := gzip.NewWriter(w)
+		defer func() {
+			if closeErr := gz.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		w = gz
+	}
+	tw := tar.NewWriter(w)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return 0, skipped, err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0o644, Size: int64(len(data)), ModTime: manifest.Created}); err != nil {
+		return 0, skipped, err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return 0, skipped, err
+	}
+
+	for _, meta := range manifest.Entries {
+		if err := m.exportWav(tw, meta); err != nil {
+			return exported, skipped, fmt.Errorf("export %s: %w", meta.Key, err)
+		}
+		exported++
+	}
+
+	if err := tw.Close(); err != nil {
+		return exported, skipped, err
+	}
+	return exported, skipped, nil
+}
+
+func (m Manager) exportWav(tw *tar.Writer, meta Meta) error {
+	f, err := os.Open(m.PathForKey(meta.Key))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hdr := &tar.Header{
+		Name:    archiveWavDir + meta.Key + ".wav",
+		Mode:    0o644,
+		Size:    meta.Size,
+		ModTime: meta.Created,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.CopyN(tw, f, meta.Size)
+	return err
+}
+
+// Import merges an archive produced by Export into the cache directory. Each
+// manifest entry must carry a key matching its voice and text; accept decides
+// whether an otherwise valid entry belongs in this cache (e.g. matching voice and
+// model) and returns a reason when it does not. Entries already present locally
+// are left untouched. Each entry is moved into place under its key lock.
+func (m Manager) Import(ctx context.Context, r io.Reader, accept func(Meta) error) (ImportResult, error) {
+	var res ImportResult
+
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return res, fmt.Errorf("open gzip: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = br
+	}
+	tr := tar.NewReader(r)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return res, fmt.Errorf("read archive: %w", err)
+	}
+	if hdr.Name != manifestName {
+		return res, fmt.Errorf("archive must start with %s, found %s", manifestName, hdr.Name)
+	}
+	var manifest Manifest
+	if err := json.NewDecoder(io.LimitReader(tr, 64<<20)).Decode(&manifest); err != nil {
+		return res, fmt.Errorf("decode manifest: %w", err)
+	}
+	if manifest.Version != manifestVersion {
+		return res, fmt.Errorf("unsupported manifest version %d", manifest.Version)
+	}
+
+	pending := make(map[string]Meta, len(manifest.Entries))
+	for _, meta := range manifest.Entries {
+		if err := validateMeta(meta); err != nil {
+			m.logger.Printf("INFO: import skipping %s: %v", meta.Key, err)
+			res.Skipped++
+			continue
+		}
+		if accept != nil {
+			if err := accept(meta); err != nil {
+				m.logger.Printf("INFO: import skipping %s: %v", meta.Key, err)
+				res.Skipped++
+				continue
+			}
+		}
+		pending[meta.Key] = meta
+	}
+
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return res, fmt.Errorf("read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		key := strings.TrimSuffix(strings.TrimPrefix(name, archiveWavDir), ".wav")
+		meta, ok := pending[key]
+		if !ok || name != archiveWavDir+key+".wav" {
+			continue
+		}
+		delete(pending, key)
+
+		if _, err := os.Stat(m.PathForKey(key)); err == nil {
+			res.Existing++
+			continue
+		}
+		if err := m.importWav(ctx, tr, meta); errors.Is(err, errBadWav) {
+			m.logger.Printf("INFO: import skipping %s: %v", key, err)
+			res.Skipped++
+			continue
+		} else if errors.Is(err, errExists) {
+			res.Existing++
+			continue
+		} else if err != nil {
+			return res, fmt.Errorf("import %s: %w", key, err)
+		}
+		res.Imported++
+	}
+
+	// Manifest entries whose wav never showed up.
+	res.Skipped += len(pending)
+	return res, nil
+}
+
+// importWav stores one archived wav. It checks the RIFF/WAVE header, that the
+// data is as long as the manifest says and that the RIFF chunk is not cut
+// short before the file is renamed into place.
+func (m Manager) importWav(ctx context.Context, r io.Reader, meta Meta) error {
+	hdr := make([]byte, 12)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return fmt.Errorf("%w: short header", errBadWav)
+	}
+	if string(hdr[0:4]) != "RIFF" || string(hdr[8:12]) != "WAVE" {
+		return fmt.Errorf("%w: not a RIFF/WAVE file", errBadWav)
+	}
+
+	outPath := m.PathForKey(meta.Key)
+	tmp, err := os.CreateTemp(m.dir, filepath.Base(outPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, io.MultiReader(bytes.NewReader(hdr), r))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if meta.Size > 0 && n != meta.Size {
+		return fmt.Errorf("%w: %d bytes, manifest says %d", errBadWav, n, meta.Size)
+	}
+	// Streamed wavs may carry 0xFFFFFFFF (unknown length) instead of a size.
+	if riff := binary.LittleEndian.Uint32(hdr[4:8]); riff != 0xFFFFFFFF && int64(riff)+8 > n {
+		return fmt.Errorf("%w: truncated, RIFF chunk needs %d bytes, have %d", errBadWav, int64(riff)+8, n)
+	}
+
+	unlock, err := m.LockKey(ctx, meta.Key)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if _, err := os.Stat(outPath); err == nil {
+		return errExists
+	}
+	if err := os.Rename(tmp.Name(), outPath); err != nil {
+		return err
+	}
+	return m.WriteMeta(meta)
+}
+
+func validateMeta(meta Meta) error {
+	if !ValidKey(meta.Key) {
+		return errors.New("invalid key")
+	}
+	if BuildKey(meta.Voice, meta.Text, meta.Params) != meta.Key {
+		return errors.New("key does not match voice, text and params")
+	}
+	return nil
+}
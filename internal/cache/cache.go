@@ -4,10 +4,12 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -16,6 +18,7 @@ type Manager struct {
 	dir      string
 	maxBytes int64
 	logger   *log.Logger
+	onEvict  func(key string, size int64)
 }
 
 // NewManager creates a cache manager rooted at dir with a size limit.
@@ -26,12 +29,66 @@ func NewManager(dir string, maxBytes int64, logger *log.Logger) Manager {
 	return Manager{dir: dir, maxBytes: maxBytes, logger: logger}
 }
 
-// BuildKey returns a sha256 hex digest for the voice/text pair.
-func BuildKey(voiceID, text string) string {
-	sum := sha256.Sum256([]byte(voiceID + "::" + text))
+// WithEvictHook returns a copy of m that calls fn for every entry EnforceLimit evicts.
+func (m Manager) WithEvictHook(fn func(key string, size int64)) Manager {
+	m.onEvict = fn
+	return m
+}
+
+// MaxBytes returns the configured size limit.
+func (m Manager) MaxBytes() int64 {
+	return m.maxBytes
+}
+
+// Usage returns the total size and number of cached wav files.
+func (m Manager) Usage() (int64, int, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	var total int64
+	var count int
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".wav" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		total += info.Size()
+		count++
+	}
+	return total, count, nil
+}
+
+// BuildKey returns a sha256 hex digest for the voice/text pair. Non-empty
+// params (canonical synthesis settings) are appended, so entries without them
+// keep their original keys.
+func BuildKey(voiceID, text string, params ...string) string {
+	data := voiceID + "::" + text
+	for _, p := range params {
+		if p != "" {
+			data += "::" + p
+		}
+	}
+	sum := sha256.Sum256([]byte(data))
 	return hex.EncodeToString(sum[:])
 }
 
+// ValidKey reports whether key looks like a digest produced by BuildKey.
+func ValidKey(key string) bool {
+	if len(key) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range key {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
 // PathForKey returns the wav file path for a cache key.
 func (m Manager) PathForKey(key string) string {
 	return filepath.Join(m.dir, key+".wav")
@@ -43,8 +100,43 @@ func (m Manager) Touch(path string) {
 	_ = os.Chtimes(path, now, now)
 }
 
-// EnforceLimit deletes oldest wav files until total size is within the limit.
+// Pin marks key as exempt from eviction.
+func (m Manager) Pin(key string) error {
+	return os.WriteFile(filepath.Join(m.dir, key+".pin"), nil, 0o644)
+}
+
+// Unpin makes key evictable again.
+func (m Manager) Unpin(key string) error {
+	if err := os.Remove(filepath.Join(m.dir, key+".pin")); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Pinned lists the pinned keys.
+func (m Manager) Pinned() ([]string, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".pin" {
+			keys = append(keys, strings.TrimSuffix(e.Name(), ".pin"))
+		}
+	}
+	return keys, nil
+}
+
+// EnforceLimit deletes oldest unpinned wav files (and their metadata) until total size is within the limit.
+// Eviction passes hold an advisory lock so processes sharing the directory do not race.
 func (m Manager) EnforceLimit() error {
+	unlock, err := m.lockEviction()
+	if err != nil {
+		return fmt.Errorf("acquire eviction lock: %w", err)
+	}
+	defer unlock()
+
 	entries, err := os.ReadDir(m.dir)
 	if err != nil {
 		return err
@@ -58,11 +150,16 @@ func (m Manager) EnforceLimit() error {
 
 	var files []fileInfo
 	var total int64
+	pinned := make(map[string]bool)
 
 	for _, e := range entries {
 		if e.IsDir() {
 			continue
 		}
+		if filepath.Ext(e.Name()) == ".pin" {
+			pinned[strings.TrimSuffix(e.Name(), ".pin")] = true
+			continue
+		}
 		if filepath.Ext(e.Name()) != ".wav" {
 			continue
 		}
@@ -89,12 +186,19 @@ func (m Manager) EnforceLimit() error {
 		if total <= m.maxBytes {
 			break
 		}
+		if pinned[strings.TrimSuffix(filepath.Base(f.path), ".wav")] {
+			continue
+		}
 		if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
 			m.logger.Printf("ERROR: failed to remove cached file %s: %v", f.path, err)
 			continue
 		}
+		_ = os.Remove(strings.TrimSuffix(f.path, ".wav") + ".json")
 		total -= f.size
 		m.logger.Printf("INFO: evicted %s (size=%d) to enforce cache limit", filepath.Base(f.path), f.size)
+		if m.onEvict != nil {
+			m.onEvict(strings.TrimSuffix(filepath.Base(f.path), ".wav"), f.size)
+		}
 	}
 
 	return nil
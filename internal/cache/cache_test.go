@@ -1,10 +1,20 @@
 package cache
 
 import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -64,5 +74,332 @@ func TestEnforceLimitEvictsOldest(t *testing.T) {
 	}
 }
 
+func TestEnforceLimitSkipsPinned(t *testing.T) {
+	dir := t.TempDir()
+	manager := NewManager(dir, 5, logDiscard)
+
+	for i, name := range []string{"old", "new"} {
+		p := filepath.Join(dir, name+".wav")
+		if err := os.WriteFile(p, make([]byte, 5), 0o644); err != nil {
+			t.Fatalf("write file: %v", err)
+		}
+		mod := time.Now().Add(time.Duration(i-2) * time.Hour)
+		if err := os.Chtimes(p, mod, mod); err != nil {
+			t.Fatalf("chtimes: %v", err)
+		}
+	}
+	if err := manager.Pin("old"); err != nil {
+		t.Fatalf("pin: %v", err)
+	}
+
+	if err := manager.EnforceLimit(); err != nil {
+		t.Fatalf("enforce: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "old.wav")); err != nil {
+		t.Fatalf("pinned entry evicted: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "new.wav")); !os.IsNotExist(err) {
+		t.Fatalf("unpinned entry should be evicted")
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	src := NewManager(t.TempDir(), 1<<20, logDiscard)
+	for _, e := range []struct{ voice, text string }{
+		{"amy", "hello world"},
+		{"amy", "good morning"},
+		{"ryan", "other voice"},
+	} {
+		key := BuildKey(e.voice, e.text)
+		if err := os.WriteFile(src.PathForKey(key), testWav(e.text), 0o644); err != nil {
+			t.Fatalf("write wav: %v", err)
+		}
+		if err := src.WriteMeta(Meta{Key: key, Text: e.text, Voice: e.voice, Model: "m1"}); err != nil {
+			t.Fatalf("write meta: %v", err)
+		}
+	}
+	// A wav without metadata cannot be validated and is left out.
+	if err := os.WriteFile(src.PathForKey(BuildKey("amy", "orphan")), []byte("RIFF"), 0o644); err != nil {
+		t.Fatalf("write orphan: %v", err)
+	}
+
+	var buf bytes.Buffer
+	exported, skipped, err := src.Export(&buf, true)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if exported != 3 || skipped != 1 {
+		t.Fatalf("expected 3 exported/1 skipped, got %d/%d", exported, skipped)
+	}
+
+	dst := NewManager(t.TempDir(), 1<<20, logDiscard)
+	existing := BuildKey("amy", "good morning")
+	if err := os.WriteFile(dst.PathForKey(existing), []byte("local"), 0o644); err != nil {
+		t.Fatalf("write existing: %v", err)
+	}
+
+	res, err := dst.Import(context.Background(), &buf, func(m Meta) error {
+		if m.Voice != "amy" {
+			return errors.New("voice mismatch")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if res.Imported != 1 || res.Existing != 1 || res.Skipped != 1 {
+		t.Fatalf("unexpected import result %+v", res)
+	}
+
+	key := BuildKey("amy", "hello world")
+	if data, err := os.ReadFile(dst.PathForKey(key)); err != nil || !bytes.Equal(data, testWav("hello world")) {
+		t.Fatalf("imported wav mismatch: %q %v", data, err)
+	}
+	if meta, err := dst.ReadMeta(key); err != nil || meta.Text != "hello world" {
+		t.Fatalf("imported meta mismatch: %+v %v", meta, err)
+	}
+	if data, _ := os.ReadFile(dst.PathForKey(existing)); string(data) != "local" {
+		t.Fatalf("existing entry overwritten")
+	}
+	if _, err := os.Stat(dst.PathForKey(BuildKey("ryan", "other voice"))); !os.IsNotExist(err) {
+		t.Fatalf("rejected entry should not be imported")
+	}
+}
+
+func TestBuildKeyParams(t *testing.T) {
+	plain := BuildKey("amy", "hello")
+	if BuildKey("amy", "hello", "") != plain {
+		t.Fatalf("empty params must not change the key")
+	}
+	slow := BuildKey("amy", "hello", "length_scale=1.5")
+	if slow == plain || slow == BuildKey("amy", "hello", "length_scale=0.8") {
+		t.Fatalf("params must produce distinct keys")
+	}
+}
+
+func TestImportRejectsTamperedKey(t *testing.T) {
+	src := NewManager(t.TempDir(), 1<<20, logDiscard)
+	key := BuildKey("amy", "hello")
+	if err := os.WriteFile(src.PathForKey(key), testWav("hello"), 0o644); err != nil {
+		t.Fatalf("write wav: %v", err)
+	}
+	if err := src.WriteMeta(Meta{Key: key, Text: "goodbye", Voice: "amy"}); err != nil {
+		t.Fatalf("write meta: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, _, err := src.Export(&buf, false); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	dst := NewManager(t.TempDir(), 1<<20, logDiscard)
+	res, err := dst.Import(context.Background(), &buf, nil)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if res.Imported != 0 || res.Skipped != 1 {
+		t.Fatalf("expected tampered entry skipped, got %+v", res)
+	}
+}
+
+func TestImportWaitsForKeyLock(t *testing.T) {
+	src := NewManager(t.TempDir(), 1<<20, logDiscard)
+	key := BuildKey("amy", "hello")
+	if err := os.WriteFile(src.PathForKey(key), testWav("hello"), 0o644); err != nil {
+		t.Fatalf("write wav: %v", err)
+	}
+	if err := src.WriteMeta(Meta{Key: key, Text: "hello", Voice: "amy"}); err != nil {
+		t.Fatalf("write meta: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, _, err := src.Export(&buf, false); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	dst := NewManager(t.TempDir(), 1<<20, logDiscard)
+	unlock, err := dst.LockKey(context.Background(), key)
+	if err != nil {
+		t.Fatalf("lock: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = dst.Import(ctx, bytes.NewReader(buf.Bytes()), nil)
+	unlock()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected import to wait for the key lock, got %v", err)
+	}
+	if _, err := os.Stat(dst.PathForKey(key)); err == nil {
+		t.Fatalf("wav moved into place without the key lock")
+	}
+
+	res, err := dst.Import(context.Background(), bytes.NewReader(buf.Bytes()), nil)
+	if err != nil || res.Imported != 1 {
+		t.Fatalf("import after unlock: %+v %v", res, err)
+	}
+}
+
+func TestImportRejectsCorruptWavs(t *testing.T) {
+	src := NewManager(t.TempDir(), 1<<20, logDiscard)
+	truncated := testWav("truncated")
+	binary.LittleEndian.PutUint32(truncated[4:8], uint32(len(truncated)+100))
+	wavs := map[string][]byte{
+		"good":      testWav("good"),
+		"not a wav": []byte("<html>404 not found</html>"),
+		"truncated": truncated,
+	}
+	for text, data := range wavs {
+		key := BuildKey("amy", text)
+		if err := os.WriteFile(src.PathForKey(key), data, 0o644); err != nil {
+			t.Fatalf("write wav: %v", err)
+		}
+		if err := src.WriteMeta(Meta{Key: key, Text: text, Voice: "amy"}); err != nil {
+			t.Fatalf("write meta: %v", err)
+		}
+	}
+	var buf bytes.Buffer
+	if _, _, err := src.Export(&buf, false); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	dst := NewManager(t.TempDir(), 1<<20, logDiscard)
+	res, err := dst.Import(context.Background(), &buf, nil)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if res.Imported != 1 || res.Skipped != 2 {
+		t.Fatalf("expected 1 imported/2 skipped, got %+v", res)
+	}
+	for text := range wavs {
+		_, err := os.Stat(dst.PathForKey(BuildKey("amy", text)))
+		if (text == "good") != (err == nil) {
+			t.Fatalf("%s: unexpected presence after import: %v", text, err)
+		}
+	}
+}
+
+func TestImportChecksManifestSize(t *testing.T) {
+	key := BuildKey("amy", "hello")
+	manifest, _ := json.Marshal(Manifest{Version: manifestVersion, Entries: []Meta{{Key: key, Text: "hello", Voice: "amy", Size: 100}}})
+	wav := testWav("hello")
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range []struct {
+		name string
+		data []byte
+	}{{manifestName, manifest}, {archiveWavDir + key + ".wav", wav}} {
+		if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0o644, Size: int64(len(f.data))}); err != nil {
+			t.Fatalf("write header: %v", err)
+		}
+		if _, err := tw.Write(f.data); err != nil {
+			t.Fatalf("write data: %v", err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar: %v", err)
+	}
+
+	dst := NewManager(t.TempDir(), 1<<20, logDiscard)
+	res, err := dst.Import(context.Background(), &buf, nil)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if res.Imported != 0 || res.Skipped != 1 {
+		t.Fatalf("expected size mismatch skipped, got %+v", res)
+	}
+	if _, err := os.Stat(dst.PathForKey(key)); !os.IsNotExist(err) {
+		t.Fatalf("mis-sized wav imported")
+	}
+}
+
+// testWav returns a minimal wav whose data chunk holds text.
+func testWav(text string) []byte {
+	hdr := []byte("RIFF\x00\x00\x00\x00WAVEdata\x00\x00\x00\x00")
+	binary.LittleEndian.PutUint32(hdr[4:8], uint32(len(hdr)-8+len(text)))
+	binary.LittleEndian.PutUint32(hdr[16:20], uint32(len(text)))
+	return append(hdr, text...)
+}
+
+// TestMultiProcessWriters runs several processes against one cache directory.
+// Each increments a shared counter under LockKey and runs eviction passes; a lost
+// update or a failed eviction pass means the cross-process locking is broken.
+func TestMultiProcessWriters(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		t.Skip("flock coordination is unix-only")
+	}
+	dir := t.TempDir()
+	const procs, iterations = 4, 25
+
+	var cmds []*exec.Cmd
+	for i := 0; i < procs; i++ {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestHelperProcess$")
+		cmd.Env = append(os.Environ(), "CACHE_HELPER_DIR="+dir, "CACHE_HELPER_ID="+strconv.Itoa(i), "CACHE_HELPER_ITER="+strconv.Itoa(iterations))
+		var out bytes.Buffer
+		cmd.Stdout, cmd.Stderr = &out, &out
+		if err := cmd.Start(); err != nil {
+			t.Fatalf("start helper: %v", err)
+		}
+		t.Cleanup(func() {
+			if t.Failed() {
+				t.Logf("helper output: %s", out.String())
+			}
+		})
+		cmds = append(cmds, cmd)
+	}
+	for _, cmd := range cmds {
+		if err := cmd.Wait(); err != nil {
+			t.Fatalf("helper failed: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "counter"))
+	if err != nil {
+		t.Fatalf("read counter: %v", err)
+	}
+	if got := strings.TrimSpace(string(data)); got != strconv.Itoa(procs*iterations) {
+		t.Fatalf("lost updates: counter=%s want %d", got, procs*iterations)
+	}
+	total, _, err := NewManager(dir, 0, logDiscard).Usage()
+	if err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	if total > 40 {
+		t.Fatalf("cache over limit after concurrent eviction: %d bytes", total)
+	}
+}
+
+func TestHelperProcess(t *testing.T) {
+	dir := os.Getenv("CACHE_HELPER_DIR")
+	if dir == "" {
+		return
+	}
+	id := os.Getenv("CACHE_HELPER_ID")
+	iterations, _ := strconv.Atoi(os.Getenv("CACHE_HELPER_ITER"))
+	m := NewManager(dir, 40, logDiscard)
+	counter := filepath.Join(dir, "counter")
+
+	for i := 0; i < iterations; i++ {
+		unlock, err := m.LockKey(context.Background(), "counter")
+		if err != nil {
+			t.Fatalf("lock: %v", err)
+		}
+		n := 0
+		if data, err := os.ReadFile(counter); err == nil {
+			n, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+		}
+		time.Sleep(time.Millisecond)
+		if err := os.WriteFile(counter, []byte(strconv.Itoa(n+1)), 0o644); err != nil {
+			t.Fatalf("write counter: %v", err)
+		}
+		unlock()
+
+		key := BuildKey(id, strconv.Itoa(i))
+		if err := os.WriteFile(m.PathForKey(key), make([]byte, 10), 0o644); err != nil {
+			t.Fatalf("write wav: %v", err)
+		}
+		if err := m.EnforceLimit(); err != nil {
+			t.Fatalf("enforce: %v", err)
+		}
+	}
+}
+
 // logDiscard is a logger that drops output; keeps Manager construction simple in tests.
 var logDiscard = log.New(io.Discard, "", 0)
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	lockDirName   = ".locks"
+	keyLockShards = 256
+)
+
+// LockKey serialises writers of key across goroutines and processes sharing the
+// cache directory. Keys are striped over a fixed set of lock files so the
+// directory does not fill with one lock per entry. Call the returned func to unlock.
+func (m Manager) LockKey(ctx context.Context, key string) (func(), error) {
+	var shard uint64
+	if ValidKey(key) {
+		shard, _ = strconv.ParseUint(key[:2], 16, 8)
+	} else {
+		for _, c := range key {
+			shard = (shard*31 + uint64(c)) % keyLockShards
+		}
+	}
+	dir := filepath.Join(m.dir, lockDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return lockFile(ctx, filepath.Join(dir, fmt.Sprintf("key-%02x.lock", shard)))
+}
+
+// lockEviction takes the directory-wide lock held during eviction passes. The
+// cache directory itself is locked, so no extra file is needed.
+func (m Manager) lockEviction() (func(), error) {
+	return lockFile(context.Background(), m.dir)
+}
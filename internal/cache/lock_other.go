@@ -0,0 +1,32 @@
+//go:build !unix
+
+package cache
+
+import (
+	"context"
+	"sync"
+)
+
+var (
+	fallbackMu    sync.Mutex
+	fallbackLocks = map[string]chan struct{}{}
+)
+
+// lockFile serialises holders of path within this process only; platforms
+// without flock get no cross-process coordination.
+func lockFile(ctx context.Context, path string) (func(), error) {
+	fallbackMu.Lock()
+	ch, ok := fallbackLocks[path]
+	if !ok {
+		ch = make(chan struct{}, 1)
+		fallbackLocks[path] = ch
+	}
+	fallbackMu.Unlock()
+
+	select {
+	case ch <- struct{}{}:
+		return func() { <-ch }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Meta describes the text and synthesis settings behind a cached wav.
+type Meta struct {
+	Key   string `json:"key"`
+	Text  string `json:"text"`
+	Voice string `json:"voice"`
+	Model string `json:"model"`
+	// Params is the canonical synthesis settings string included in Key.
+	Params  string    `json:"params,omitempty"`
+	Size    int64     `json:"size,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+// DialogueVoice is the Meta.Voice of dialogue composites. Their Text is the
+// script the key was built from and their Model is a DialogueModel.
+const DialogueVoice = "dialogue"
+
+// DialogueModel encodes the model of each voice in a dialogue as sorted
+// "voice=model" pairs separated by commas.
+func DialogueModel(models map[string]string) string {
+	pairs := make([]string, 0, len(models))
+	for voice, model := range models {
+		pairs = append(pairs, voice+"="+model)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// ParseDialogueModel reverses DialogueModel.
+func ParseDialogueModel(s string) map[string]string {
+	models := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		if voice, model, ok := strings.Cut(pair, "="); ok {
+			models[voice] = model
+		}
+	}
+	return models
+}
+
+// MetaPathForKey returns the metadata sidecar path for a cache key.
+func (m Manager) MetaPathForKey(key string) string {
+	return filepath.Join(m.dir, key+".json")
+}
+
+// WriteMeta stores the sidecar for meta.Key atomically.
+func (m Manager) WriteMeta(meta Meta) error {
+	if meta.Created.IsZero() {
+		meta.Created = time.Now().UTC()
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	path := m.MetaPathForKey(meta.Key)
+	tmp, err := os.CreateTemp(m.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// ReadMeta loads the sidecar for key.
+func (m Manager) ReadMeta(key string) (Meta, error) {
+	var meta Meta
+	data, err := os.ReadFile(m.MetaPathForKey(key))
+	if err != nil {
+		return meta, err
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return meta, fmt.Errorf("decode %s: %w", key, err)
+	}
+	return meta, nil
+}
+
+// ModelFingerprint returns a short content digest identifying a model file, so
+// cache entries produced by different models can be told apart.
+func ModelFingerprint(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
@@ -5,14 +5,33 @@ import (
 	"io"
 	"os"
 	"strings"
+
+	"github.com/venkytv/tts-cached/internal/textformat"
 )
 
 // ResolveText chooses text input based on flags and args.
 // - If filePath is "-", read from stdin.
 // - If filePath is a non-empty path, read the file contents.
 // - Otherwise, use the joined args.
-func ResolveText(filePath string, args []string, stdin io.Reader) (string, error) {
+//
+// The text is then converted from format (see textformat.Convert). An empty
+// format is guessed from the file's extension, defaulting to plain text.
+func ResolveText(filePath, format string, args []string, stdin io.Reader) (string, error) {
 	filePath = strings.TrimSpace(filePath)
+	if format == "" && filePath != "-" {
+		format = textformat.ForFile(filePath)
+	}
+	text, err := readText(filePath, args, stdin)
+	if err != nil {
+		return "", err
+	}
+	if text, err = textformat.Convert(text, format); err != nil {
+		return "", err
+	}
+	return normalize(text)
+}
+
+func readText(filePath string, args []string, stdin io.Reader) (string, error) {
 	if filePath == "-" {
 		if stdin == nil {
 			return "", errors.New("stdin unavailable")
@@ -21,7 +40,7 @@ func ResolveText(filePath string, args []string, stdin io.Reader) (string, error
 		if err != nil {
 			return "", err
 		}
-		return normalize(string(b))
+		return string(b), nil
 	}
 
 	if filePath != "" {
@@ -29,11 +48,11 @@ func ResolveText(filePath string, args []string, stdin io.Reader) (string, error
 		if err != nil {
 			return "", err
 		}
-		return normalize(string(b))
+		return string(b), nil
 	}
 
 	if len(args) > 0 {
-		return normalize(strings.Join(args, " "))
+		return strings.Join(args, " "), nil
 	}
 
 	if stdin != nil {
@@ -41,7 +60,7 @@ func ResolveText(filePath string, args []string, stdin io.Reader) (string, error
 		if err != nil {
 			return "", err
 		}
-		return normalize(string(b))
+		return string(b), nil
 	}
 
 	return "", errors.New("no input provided")
@@ -8,7 +8,7 @@ import (
 )
 
 func TestResolveTextFromArgs(t *testing.T) {
-	text, err := ResolveText("", []string{"hello", "world"}, nil)
+	text, err := ResolveText("", "", []string{"hello", "world"}, nil)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -24,7 +24,7 @@ func TestResolveTextFromFile(t *testing.T) {
 		t.Fatalf("write temp file: %v", err)
 	}
 
-	text, err := ResolveText(fp, nil, nil)
+	text, err := ResolveText(fp, "", nil, nil)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -35,7 +35,7 @@ func TestResolveTextFromFile(t *testing.T) {
 
 func TestResolveTextFromStdin(t *testing.T) {
 	stdin := bytes.NewBufferString("from stdin")
-	text, err := ResolveText("-", nil, stdin)
+	text, err := ResolveText("-", "", nil, stdin)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -46,7 +46,7 @@ func TestResolveTextFromStdin(t *testing.T) {
 
 func TestResolveTextFromStdinDefault(t *testing.T) {
 	stdin := bytes.NewBufferString("fallback stdin")
-	text, err := ResolveText("", nil, stdin)
+	text, err := ResolveText("", "", nil, stdin)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -56,7 +56,45 @@ func TestResolveTextFromStdinDefault(t *testing.T) {
 }
 
 func TestResolveTextEmpty(t *testing.T) {
-	if _, err := ResolveText("", nil, nil); err == nil {
+	if _, err := ResolveText("", "", nil, nil); err == nil {
 		t.Fatalf("expected error for empty input")
 	}
 }
+
+func TestResolveTextConvertsFormat(t *testing.T) {
+	text, err := ResolveText("", "markdown", []string{"# Title\n\n- [docs](https://example.com)"}, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if text != "Title.\ndocs." {
+		t.Fatalf("unexpected text: %q", text)
+	}
+
+	if _, err := ResolveText("", "rtf", []string{"hello"}, nil); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+	if _, err := ResolveText("", "html", []string{"<script>x</script>"}, nil); err == nil {
+		t.Fatalf("expected error for html without text")
+	}
+}
+
+func TestResolveTextFormatFromExtension(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "mail.html")
+	if err := os.WriteFile(fp, []byte("<p>Hi <b>there</b></p>"), 0o644); err != nil {
+		t.Fatalf("write temp file: %v", err)
+	}
+
+	text, err := ResolveText(fp, "", nil, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if text != "Hi there." {
+		t.Fatalf("unexpected text: %q", text)
+	}
+
+	// An explicit format wins over the extension.
+	if text, err = ResolveText(fp, "plain", nil, nil); err != nil || text != "<p>Hi <b>there</b></p>" {
+		t.Fatalf("unexpected plain text: %q, %v", text, err)
+	}
+}
@@ -2,23 +2,92 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/unicodetext"
 )
 
 // Config holds environment-driven settings for the service.
 type Config struct {
-	PiperExec     string
-	PiperModel    string
-	PiperFlags    []string
-	CacheDir      string
-	ListenAddr    string
-	PlayCmd       string
-	PlayArgs      []string
-	VoiceID       string
-	CacheMaxBytes int64
+	PiperExec       string
+	PiperModel      string
+	PiperFlags      []string
+	PiperWorkers    int
+	CacheDir        string
+	ListenAddr      string
+	PlayCmd         string
+	PlayArgs        []string
+	StreamPlayback  bool
+	StreamPlayCmd   string
+	StreamPlayArgs  []string
+	PiperSampleRate int
+	VoiceID         string
+	CacheMaxBytes   int64
+	Peers           []string
+	PeerTimeout     time.Duration
+	PeerMaxBytes    int64
+
+	SynthConcurrency int
+	SynthQueue       int
+
+	SentenceCache bool
+	SentenceGap   time.Duration
+
+	// MaxBodyBytes bounds request bodies and MaxTextChars the text of a
+	// request once converted and whitespace-collapsed; larger requests are
+	// rejected with 413.
+	MaxBodyBytes int64
+	MaxTextChars int
+
+	// SynthTimeout bounds a single engine run; 0 leaves it unbounded. Texts
+	// longer than MaxUnitChars are synthesized in units of at most that many
+	// characters and joined; unless set, MaxUnitChars is derived from
+	// SynthTimeout.
+	SynthTimeout time.Duration
+	MaxUnitChars int
+
+	// LangDetect picks a voice by Accept-Language or detected text language
+	// when a request names no voice.
+	LangDetect bool
+
+	BreakerThreshold     int
+	BreakerProbeInterval time.Duration
+
+	WarmupFile        string
+	WarmupConcurrency int
+	WarmupBlock       bool
+
+	// LexiconFile names a JSON pronunciation lexicon, reloaded when it changes.
+	LexiconFile string
+
+	// FilterCmd, when set, rewrites request text: it reads the text on stdin
+	// and prints the replacement. FilterFailure is FilterSkip or FilterReject.
+	FilterCmd     string
+	FilterArgs    []string
+	FilterTimeout time.Duration
+	FilterFailure string
+
+	// Emoji is how emoji are spoken: unicodetext.EmojiVerbalize, EmojiStrip
+	// or EmojiKeep. Voices may override it.
+	Emoji string
+
+	// Redact lists built-in redaction detectors (or "all"); RedactFile holds
+	// extra patterns, one regular expression per line. Matches are spoken as
+	// RedactPlaceholder.
+	Redact            []string
+	RedactFile        string
+	RedactPlaceholder string
+
+	// VoicesFile names a JSON file defining engines and voices. Without it a
+	// single Piper engine is built from the PIPER_* settings and serves VoiceID.
+	VoicesFile string
+	Engines    map[string]EngineConfig
+	Voices     map[string]VoiceConfig
 }
 
 const (
@@ -26,8 +95,39 @@ const (
 	defaultCacheDir      = "/var/cache/tts-cached"
 	defaultListenAddr    = "127.0.0.1:4410" // 44.1 kHz-inspired port
 	defaultPlayCmd       = "/usr/bin/aplay"
+	defaultEspeakExec    = "espeak-ng"
 	defaultVoiceID       = "default"
+	defaultStreamArgs    = "-q -t raw -f S16_LE -c 1 -r {rate}"
+	defaultSampleRate    = 22050
 	defaultCacheMaxBytes = int64(536870912) // 512 MiB
+	defaultPeerTimeout   = 2 * time.Second
+	defaultPeerMaxBytes  = int64(16777216) // 16 MiB
+
+	defaultSynthQueue        = 8
+	defaultSentenceGap       = 250 * time.Millisecond
+	defaultMaxBodyBytes      = int64(1048576) // 1 MiB
+	defaultMaxTextChars      = 20000
+	defaultSynthTimeout      = 60 * time.Second
+	defaultBreakerThreshold  = 3
+	defaultBreakerProbe      = 30 * time.Second
+	defaultWarmupConcurrency = 2
+	defaultFilterTimeout     = 5 * time.Second
+	defaultRedactPlaceholder = "redacted"
+)
+
+// unitCharsPerSecond is a conservative synthesis speed for slow hardware,
+// used to size synthesis units to SynthTimeout.
+const unitCharsPerSecond = 15
+
+// NoSynthQueue as an override's SynthQueue allows no waiting requests; a zero
+// override keeps the environment's SYNTH_QUEUE.
+const NoSynthQueue = -1
+
+// Filter failure policies: FilterSkip synthesizes the unfiltered text,
+// FilterReject fails the request.
+const (
+	FilterSkip   = "skip"
+	FilterReject = "reject"
 )
 
 // Load reads configuration from environment variables and ensures the cache directory exists.
@@ -38,25 +138,82 @@ func Load() (Config, error) {
 // LoadWithOverrides merges environment variables with explicit overrides and ensures the cache directory exists.
 func LoadWithOverrides(override Config) (Config, error) {
 	cfg := Config{
-		PiperExec:     getEnv("PIPER_EXEC", defaultPiperExec),
-		PiperModel:    strings.TrimSpace(os.Getenv("PIPER_MODEL")),
-		CacheDir:      getEnv("CACHE_DIR", defaultCacheDir),
-		ListenAddr:    getEnv("LISTEN_ADDR", defaultListenAddr),
-		PlayCmd:       getEnv("PLAY_CMD", defaultPlayCmd),
-		VoiceID:       getEnv("VOICE_ID", defaultVoiceID),
-		CacheMaxBytes: defaultCacheMaxBytes,
+		PiperExec:       getEnv("PIPER_EXEC", defaultPiperExec),
+		PiperModel:      strings.TrimSpace(os.Getenv("PIPER_MODEL")),
+		CacheDir:        getEnv("CACHE_DIR", defaultCacheDir),
+		ListenAddr:      getEnv("LISTEN_ADDR", defaultListenAddr),
+		PlayCmd:         getEnv("PLAY_CMD", defaultPlayCmd),
+		VoiceID:         getEnv("VOICE_ID", defaultVoiceID),
+		StreamPlayCmd:   getEnv("STREAM_PLAY_CMD", defaultPlayCmd),
+		StreamPlayArgs:  strings.Fields(getEnv("STREAM_PLAY_ARGS", defaultStreamArgs)),
+		PiperSampleRate: defaultSampleRate,
+		CacheMaxBytes:   defaultCacheMaxBytes,
+		PeerTimeout:     defaultPeerTimeout,
+		PeerMaxBytes:    defaultPeerMaxBytes,
+
+		SynthQueue:  defaultSynthQueue,
+		SentenceGap: defaultSentenceGap,
+
+		MaxBodyBytes: defaultMaxBodyBytes,
+		MaxTextChars: defaultMaxTextChars,
+		SynthTimeout: defaultSynthTimeout,
+
+		BreakerThreshold:     defaultBreakerThreshold,
+		BreakerProbeInterval: defaultBreakerProbe,
+
+		WarmupFile:        strings.TrimSpace(os.Getenv("WARMUP_FILE")),
+		WarmupConcurrency: defaultWarmupConcurrency,
+
+		LexiconFile: strings.TrimSpace(os.Getenv("LEXICON_FILE")),
+
+		FilterCmd:     strings.TrimSpace(os.Getenv("FILTER_CMD")),
+		FilterTimeout: defaultFilterTimeout,
+		FilterFailure: strings.TrimSpace(getEnv("FILTER_FAILURE", FilterSkip)),
+
+		Emoji: strings.TrimSpace(getEnv("EMOJI", unicodetext.EmojiVerbalize)),
+
+		Redact:            SplitList(os.Getenv("REDACT")),
+		RedactFile:        strings.TrimSpace(os.Getenv("REDACT_FILE")),
+		RedactPlaceholder: strings.TrimSpace(getEnv("REDACT_PLACEHOLDER", defaultRedactPlaceholder)),
+
+		VoicesFile: strings.TrimSpace(os.Getenv("VOICES_FILE")),
 	}
+	voiceSet := strings.TrimSpace(os.Getenv("VOICE_ID")) != "" || override.VoiceID != ""
 
 	if args := strings.TrimSpace(os.Getenv("PIPER_FLAGS")); args != "" {
 		cfg.PiperFlags = strings.Fields(args)
 	}
 
+	if workersStr := strings.TrimSpace(os.Getenv("PIPER_WORKERS")); workersStr != "" {
+		val, err := strconv.Atoi(workersStr)
+		if err != nil || val < 0 {
+			return Config{}, errors.New("invalid PIPER_WORKERS; must be non-negative integer")
+		}
+		cfg.PiperWorkers = val
+	}
+
 	if args := strings.TrimSpace(os.Getenv("PLAY_ARGS")); args != "" {
 		cfg.PlayArgs = strings.Fields(args)
 	} else {
 		cfg.PlayArgs = []string{}
 	}
 
+	if streamStr := strings.TrimSpace(os.Getenv("STREAM_PLAYBACK")); streamStr != "" {
+		val, err := strconv.ParseBool(streamStr)
+		if err != nil {
+			return Config{}, errors.New("invalid STREAM_PLAYBACK; must be true or false")
+		}
+		cfg.StreamPlayback = val
+	}
+
+	if rateStr := strings.TrimSpace(os.Getenv("PIPER_SAMPLE_RATE")); rateStr != "" {
+		val, err := strconv.Atoi(rateStr)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid PIPER_SAMPLE_RATE; must be positive integer")
+		}
+		cfg.PiperSampleRate = val
+	}
+
 	if maxBytesStr := strings.TrimSpace(os.Getenv("CACHE_MAX_BYTES")); maxBytesStr != "" {
 		val, err := strconv.ParseInt(maxBytesStr, 10, 64)
 		if err != nil || val <= 0 {
@@ -65,6 +222,142 @@ func LoadWithOverrides(override Config) (Config, error) {
 		cfg.CacheMaxBytes = val
 	}
 
+	if peers := strings.TrimSpace(os.Getenv("PEERS")); peers != "" {
+		cfg.Peers = SplitList(peers)
+	}
+
+	if timeoutStr := strings.TrimSpace(os.Getenv("PEER_TIMEOUT")); timeoutStr != "" {
+		val, err := time.ParseDuration(timeoutStr)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid PEER_TIMEOUT; must be positive duration")
+		}
+		cfg.PeerTimeout = val
+	}
+
+	if maxBytesStr := strings.TrimSpace(os.Getenv("PEER_MAX_BYTES")); maxBytesStr != "" {
+		val, err := strconv.ParseInt(maxBytesStr, 10, 64)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid PEER_MAX_BYTES; must be positive integer")
+		}
+		cfg.PeerMaxBytes = val
+	}
+
+	if concStr := strings.TrimSpace(os.Getenv("SYNTH_CONCURRENCY")); concStr != "" {
+		val, err := strconv.Atoi(concStr)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid SYNTH_CONCURRENCY; must be positive integer")
+		}
+		cfg.SynthConcurrency = val
+	}
+
+	if queueStr := strings.TrimSpace(os.Getenv("SYNTH_QUEUE")); queueStr != "" {
+		val, err := strconv.Atoi(queueStr)
+		if err != nil || val < 0 {
+			return Config{}, errors.New("invalid SYNTH_QUEUE; must be non-negative integer")
+		}
+		cfg.SynthQueue = val
+	}
+
+	if sentStr := strings.TrimSpace(os.Getenv("SENTENCE_CACHE")); sentStr != "" {
+		val, err := strconv.ParseBool(sentStr)
+		if err != nil {
+			return Config{}, errors.New("invalid SENTENCE_CACHE; must be true or false")
+		}
+		cfg.SentenceCache = val
+	}
+
+	if detectStr := strings.TrimSpace(os.Getenv("LANG_DETECT")); detectStr != "" {
+		val, err := strconv.ParseBool(detectStr)
+		if err != nil {
+			return Config{}, errors.New("invalid LANG_DETECT; must be true or false")
+		}
+		cfg.LangDetect = val
+	}
+
+	if gapStr := strings.TrimSpace(os.Getenv("SENTENCE_GAP")); gapStr != "" {
+		val, err := time.ParseDuration(gapStr)
+		if err != nil || val < 0 {
+			return Config{}, errors.New("invalid SENTENCE_GAP; must be non-negative duration")
+		}
+		cfg.SentenceGap = val
+	}
+
+	if maxBytesStr := strings.TrimSpace(os.Getenv("MAX_BODY_BYTES")); maxBytesStr != "" {
+		val, err := strconv.ParseInt(maxBytesStr, 10, 64)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid MAX_BODY_BYTES; must be positive integer")
+		}
+		cfg.MaxBodyBytes = val
+	}
+
+	if maxStr := strings.TrimSpace(os.Getenv("MAX_TEXT_CHARS")); maxStr != "" {
+		val, err := strconv.Atoi(maxStr)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid MAX_TEXT_CHARS; must be positive integer")
+		}
+		cfg.MaxTextChars = val
+	}
+
+	if timeoutStr := strings.TrimSpace(os.Getenv("SYNTH_TIMEOUT")); timeoutStr != "" {
+		val, err := time.ParseDuration(timeoutStr)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid SYNTH_TIMEOUT; must be positive duration")
+		}
+		cfg.SynthTimeout = val
+	}
+
+	if maxStr := strings.TrimSpace(os.Getenv("MAX_UNIT_CHARS")); maxStr != "" {
+		val, err := strconv.Atoi(maxStr)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid MAX_UNIT_CHARS; must be positive integer")
+		}
+		cfg.MaxUnitChars = val
+	}
+
+	if thresholdStr := strings.TrimSpace(os.Getenv("BREAKER_THRESHOLD")); thresholdStr != "" {
+		val, err := strconv.Atoi(thresholdStr)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid BREAKER_THRESHOLD; must be positive integer")
+		}
+		cfg.BreakerThreshold = val
+	}
+
+	if probeStr := strings.TrimSpace(os.Getenv("BREAKER_PROBE_INTERVAL")); probeStr != "" {
+		val, err := time.ParseDuration(probeStr)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid BREAKER_PROBE_INTERVAL; must be positive duration")
+		}
+		cfg.BreakerProbeInterval = val
+	}
+
+	if concStr := strings.TrimSpace(os.Getenv("WARMUP_CONCURRENCY")); concStr != "" {
+		val, err := strconv.Atoi(concStr)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid WARMUP_CONCURRENCY; must be positive integer")
+		}
+		cfg.WarmupConcurrency = val
+	}
+
+	if blockStr := strings.TrimSpace(os.Getenv("WARMUP_BLOCK")); blockStr != "" {
+		val, err := strconv.ParseBool(blockStr)
+		if err != nil {
+			return Config{}, errors.New("invalid WARMUP_BLOCK; must be true or false")
+		}
+		cfg.WarmupBlock = val
+	}
+
+	if args := strings.TrimSpace(os.Getenv("FILTER_ARGS")); args != "" {
+		cfg.FilterArgs = strings.Fields(args)
+	}
+
+	if timeoutStr := strings.TrimSpace(os.Getenv("FILTER_TIMEOUT")); timeoutStr != "" {
+		val, err := time.ParseDuration(timeoutStr)
+		if err != nil || val <= 0 {
+			return Config{}, errors.New("invalid FILTER_TIMEOUT; must be positive duration")
+		}
+		cfg.FilterTimeout = val
+	}
+
 	// Apply overrides.
 	if override.PiperExec != "" {
 		cfg.PiperExec = override.PiperExec
@@ -75,6 +368,9 @@ func LoadWithOverrides(override Config) (Config, error) {
 	if override.PiperFlags != nil {
 		cfg.PiperFlags = override.PiperFlags
 	}
+	if override.PiperWorkers > 0 {
+		cfg.PiperWorkers = override.PiperWorkers
+	}
 	if override.CacheDir != "" {
 		cfg.CacheDir = override.CacheDir
 	}
@@ -87,6 +383,18 @@ func LoadWithOverrides(override Config) (Config, error) {
 	if override.PlayArgs != nil {
 		cfg.PlayArgs = override.PlayArgs
 	}
+	if override.StreamPlayback {
+		cfg.StreamPlayback = true
+	}
+	if override.StreamPlayCmd != "" {
+		cfg.StreamPlayCmd = override.StreamPlayCmd
+	}
+	if override.StreamPlayArgs != nil {
+		cfg.StreamPlayArgs = override.StreamPlayArgs
+	}
+	if override.PiperSampleRate > 0 {
+		cfg.PiperSampleRate = override.PiperSampleRate
+	}
 	if override.VoiceID != "" {
 		cfg.VoiceID = override.VoiceID
 	}
@@ -94,8 +402,117 @@ func LoadWithOverrides(override Config) (Config, error) {
 		cfg.CacheMaxBytes = override.CacheMaxBytes
 	}
 
-	if cfg.PiperModel == "" {
-		return Config{}, errors.New("PIPER_MODEL is required (flag or env)")
+	if override.Peers != nil {
+		cfg.Peers = override.Peers
+	}
+	if override.PeerTimeout > 0 {
+		cfg.PeerTimeout = override.PeerTimeout
+	}
+	if override.PeerMaxBytes > 0 {
+		cfg.PeerMaxBytes = override.PeerMaxBytes
+	}
+
+	if override.SynthConcurrency > 0 {
+		cfg.SynthConcurrency = override.SynthConcurrency
+	}
+	switch {
+	case override.SynthQueue > 0:
+		cfg.SynthQueue = override.SynthQueue
+	case override.SynthQueue == NoSynthQueue:
+		cfg.SynthQueue = 0
+	}
+	if cfg.SynthConcurrency == 0 {
+		// One synthesis per resident worker, or one model load at a time otherwise.
+		cfg.SynthConcurrency = cfg.PiperWorkers
+		if cfg.SynthConcurrency == 0 {
+			cfg.SynthConcurrency = 1
+		}
+	}
+
+	if override.SentenceCache {
+		cfg.SentenceCache = true
+	}
+	if override.LangDetect {
+		cfg.LangDetect = true
+	}
+	if override.SentenceGap > 0 {
+		cfg.SentenceGap = override.SentenceGap
+	}
+
+	if override.MaxBodyBytes > 0 {
+		cfg.MaxBodyBytes = override.MaxBodyBytes
+	}
+	if override.MaxTextChars > 0 {
+		cfg.MaxTextChars = override.MaxTextChars
+	}
+	if override.SynthTimeout > 0 {
+		cfg.SynthTimeout = override.SynthTimeout
+	}
+	if override.MaxUnitChars > 0 {
+		cfg.MaxUnitChars = override.MaxUnitChars
+	}
+	if cfg.MaxUnitChars == 0 {
+		cfg.MaxUnitChars = int(cfg.SynthTimeout.Seconds() * unitCharsPerSecond)
+		if cfg.MaxUnitChars < 1 {
+			cfg.MaxUnitChars = 1
+		}
+	}
+
+	if override.BreakerThreshold > 0 {
+		cfg.BreakerThreshold = override.BreakerThreshold
+	}
+	if override.BreakerProbeInterval > 0 {
+		cfg.BreakerProbeInterval = override.BreakerProbeInterval
+	}
+
+	if override.WarmupFile != "" {
+		cfg.WarmupFile = override.WarmupFile
+	}
+	if override.WarmupConcurrency > 0 {
+		cfg.WarmupConcurrency = override.WarmupConcurrency
+	}
+	if override.WarmupBlock {
+		cfg.WarmupBlock = true
+	}
+
+	if override.LexiconFile != "" {
+		cfg.LexiconFile = override.LexiconFile
+	}
+	if override.FilterCmd != "" {
+		cfg.FilterCmd = override.FilterCmd
+	}
+	if override.FilterArgs != nil {
+		cfg.FilterArgs = override.FilterArgs
+	}
+	if override.FilterTimeout > 0 {
+		cfg.FilterTimeout = override.FilterTimeout
+	}
+	if override.FilterFailure != "" {
+		cfg.FilterFailure = override.FilterFailure
+	}
+	if cfg.FilterFailure != FilterSkip && cfg.FilterFailure != FilterReject {
+		return Config{}, errors.New("invalid FILTER_FAILURE; must be skip or reject")
+	}
+	if override.Emoji != "" {
+		cfg.Emoji = override.Emoji
+	}
+	if !unicodetext.ValidMode(cfg.Emoji) {
+		return Config{}, errors.New("invalid EMOJI; must be verbalize, strip or keep")
+	}
+	if override.Redact != nil {
+		cfg.Redact = override.Redact
+	}
+	if override.RedactFile != "" {
+		cfg.RedactFile = override.RedactFile
+	}
+	if override.RedactPlaceholder != "" {
+		cfg.RedactPlaceholder = override.RedactPlaceholder
+	}
+	if override.VoicesFile != "" {
+		cfg.VoicesFile = override.VoicesFile
+	}
+	if err := cfg.loadVoices(voiceSet); err != nil {
+		return Config{}, err
 	}
 
 	if err := os.MkdirAll(cfg.CacheDir, 0o755); err != nil {
@@ -110,6 +527,59 @@ func LoadWithOverrides(override Config) (Config, error) {
 	return cfg, nil
 }
 
+// loadVoices fills Engines and Voices from VoicesFile, or from the PIPER_*
+// settings when no voices file is configured. The file's default voice is used
+// unless VOICE_ID was set explicitly.
+func (cfg *Config) loadVoices(voiceSet bool) error {
+	if cfg.VoicesFile == "" {
+		if cfg.PiperModel == "" {
+			return errors.New("PIPER_MODEL is required (flag or env) unless VOICES_FILE is set")
+		}
+		cfg.Engines = map[string]EngineConfig{
+			EnginePiper: {
+				Type:       EnginePiper,
+				Exec:       cfg.PiperExec,
+				Model:      cfg.PiperModel,
+				Flags:      cfg.PiperFlags,
+				Workers:    cfg.PiperWorkers,
+				SampleRate: cfg.PiperSampleRate,
+			},
+		}
+		cfg.Voices = map[string]VoiceConfig{cfg.VoiceID: {Engine: EnginePiper}}
+		return nil
+	}
+
+	vf, err := LoadVoicesFile(cfg.VoicesFile)
+	if err != nil {
+		return err
+	}
+	for name, e := range vf.Engines {
+		switch e.Type {
+		case EnginePiper:
+			if e.Exec == "" {
+				e.Exec = cfg.PiperExec
+			}
+			if e.SampleRate == 0 {
+				e.SampleRate = cfg.PiperSampleRate
+			}
+		case EngineEspeak:
+			if e.Exec == "" {
+				e.Exec = defaultEspeakExec
+			}
+		}
+		vf.Engines[name] = e
+	}
+	cfg.Engines = vf.Engines
+	cfg.Voices = vf.Voices
+	if !voiceSet {
+		cfg.VoiceID = vf.DefaultVoice
+	}
+	if _, ok := cfg.Voices[cfg.VoiceID]; !ok {
+		return fmt.Errorf("voice %q is not defined in %s", cfg.VoiceID, cfg.VoicesFile)
+	}
+	return nil
+}
+
 func getEnv(key, def string) string {
 	if v := os.Getenv(key); strings.TrimSpace(v) != "" {
 		return v
@@ -117,6 +587,13 @@ func getEnv(key, def string) string {
 	return def
 }
 
+// SplitList splits a comma- or whitespace-separated list, dropping empty items.
+func SplitList(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	})
+}
+
 // DefaultPiperExec exposes the default piper executable path.
 func DefaultPiperExec() string { return defaultPiperExec }
 
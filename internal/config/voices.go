@@ -0,0 +1,164 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/unicodetext"
+)
+
+// Engine types understood by tts-cached.
+const (
+	EnginePiper  = "piper"
+	EngineEspeak = "espeak-ng"
+	EngineExec   = "exec"
+	EngineClip   = "clip"
+	EnginePlugin = "plugin"
+)
+
+// EngineConfig describes one synthesis backend.
+type EngineConfig struct {
+	Type       string   `json:"type"`
+	Exec       string   `json:"exec,omitempty"`
+	Model      string   `json:"model,omitempty"`
+	Flags      []string `json:"flags,omitempty"`
+	Workers    int      `json:"workers,omitempty"`
+	SampleRate int      `json:"sample_rate,omitempty"`
+	Languages  []string `json:"languages,omitempty"`
+	Speakers   []string `json:"speakers,omitempty"`
+
+	// Exec template settings; see internal/exectmpl.
+	Args   []string `json:"args,omitempty"`
+	Input  string   `json:"input,omitempty"`
+	Output string   `json:"output,omitempty"`
+
+	// File is the wav returned by a clip engine.
+	File string `json:"file,omitempty"`
+
+	// PingInterval is how often a plugin engine is health-checked, as a Go
+	// duration string (default 30s; "0" disables pings).
+	PingInterval string `json:"ping_interval,omitempty"`
+}
+
+// PluginPingInterval parses PingInterval.
+func (e EngineConfig) PluginPingInterval() (time.Duration, error) {
+	if e.PingInterval == "" {
+		return defaultPluginPing, nil
+	}
+	d, err := time.ParseDuration(e.PingInterval)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid ping_interval %q", e.PingInterval)
+	}
+	return d, nil
+}
+
+const defaultPluginPing = 30 * time.Second
+
+// VoiceConfig binds a voice name to an engine.
+type VoiceConfig struct {
+	Engine   string `json:"engine"`
+	Voice    string `json:"voice,omitempty"`
+	Speaker  string `json:"speaker,omitempty"`
+	Language string `json:"language,omitempty"`
+	// Fallback lists voices tried in order when this voice's engine fails.
+	Fallback []string `json:"fallback,omitempty"`
+	// Speakers restricts the speakers requests may select.
+	Speakers []string `json:"speakers,omitempty"`
+	// Ranges bounds per-request numeric parameters such as length_scale.
+	Ranges map[string]ParamRange `json:"ranges,omitempty"`
+	// Emoji overrides EMOJI for this voice.
+	Emoji string `json:"emoji,omitempty"`
+}
+
+// ParamRange is an inclusive bound for a numeric request parameter.
+type ParamRange struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// rangeParams are the parameter names a voice may bound.
+var rangeParams = map[string]bool{"length_scale": true, "noise_scale": true, "noise_w": true, "sentence_silence": true}
+
+// VoicesFile is the JSON layout of VOICES_FILE.
+type VoicesFile struct {
+	DefaultVoice string                  `json:"default_voice"`
+	Engines      map[string]EngineConfig `json:"engines"`
+	Voices       map[string]VoiceConfig  `json:"voices"`
+}
+
+// LoadVoicesFile reads and validates a voices file.
+func LoadVoicesFile(path string) (VoicesFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return VoicesFile{}, err
+	}
+	var vf VoicesFile
+	if err := json.Unmarshal(data, &vf); err != nil {
+		return VoicesFile{}, fmt.Errorf("parse voices file: %w", err)
+	}
+	if len(vf.Voices) == 0 {
+		return VoicesFile{}, fmt.Errorf("voices file %s defines no voices", path)
+	}
+	for name, e := range vf.Engines {
+		switch e.Type {
+		case EnginePiper:
+			if e.Model == "" {
+				return VoicesFile{}, fmt.Errorf("engine %q: model is required", name)
+			}
+		case EngineEspeak:
+		case EngineExec:
+			if e.Exec == "" {
+				return VoicesFile{}, fmt.Errorf("engine %q: exec is required", name)
+			}
+		case EngineClip:
+			if e.File == "" {
+				return VoicesFile{}, fmt.Errorf("engine %q: file is required", name)
+			}
+		case EnginePlugin:
+			if e.Exec == "" {
+				return VoicesFile{}, fmt.Errorf("engine %q: exec is required", name)
+			}
+			if _, err := e.PluginPingInterval(); err != nil {
+				return VoicesFile{}, fmt.Errorf("engine %q: %w", name, err)
+			}
+		default:
+			return VoicesFile{}, fmt.Errorf("engine %q: unknown type %q", name, e.Type)
+		}
+	}
+	for name, v := range vf.Voices {
+		if _, ok := vf.Engines[v.Engine]; !ok {
+			return VoicesFile{}, fmt.Errorf("voice %q: unknown engine %q", name, v.Engine)
+		}
+		for _, fb := range v.Fallback {
+			if _, ok := vf.Voices[fb]; !ok || fb == name {
+				return VoicesFile{}, fmt.Errorf("voice %q: invalid fallback voice %q", name, fb)
+			}
+		}
+		if v.Emoji != "" && !unicodetext.ValidMode(v.Emoji) {
+			return VoicesFile{}, fmt.Errorf("voice %q: invalid emoji mode %q", name, v.Emoji)
+		}
+		for param, r := range v.Ranges {
+			if !rangeParams[param] {
+				return VoicesFile{}, fmt.Errorf("voice %q: unknown range parameter %q", name, param)
+			}
+			if r.Min > r.Max {
+				return VoicesFile{}, fmt.Errorf("voice %q: %s range min exceeds max", name, param)
+			}
+		}
+	}
+	if vf.DefaultVoice == "" {
+		names := make([]string, 0, len(vf.Voices))
+		for name := range vf.Voices {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		vf.DefaultVoice = names[0]
+	}
+	if _, ok := vf.Voices[vf.DefaultVoice]; !ok {
+		return VoicesFile{}, fmt.Errorf("default voice %q is not defined", vf.DefaultVoice)
+	}
+	return vf, nil
+}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/venkytv/tts-cached/internal/audio"
+)
+
+// Clip is an engine that ignores the text and returns a pre-recorded wav, e.g.
+// a "system error" announcement at the end of a fallback chain.
+type Clip struct {
+	data   []byte
+	format audio.Format
+}
+
+// NewClip loads the wav at path.
+func NewClip(path string) (Clip, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Clip{}, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return Clip{}, err
+	}
+	defer f.Close()
+	format, _, err := audio.ReadWAV(f)
+	if err != nil {
+		return Clip{}, fmt.Errorf("read clip %s: %w", path, err)
+	}
+	return Clip{data: data, format: format}, nil
+}
+
+// Capabilities reports the clip's sample rate.
+func (c Clip) Capabilities() Capabilities {
+	return Capabilities{SampleRate: c.format.SampleRate}
+}
+
+// Synthesize writes the clip to outPath via a temp file.
+func (c Clip) Synthesize(_ context.Context, _ Request, outPath string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(outPath), filepath.Base(outPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(c.data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), outPath)
+}
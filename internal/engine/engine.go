@@ -0,0 +1,188 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Request is a single synthesis request handed to an engine.
+type Request struct {
+	Text string
+	// Voice is the engine-specific voice name (e.g. an espeak-ng voice); engines
+	// bound to a single model ignore it.
+	Voice  string
+	Params Params
+}
+
+// Capabilities describes what an engine can produce.
+type Capabilities struct {
+	SampleRate int      `json:"sample_rate"`
+	Languages  []string `json:"languages,omitempty"`
+	Speakers   []string `json:"speakers,omitempty"`
+	Streaming  bool     `json:"streaming"`
+	// Params lists the per-request parameters the engine honours.
+	Params []string `json:"params,omitempty"`
+	// SpeakerAliases maps other accepted names of a speaker, such as its
+	// numeric id, to the name listed in Speakers.
+	SpeakerAliases map[string]string `json:"speaker_aliases,omitempty"`
+}
+
+// CanonicalSpeaker returns the name Speakers lists for speaker, or speaker
+// itself when it has no alias.
+func (c Capabilities) CanonicalSpeaker(speaker string) string {
+	if name, ok := c.SpeakerAliases[speaker]; ok {
+		return name
+	}
+	return speaker
+}
+
+// Engine synthesizes text to a wav file.
+type Engine interface {
+	Synthesize(ctx context.Context, req Request, outPath string) error
+	Capabilities() Capabilities
+}
+
+// Streamer is implemented by engines that can emit 16-bit mono PCM at their
+// sample rate while synthesizing.
+type Streamer interface {
+	SynthesizeRaw(ctx context.Context, req Request, w io.Writer) error
+}
+
+// Closer is implemented by engines holding resources such as resident processes.
+type Closer interface {
+	Close() error
+}
+
+// Voice binds a public voice name, used in requests and cache keys, to an engine.
+type Voice struct {
+	Name        string `json:"name"`
+	Engine      string `json:"engine"`
+	EngineVoice string `json:"engine_voice,omitempty"`
+	Speaker     string `json:"speaker,omitempty"`
+	Language    string `json:"language,omitempty"`
+	// Fingerprint identifies the model behind the voice in cache metadata.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Fallback lists voices to try, in order, when this voice's engine fails.
+	Fallback []string `json:"fallback,omitempty"`
+	// Speakers, when set, restricts the speakers requests may choose.
+	Speakers []string `json:"speakers,omitempty"`
+	// Ranges overrides DefaultRanges for numeric parameters.
+	Ranges map[string]Range `json:"ranges,omitempty"`
+	// Emoji overrides the server's emoji mode (verbalize, strip or keep).
+	Emoji string `json:"emoji,omitempty"`
+}
+
+// Link is one step of a voice's fallback chain.
+type Link struct {
+	Voice  Voice
+	Engine Engine
+}
+
+// ErrUnknownVoice is returned when a request names a voice that is not configured.
+var ErrUnknownVoice = errors.New("unknown voice")
+
+// Registry holds the configured engines and the voices that use them.
+type Registry struct {
+	engines      map[string]Engine
+	voices       map[string]Voice
+	defaultVoice string
+}
+
+// NewRegistry creates an empty registry whose default voice is defaultVoice.
+func NewRegistry(defaultVoice string) *Registry {
+	return &Registry{
+		engines:      make(map[string]Engine),
+		voices:       make(map[string]Voice),
+		defaultVoice: defaultVoice,
+	}
+}
+
+// AddEngine registers e under name.
+func (r *Registry) AddEngine(name string, e Engine) {
+	r.engines[name] = e
+}
+
+// AddVoice registers v; its engine must already be registered.
+func (r *Registry) AddVoice(v Voice) error {
+	if v.Name == "" {
+		return errors.New("voice name is required")
+	}
+	if _, ok := r.engines[v.Engine]; !ok {
+		return fmt.Errorf("voice %q: unknown engine %q", v.Name, v.Engine)
+	}
+	r.voices[v.Name] = v
+	return nil
+}
+
+// Resolve returns the voice called name (the default voice when name is empty)
+// and its engine.
+func (r *Registry) Resolve(name string) (Voice, Engine, error) {
+	if name == "" {
+		name = r.defaultVoice
+	}
+	v, ok := r.voices[name]
+	if !ok {
+		return Voice{}, nil, fmt.Errorf("%w %q", ErrUnknownVoice, name)
+	}
+	return v, r.engines[v.Engine], nil
+}
+
+// Chain returns the voice called name (the default voice when name is empty)
+// followed by its fallback voices. Each voice appears at most once.
+func (r *Registry) Chain(name string) ([]Link, error) {
+	v, e, err := r.Resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	chain := []Link{{Voice: v, Engine: e}}
+	seen := map[string]bool{v.Name: true}
+	for _, fb := range v.Fallback {
+		if seen[fb] {
+			continue
+		}
+		seen[fb] = true
+		fv, fe, err := r.Resolve(fb)
+		if err != nil {
+			return nil, fmt.Errorf("voice %q fallback: %w", v.Name, err)
+		}
+		chain = append(chain, Link{Voice: fv, Engine: fe})
+	}
+	return chain, nil
+}
+
+// Engine returns the engine registered under name.
+func (r *Registry) Engine(name string) (Engine, bool) {
+	e, ok := r.engines[name]
+	return e, ok
+}
+
+// DefaultVoice returns the name of the default voice.
+func (r *Registry) DefaultVoice() string {
+	return r.defaultVoice
+}
+
+// Voices returns all voices sorted by name.
+func (r *Registry) Voices() []Voice {
+	out := make([]Voice, 0, len(r.voices))
+	for _, v := range r.voices {
+		out = append(out, v)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Close releases engines that hold resources.
+func (r *Registry) Close() error {
+	var errs []error
+	for name, e := range r.engines {
+		if c, ok := e.(Closer); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
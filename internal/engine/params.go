@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Synthesis parameter names, as used in requests, Range maps and Capabilities.
+const (
+	ParamSpeaker         = "speaker"
+	ParamLengthScale     = "length_scale"
+	ParamNoiseScale      = "noise_scale"
+	ParamNoiseW          = "noise_w"
+	ParamSentenceSilence = "sentence_silence"
+)
+
+// ErrInvalidParams is returned when a request's parameters are not allowed for its voice.
+var ErrInvalidParams = errors.New("invalid synthesis parameters")
+
+// Params are optional per-request synthesis settings; nil means engine default.
+type Params struct {
+	Speaker         string   `json:"speaker,omitempty"`
+	LengthScale     *float64 `json:"length_scale,omitempty"`
+	NoiseScale      *float64 `json:"noise_scale,omitempty"`
+	NoiseW          *float64 `json:"noise_w,omitempty"`
+	SentenceSilence *float64 `json:"sentence_silence,omitempty"`
+}
+
+// Range bounds a numeric parameter.
+type Range struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// DefaultRanges apply to voices that do not configure their own.
+var DefaultRanges = map[string]Range{
+	ParamLengthScale:     {Min: 0.25, Max: 4},
+	ParamNoiseScale:      {Min: 0, Max: 2},
+	ParamNoiseW:          {Min: 0, Max: 2},
+	ParamSentenceSilence: {Min: 0, Max: 5},
+}
+
+// Floats returns the numeric parameters that are set, keyed by name.
+func (p Params) Floats() map[string]float64 {
+	out := make(map[string]float64)
+	for name, v := range map[string]*float64{
+		ParamLengthScale:     p.LengthScale,
+		ParamNoiseScale:      p.NoiseScale,
+		ParamNoiseW:          p.NoiseW,
+		ParamSentenceSilence: p.SentenceSilence,
+	} {
+		if v != nil {
+			out[name] = *v
+		}
+	}
+	return out
+}
+
+// IsZero reports whether no parameter is set.
+func (p Params) IsZero() bool {
+	return p.Speaker == "" && len(p.Floats()) == 0
+}
+
+// Canonical returns p with its speaker under the name the engine lists, so a
+// speaker and its numeric id validate and cache alike.
+func (p Params) Canonical(caps Capabilities) Params {
+	p.Speaker = caps.CanonicalSpeaker(p.Speaker)
+	return p
+}
+
+// Key returns a canonical encoding of the set parameters for cache keys; it is
+// empty when none are set.
+func (p Params) Key() string {
+	var parts []string
+	if p.Speaker != "" {
+		parts = append(parts, ParamSpeaker+"="+p.Speaker)
+	}
+	for name, v := range p.Floats() {
+		parts = append(parts, name+"="+FormatFloat(v))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ";")
+}
+
+// FormatFloat renders v in its shortest exact form, e.g. for command-line flags.
+func FormatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Validate checks p against the voice's allowed speakers and ranges and the
+// parameters its engine supports.
+func (v Voice) Validate(p Params, caps Capabilities) error {
+	supported := make(map[string]bool, len(caps.Params))
+	for _, name := range caps.Params {
+		supported[name] = true
+	}
+	if p.Speaker != "" {
+		if !supported[ParamSpeaker] {
+			return fmt.Errorf("%w: voice %q does not support %s", ErrInvalidParams, v.Name, ParamSpeaker)
+		}
+		allowed := caps.Speakers
+		if len(v.Speakers) > 0 {
+			allowed = make([]string, len(v.Speakers))
+			for i, speaker := range v.Speakers {
+				allowed[i] = caps.CanonicalSpeaker(speaker)
+			}
+		}
+		if len(allowed) > 0 && !contains(allowed, caps.CanonicalSpeaker(p.Speaker)) {
+			return fmt.Errorf("%w: voice %q has no speaker %q", ErrInvalidParams, v.Name, p.Speaker)
+		}
+	}
+	for name, val := range p.Floats() {
+		if !supported[name] {
+			return fmt.Errorf("%w: voice %q does not support %s", ErrInvalidParams, v.Name, name)
+		}
+		r := v.Range(name)
+		if val < r.Min || val > r.Max {
+			return fmt.Errorf("%w: %s %s outside [%s, %s] for voice %q", ErrInvalidParams, name, FormatFloat(val), FormatFloat(r.Min), FormatFloat(r.Max), v.Name)
+		}
+	}
+	return nil
+}
+
+// Range returns the bounds of a numeric parameter for the voice.
+func (v Voice) Range(name string) Range {
+	if r, ok := v.Ranges[name]; ok {
+		return r
+	}
+	return DefaultRanges[name]
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
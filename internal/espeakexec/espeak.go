@@ -0,0 +1,90 @@
+package espeakexec
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/engine"
+)
+
+// SampleRate is the fixed output rate of espeak-ng.
+const SampleRate = 22050
+
+// defaultSpeed is espeak-ng's default rate in words per minute; length_scale
+// divides it.
+const defaultSpeed = 175
+
+// Runner executes the espeak-ng CLI to synthesize audio.
+type Runner struct {
+	execPath  string
+	flags     []string
+	languages []string
+	logger    *log.Logger
+}
+
+// New creates a Runner for the given executable and extra flags. languages is
+// advertised in the engine's capabilities.
+func New(execPath string, flags, languages []string, logger *log.Logger) Runner {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return Runner{
+		execPath:  execPath,
+		flags:     flags,
+		languages: languages,
+		logger:    logger,
+	}
+}
+
+// Capabilities reports espeak-ng's fixed sample rate and configured languages.
+// Only length_scale is supported, mapped onto the speaking rate.
+func (r Runner) Capabilities() engine.Capabilities {
+	return engine.Capabilities{SampleRate: SampleRate, Languages: r.languages, Params: []string{engine.ParamLengthScale}}
+}
+
+// Synthesize runs espeak-ng with stdin text, selecting req.Voice with -v, and
+// writes the wav to outPath via a temp file.
+func (r Runner) Synthesize(ctx context.Context, req engine.Request, outPath string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(outPath), filepath.Base(outPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+
+	args := []string{"--stdin", "-w", tmpPath}
+	if req.Voice != "" {
+		args = append(args, "-v", req.Voice)
+	}
+	args = append(args, r.flags...)
+	if ls := req.Params.LengthScale; ls != nil && *ls > 0 {
+		args = append(args, "-s", strconv.Itoa(int(math.Round(defaultSpeed / *ls))))
+	}
+
+	r.logger.Printf("INFO: invoking espeak-ng exec=%s args=%v", r.execPath, args)
+
+	cmd := exec.CommandContext(ctx, r.execPath, args...)
+	cmd.Stdin = strings.NewReader(req.Text)
+
+	start := time.Now()
+	if output, err := cmd.CombinedOutput(); err != nil {
+		_ = os.Remove(tmpPath)
+		r.logger.Printf("ERROR: espeak-ng failed after %s: %v (output: %s)", time.Since(start).Round(time.Millisecond), err, strings.TrimSpace(string(output)))
+		return fmt.Errorf("espeak-ng exec failed: %w", err)
+	}
+	r.logger.Printf("INFO: espeak-ng completed in %s", time.Since(start).Round(time.Millisecond))
+
+	if err := os.Rename(tmpPath, outPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("rename espeak-ng output failed: %w", err)
+	}
+	return nil
+}
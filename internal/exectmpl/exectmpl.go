@@ -0,0 +1,237 @@
+package exectmpl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/audio"
+	"github.com/venkytv/tts-cached/internal/engine"
+)
+
+// Input modes: how the text reaches the command.
+const (
+	InputStdin = "stdin" // text is written to stdin
+	InputNone  = "none"  // text is passed only through the {text} placeholder
+)
+
+// Output modes: how audio is collected from the command.
+const (
+	OutputFile = "file" // the command writes a wav to {out}
+	OutputWAV  = "wav"  // the command writes a wav to stdout
+	OutputRaw  = "raw"  // the command writes 16-bit mono PCM at SampleRate to stdout
+)
+
+// Spec describes a command-line synthesizer. Args may contain the placeholders
+// {text}, {out}, {voice}, {speaker} and {rate}, plus {length_scale},
+// {noise_scale}, {noise_w} and {sentence_silence}, which expand to an empty
+// string when the request leaves them unset.
+type Spec struct {
+	Command    string
+	Args       []string
+	Input      string
+	Output     string
+	SampleRate int
+	Languages  []string
+	Speakers   []string
+}
+
+// Runner executes a synthesizer described by a Spec.
+type Runner struct {
+	spec   Spec
+	logger *log.Logger
+}
+
+// New validates spec, filling in the stdin/file defaults, and creates a Runner.
+func New(spec Spec, logger *log.Logger) (Runner, error) {
+	if logger == nil {
+		logger = log.Default()
+	}
+	if spec.Command == "" {
+		return Runner{}, errors.New("exec engine: command is required")
+	}
+	if spec.Input == "" {
+		spec.Input = InputStdin
+	}
+	if spec.Output == "" {
+		spec.Output = OutputFile
+	}
+	switch spec.Input {
+	case InputStdin, InputNone:
+	default:
+		return Runner{}, fmt.Errorf("exec engine: unknown input mode %q", spec.Input)
+	}
+	switch spec.Output {
+	case OutputFile, OutputWAV, OutputRaw:
+	default:
+		return Runner{}, fmt.Errorf("exec engine: unknown output mode %q", spec.Output)
+	}
+	if spec.Output == OutputRaw && spec.SampleRate <= 0 {
+		return Runner{}, errors.New("exec engine: raw output requires a sample rate")
+	}
+	if spec.Output == OutputFile && !hasPlaceholder(spec.Args, "{out}") {
+		return Runner{}, errors.New("exec engine: file output requires {out} in args")
+	}
+	return Runner{spec: spec, logger: logger}, nil
+}
+
+// Capabilities reports the configured rate, languages and speakers; raw output
+// is streamable.
+func (r Runner) Capabilities() engine.Capabilities {
+	return engine.Capabilities{
+		SampleRate: r.spec.SampleRate,
+		Languages:  r.spec.Languages,
+		Speakers:   r.spec.Speakers,
+		Streaming:  r.spec.Output == OutputRaw,
+		Params:     r.params(),
+	}
+}
+
+// params reports which request parameters have a placeholder in Args.
+func (r Runner) params() []string {
+	var out []string
+	for _, name := range []string{engine.ParamSpeaker, engine.ParamLengthScale, engine.ParamNoiseScale, engine.ParamNoiseW, engine.ParamSentenceSilence} {
+		if hasPlaceholder(r.spec.Args, "{"+name+"}") {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func hasPlaceholder(args []string, placeholder string) bool {
+	for _, a := range args {
+		if strings.Contains(a, placeholder) {
+			return true
+		}
+	}
+	return false
+}
+
+// Synthesize runs the command and stores its audio at outPath via a temp file.
+// Output that is not a wav with audio data is rejected.
+func (r Runner) Synthesize(ctx context.Context, req engine.Request, outPath string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(outPath), filepath.Base(outPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	defer tmp.Close()
+
+	var stdout io.Writer
+	var ww *audio.WAVWriter
+	switch r.spec.Output {
+	case OutputWAV:
+		stdout = tmp
+	case OutputRaw:
+		if ww, err = audio.NewWAVWriter(tmp, audio.PiperFormat(r.spec.SampleRate)); err != nil {
+			return fmt.Errorf("write wav header: %w", err)
+		}
+		stdout = ww
+	}
+
+	if err := r.run(ctx, req, tmpPath, stdout); err != nil {
+		return err
+	}
+	if ww != nil {
+		if err := ww.Close(); err != nil {
+			return fmt.Errorf("finalize wav: %w", err)
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close wav: %w", err)
+	}
+	if err := checkWAV(tmpPath); err != nil {
+		r.logger.Printf("ERROR: exec engine produced no usable audio: %v", err)
+		return fmt.Errorf("exec engine output: %w", err)
+	}
+	if err := os.Rename(tmpPath, outPath); err != nil {
+		return fmt.Errorf("rename exec output failed: %w", err)
+	}
+	return nil
+}
+
+// checkWAV reports whether path holds a wav with non-empty sample data.
+func checkWAV(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, pcm, err := audio.ReadWAV(f)
+	if err != nil {
+		return err
+	}
+	if len(pcm) == 0 {
+		return errors.New("wav has no audio")
+	}
+	return nil
+}
+
+// SynthesizeRaw copies the command's PCM to w as it is produced. Only raw
+// output mode supports it.
+func (r Runner) SynthesizeRaw(ctx context.Context, req engine.Request, w io.Writer) error {
+	if r.spec.Output != OutputRaw {
+		return fmt.Errorf("exec engine: output mode %q cannot stream", r.spec.Output)
+	}
+	return r.run(ctx, req, "", w)
+}
+
+func (r Runner) run(ctx context.Context, req engine.Request, outPath string, stdout io.Writer) error {
+	args := r.expand(req, outPath)
+	r.logger.Printf("INFO: invoking exec engine cmd=%s args=%v", r.spec.Command, args)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.spec.Command, args...)
+	if r.spec.Input == InputStdin {
+		cmd.Stdin = strings.NewReader(req.Text)
+	}
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+	if stdout == nil {
+		cmd.Stdout = &stderr
+	}
+
+	start := time.Now()
+	if err := cmd.Run(); err != nil {
+		r.logger.Printf("ERROR: exec engine failed after %s: %v (output: %s)", time.Since(start).Round(time.Millisecond), err, strings.TrimSpace(stderr.String()))
+		return fmt.Errorf("exec engine failed: %w", err)
+	}
+	r.logger.Printf("INFO: exec engine completed in %s", time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+func (r Runner) expand(req engine.Request, outPath string) []string {
+	rep := strings.NewReplacer(
+		"{text}", req.Text,
+		"{out}", outPath,
+		"{voice}", req.Voice,
+		"{speaker}", req.Params.Speaker,
+		"{rate}", strconv.Itoa(r.spec.SampleRate),
+		"{length_scale}", floatParam(req.Params.LengthScale),
+		"{noise_scale}", floatParam(req.Params.NoiseScale),
+		"{noise_w}", floatParam(req.Params.NoiseW),
+		"{sentence_silence}", floatParam(req.Params.SentenceSilence),
+	)
+	args := make([]string, len(r.spec.Args))
+	for i, a := range r.spec.Args {
+		args[i] = rep.Replace(a)
+	}
+	return args
+}
+
+func floatParam(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return engine.FormatFloat(*v)
+}
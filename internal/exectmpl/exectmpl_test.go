@@ -0,0 +1,140 @@
+package exectmpl
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/venkytv/tts-cached/internal/audio"
+	"github.com/venkytv/tts-cached/internal/engine"
+)
+
+func TestFileOutputWithPlaceholders(t *testing.T) {
+	skipWithoutShell(t)
+	dir := t.TempDir()
+	header := filepath.Join(dir, "header")
+	if err := os.WriteFile(header, audio.StreamingHeader(audio.PiperFormat(16000)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := New(Spec{
+		Command: "sh",
+		Args:    []string{"-c", `cat "$1" > "$4"; printf '%s|%s|' "$2" "$3" >> "$4"; cat >> "$4"`, "sh", header, "{voice}", "{speaker}", "{out}"},
+	}, logDiscard)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	out := filepath.Join(dir, "a.wav")
+	if err := r.Synthesize(context.Background(), engine.Request{Text: "hello", Voice: "en", Params: engine.Params{Speaker: "3"}}, out); err != nil {
+		t.Fatalf("synthesize: %v", err)
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+	if _, pcm, err := audio.ReadWAV(f); err != nil || string(pcm) != "en|3|hello" {
+		t.Fatalf("unexpected output %q: %v", pcm, err)
+	}
+}
+
+func TestRawOutputIsWrappedAndStreams(t *testing.T) {
+	skipWithoutShell(t)
+	r, err := New(Spec{
+		Command:    "sh",
+		Args:       []string{"-c", `printf '%s' "$1"`, "sh", "{text}"},
+		Input:      InputNone,
+		Output:     OutputRaw,
+		SampleRate: 16000,
+	}, logDiscard)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	if !r.Capabilities().Streaming {
+		t.Fatalf("raw output should stream")
+	}
+
+	out := filepath.Join(t.TempDir(), "a.wav")
+	if err := r.Synthesize(context.Background(), engine.Request{Text: "abcd"}, out); err != nil {
+		t.Fatalf("synthesize: %v", err)
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+	format, pcm, err := audio.ReadWAV(f)
+	if err != nil || format.SampleRate != 16000 || string(pcm) != "abcd" {
+		t.Fatalf("unexpected wav %+v %q: %v", format, pcm, err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.SynthesizeRaw(context.Background(), engine.Request{Text: "xy"}, &buf); err != nil || buf.String() != "xy" {
+		t.Fatalf("raw stream %q: %v", buf.String(), err)
+	}
+}
+
+func TestFailureLeavesNoFile(t *testing.T) {
+	skipWithoutShell(t)
+	r, err := New(Spec{Command: "sh", Args: []string{"-c", "exit 3"}, Output: OutputWAV}, logDiscard)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	dir := t.TempDir()
+	if err := r.Synthesize(context.Background(), engine.Request{Text: "x"}, filepath.Join(dir, "a.wav")); err == nil {
+		t.Fatalf("expected error")
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*")); len(matches) != 0 {
+		t.Fatalf("leftover files %v", matches)
+	}
+}
+
+func TestOutputWithoutAudioIsRejected(t *testing.T) {
+	skipWithoutShell(t)
+	for _, spec := range []Spec{
+		{Command: "sh", Args: []string{"-c", ": > \"$1\"", "sh", "{out}"}},
+		{Command: "sh", Args: []string{"-c", "printf 'not a wav'"}, Output: OutputWAV},
+		{Command: "true", Output: OutputWAV},
+		{Command: "true", Output: OutputRaw, SampleRate: 16000},
+	} {
+		r, err := New(spec, logDiscard)
+		if err != nil {
+			t.Fatalf("new %+v: %v", spec, err)
+		}
+		dir := t.TempDir()
+		if err := r.Synthesize(context.Background(), engine.Request{Text: "x"}, filepath.Join(dir, "a.wav")); err == nil {
+			t.Fatalf("expected error for %+v", spec)
+		}
+		if matches, _ := filepath.Glob(filepath.Join(dir, "*")); len(matches) != 0 {
+			t.Fatalf("leftover files %v for %+v", matches, spec)
+		}
+	}
+}
+
+func TestNewRejectsInvalidSpec(t *testing.T) {
+	for _, spec := range []Spec{
+		{},
+		{Command: "x", Input: "pipe"},
+		{Command: "x", Output: "mp3"},
+		{Command: "x", Output: OutputRaw},
+		{Command: "true"},
+		{Command: "x", Args: []string{"{text}"}, Output: OutputFile},
+	} {
+		if _, err := New(spec, logDiscard); err == nil {
+			t.Fatalf("expected error for %+v", spec)
+		}
+	}
+}
+
+func skipWithoutShell(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+}
+
+var logDiscard = log.New(io.Discard, "", 0)
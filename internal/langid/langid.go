@@ -0,0 +1,218 @@
+// Package langid guesses the language of short announcement texts: the writing
+// system decides non-Latin scripts outright, and Latin text is scored against
+// character trigram profiles built from word frequency lists.
+package langid
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// scripts maps non-Latin writing systems to the languages written in them, in
+// order of preference.
+var scripts = []struct {
+	table *unicode.RangeTable
+	langs []string
+}{
+	{unicode.Devanagari, []string{"hi", "mr", "ne"}},
+	{unicode.Bengali, []string{"bn"}},
+	{unicode.Tamil, []string{"ta"}},
+	{unicode.Cyrillic, []string{"ru", "uk", "bg"}},
+	{unicode.Greek, []string{"el"}},
+	{unicode.Arabic, []string{"ar", "fa", "ur"}},
+	{unicode.Hebrew, []string{"he"}},
+	{unicode.Hangul, []string{"ko"}},
+	{unicode.Hiragana, []string{"ja"}},
+	{unicode.Katakana, []string{"ja"}},
+	{unicode.Han, []string{"zh", "ja"}},
+}
+
+// profile holds the log-probabilities of a Latin-script language's character
+// trigrams (a space marks a word boundary) and letters that strongly suggest it.
+type profile struct {
+	words  map[string]float64 // bonus for whole frequent words, in nats
+	logp   map[string]float64
+	unseen float64 // log-probability of a trigram missing from logp
+	marks  string
+}
+
+// smoothing is the weight added to every trigram, seen or not, so that one
+// unknown trigram does not rule a language out.
+const smoothing = 0.01
+
+// markBonus is added to the score for each distinctive letter, in nats.
+const markBonus = 5
+
+// marks are letters that occur in a language but rarely in the others.
+var marks = map[string]string{
+	"en": "",
+	"de": "äöüß",
+	"fr": "éèêàçùœ",
+	"es": "ñ¿¡áíóú",
+}
+
+var profiles = buildProfiles()
+
+// buildProfiles derives trigram profiles from frequentWords, weighting the
+// trigrams of the word at rank r by 1/r.
+func buildProfiles() map[string]profile {
+	out := make(map[string]profile, len(frequentWords))
+	for lang, list := range frequentWords {
+		weights := make(map[string]float64)
+		words := make(map[string]float64)
+		fields := strings.Fields(strings.ToLower(list))
+		r := 0
+		for _, word := range fields {
+			if _, seen := words[word]; seen {
+				continue
+			}
+			r++
+			// A listed word is likelier in its language the more frequent it is.
+			words[word] = math.Log(float64(len(fields)) / float64(r))
+			runes := []rune(" " + word + " ")
+			for i := 0; i+3 <= len(runes); i++ {
+				weights[string(runes[i:i+3])] += 1 / float64(r)
+			}
+		}
+		var total float64
+		for _, w := range weights {
+			total += w
+		}
+		// Assume a vocabulary of all trigrams over 27 symbols.
+		norm := math.Log(total + smoothing*27*27*27)
+		logp := make(map[string]float64, len(weights))
+		for g, w := range weights {
+			logp[g] = math.Log(w+smoothing) - norm
+		}
+		out[lang] = profile{words: words, logp: logp, unseen: math.Log(smoothing) - norm, marks: marks[lang]}
+	}
+	return out
+}
+
+// Detect returns the most likely language of text, as an ISO 639-1 code,
+// among candidates (all known languages when empty). ok is false when the text
+// gives too little evidence or no candidate matches its script.
+func Detect(text string, candidates []string) (lang string, ok bool) {
+	allowed := func(l string) bool {
+		if len(candidates) == 0 {
+			return true
+		}
+		for _, c := range candidates {
+			if Base(c) == l {
+				return true
+			}
+		}
+		return false
+	}
+
+	counts := make([]int, len(scripts))
+	latin := 0
+	for _, r := range text {
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+			continue
+		}
+		for i, s := range scripts {
+			if unicode.Is(s.table, r) {
+				counts[i]++
+				break
+			}
+		}
+	}
+	best, bestCount := -1, latin
+	for i, n := range counts {
+		if n > bestCount {
+			best, bestCount = i, n
+		}
+	}
+	if bestCount == 0 {
+		return "", false
+	}
+	if best >= 0 {
+		for _, l := range scripts[best].langs {
+			if allowed(l) {
+				return l, true
+			}
+		}
+		return "", false
+	}
+
+	scores := make(map[string]float64)
+	evidence := false
+	for l, p := range profiles {
+		if allowed(l) {
+			sc, known := p.score(text)
+			scores[l] = sc
+			evidence = evidence || known
+		}
+	}
+	if !evidence {
+		return "", false
+	}
+	top, topScore, tie := "", math.Inf(-1), false
+	for l, sc := range scores {
+		switch {
+		case sc > topScore:
+			top, topScore, tie = l, sc, false
+		case sc == topScore:
+			tie = true
+		}
+	}
+	if top == "" || tie {
+		return "", false
+	}
+	return top, true
+}
+
+// score returns the log-likelihood of text's trigrams under the profile,
+// plus a bonus for each frequent word and each distinctive letter, and
+// whether any trigram is one the profile has seen.
+func (p profile) score(text string) (float64, bool) {
+	total := 0.0
+	lower := strings.ToLower(text)
+	for _, word := range strings.FieldsFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) && r != '\'' }) {
+		total += p.words[word]
+	}
+
+	var b strings.Builder
+	b.WriteByte(' ')
+	space := true
+	for _, r := range lower {
+		if unicode.IsLetter(r) {
+			b.WriteRune(r)
+			space = false
+			if strings.ContainsRune(p.marks, r) {
+				total += markBonus
+			}
+		} else if !space {
+			b.WriteByte(' ')
+			space = true
+		}
+	}
+	if !space {
+		b.WriteByte(' ')
+	}
+
+	known := false
+	runes := []rune(b.String())
+	for i := 0; i+3 <= len(runes); i++ {
+		lp, ok := p.logp[string(runes[i:i+3])]
+		if !ok {
+			lp = p.unseen
+		}
+		known = known || ok
+		total += lp
+	}
+	return total, known
+}
+
+// Base returns the lowercased primary subtag of a language tag, e.g. "de" for
+// "de-AT" or "en_US".
+func Base(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
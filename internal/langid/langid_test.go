@@ -0,0 +1,102 @@
+package langid
+
+import "testing"
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		text       string
+		candidates []string
+		want       string
+	}{
+		{"The front door has been opened", nil, "en"},
+		{"Please take the laundry out of the washing machine", []string{"en_US", "de"}, "en"},
+		{"Die Haustür wurde geöffnet", nil, "de"},
+		{"Das Essen ist fertig, bitte kommt in die Küche", []string{"en", "de-DE"}, "de"},
+		{"Bitte nicht vergessen, den Müll rauszubringen", []string{"en", "de"}, "de"},
+		{"मुख्य दरवाज़ा खुला है", []string{"en", "de", "hi"}, "hi"},
+		{"La porte d'entrée est ouverte", []string{"en", "fr"}, "fr"},
+		{"La puerta principal está abierta", []string{"en", "es"}, "es"},
+	}
+	for _, c := range cases {
+		if got, ok := Detect(c.text, c.candidates); !ok || got != c.want {
+			t.Errorf("Detect(%q) = %q, %v; want %q", c.text, got, ok, c.want)
+		}
+	}
+}
+
+// TestDetectHeldOut checks sentences that share no origin with the word lists
+// the profiles are built from.
+func TestDetectHeldOut(t *testing.T) {
+	sentences := map[string][]string{
+		"en": {
+			"The washing machine has finished its cycle",
+			"Your package was delivered to the neighbour",
+			"It is going to rain later this afternoon",
+			"Remember to water the plants before you leave",
+			"The garage door is still open",
+			"Dinner will be ready in ten minutes",
+			"Someone is waiting at the front gate",
+			"The battery of the smoke alarm is running low",
+		},
+		"de": {
+			"Die Waschmaschine ist fertig",
+			"Dein Paket wurde beim Nachbarn abgegeben",
+			"Heute Nachmittag soll es regnen",
+			"Vergiss nicht, die Pflanzen zu gießen",
+			"Das Garagentor ist noch offen",
+			"Das Abendessen ist in zehn Minuten fertig",
+			"Jemand wartet vor dem Gartentor",
+			"Die Batterie des Rauchmelders ist fast leer",
+		},
+		"fr": {
+			"La machine à laver a terminé son cycle",
+			"Votre colis a été livré chez le voisin",
+			"Il va pleuvoir cet après-midi",
+			"N'oublie pas d'arroser les plantes avant de partir",
+			"La porte du garage est encore ouverte",
+			"Le dîner sera prêt dans dix minutes",
+			"Quelqu'un attend devant le portail",
+			"La pile du détecteur de fumée est presque vide",
+		},
+		"es": {
+			"La lavadora ha terminado el programa",
+			"Tu paquete fue entregado al vecino",
+			"Va a llover esta tarde",
+			"No olvides regar las plantas antes de salir",
+			"La puerta del garaje sigue abierta",
+			"La cena estará lista en diez minutos",
+			"Alguien está esperando en la entrada",
+			"La batería del detector de humo está casi agotada",
+		},
+	}
+	for want, list := range sentences {
+		for _, text := range list {
+			if got, ok := Detect(text, []string{"en", "de", "fr", "es"}); !ok || got != want {
+				t.Errorf("Detect(%q) = %q, %v; want %q", text, got, ok, want)
+			}
+		}
+	}
+}
+
+func TestDetectUndetermined(t *testing.T) {
+	for _, c := range []struct {
+		text       string
+		candidates []string
+	}{
+		{"", nil},
+		{"12:30 !!", nil},
+		{"मुख्य दरवाज़ा खुला है", []string{"en", "de"}},
+	} {
+		if got, ok := Detect(c.text, c.candidates); ok {
+			t.Errorf("Detect(%q, %v) = %q, want undetermined", c.text, c.candidates, got)
+		}
+	}
+}
+
+func TestBase(t *testing.T) {
+	for in, want := range map[string]string{"en_US": "en", "de-AT": "de", " HI ": "hi", "fr": "fr"} {
+		if got := Base(in); got != want {
+			t.Errorf("Base(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
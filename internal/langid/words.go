@@ -0,0 +1,152 @@
+package langid
+
+// frequentWords lists each Latin-script language's most frequent words, most
+// frequent first: English after the Corpus of Contemporary American English,
+// the others after the OpenSubtitles frequency lists published on Wiktionary.
+// Spoken-language lists suit announcements better than news corpora. Repeated
+// words keep their first rank.
+var frequentWords = map[string]string{
+	"en": `the be and of a in to have it i that for you he with on do say this they
+at but we his from not by she or as what go their can who get if would her
+all my make about know will up one time there year so think when which them
+some me people take out into just see him your come could now than like
+other how then its our two more these want way look first also new because
+day use no man find here thing give many well only those tell very even
+back any good woman through us life child work down may after should call
+world over school still try last ask need too feel three state never become
+between high really something most another much family own leave put old
+while mean keep student why let great same big group begin seem country help
+talk where turn problem every start hand might american show part against
+place such again few case week company system each right program hear
+question during play government run small number off always move night live
+point believe hold today bring happen next without before large million must
+home under water room write mother area national money story young fact
+month different lot study book eye job word though business issue side kind
+four head far black long both little house yes since provide service around
+friend important father sit away until power hour game often yet line
+political end among ever stand bad lose however member pay law meet car
+city almost include continue set later community much name five once white
+least president learn real change team minute best several idea kid body
+information nothing ago lead social understand whether watch together follow
+parent stop face anything create public already speak others read level
+allow add office spend door health person art sure war history party within
+grow result open morning walk reason low win research girl guy early food
+moment himself air teacher force offer enough education across although
+remember foot second boy maybe toward able age policy everything love
+process music including consider appear actually buy probably human wait
+serve market die send expect sense build stay fall oh nation plan cut
+college interest death course someone experience behind reach local kill six
+remain effect yeah suggest class control raise care perhaps late hard field
+else pass former sell major sometimes require along development themselves
+report role better economic effort decide rate strong possible heart drug
+show leader light voice wife whole police mind finally pull return free
+military price less according decision explain son hope develop view
+relationship carry town road drive arm true federal break difference thank
+receive value international building action full model join season society
+tax director position player agree especially record pick wear paper
+special space ground form support event official whose matter everyone
+center couple site project hit base activity star table need court produce
+eat american teach oil half situation easy cost industry figure street image
+itself phone either data cover quite picture clear practice piece land recent
+describe product doctor wall patient worker news test movie certain north
+personal simply third technology catch step baby computer type attention
+draw film tree source red nearly organization choose cause hair century
+evidence window difficult listen soon culture billion chance brother energy
+period summer realize hundred available plant likely opportunity term short
+letter condition choice single rule daughter administration south husband
+floor campaign material population economy medical hospital church close
+thousand risk current fire future wrong involve defense anyone increase
+security bank myself certainly west sport board seek per subject officer
+private rest behavior deal performance fight throw top quickly past goal
+bed order author fill represent focus foreign drop blood upon agency push
+nature color recently store reduce sound note fine before near movement page
+enter share than common poor other natural race concern series significant
+similar hot language each usually response dead rise animal factor decade
+article shoot east save seven artist away scene stock career despite central
+eight thus treatment beyond happy exactly protect approach lie size dog fund
+serious occur media ready sign thought list individual simple quality
+pressure accept answer resource identify left meeting determine prepare
+disease whatever success argue cup particularly amount ability staff
+recognize indicate character growth loss degree wonder attack herself region
+television box training pretty trade deal election everybody physical lay
+general feeling standard bill message fail outside arrive analysis benefit
+name sex forward lawyer present section environmental glass answer skill
+sister professor operation financial crime stage ok compare authority miss
+design sort one act ten knowledge gun station blue state strategy clearly
+discuss indeed force truth song example democratic check environment leg dark
+public various rather laugh guess executive set study prove hang entire rock
+design enough forget since claim note remove manager help close sound enjoy
+network legal religious cold form final main science green memory card above
+seat cell establish nice trial expert that spring firm democrat radio visit
+management care avoid imagine tonight huge ball no finish yourself talk
+theory impact respond statement maintain charge popular traditional onto
+reveal direction weapon employee cultural contain peace head control base
+pain apply play measure wide shake fly interview manage chair fish
+particular camera structure politics perform bit weight suddenly discover
+candidate top production treat trip evening affect inside conference unit
+best style adult worry range mention rather far deep front edge individual
+specific writer trouble necessary throughout challenge fear shoulder
+institution middle sea dream bar beautiful property instead improve stuff`,
+
+	"de": `ich sie das ist du nicht die und es der wir was zu ein er in mir mit ja
+wie den auf mich dass so nein hier eine dich wenn sind war von hat an habe
+für noch aber bin nur uns ihr kann dir mal da auch doch jetzt haben schon ihn
+nicht oh bitte dem gut als was wird werden einen alles wo weiß gibt mein
+immer kein des hast sein wollen will meine okay nichts hab etwas keine muss
+sich nach warum bei geht dann können machen einem denn wissen los man um
+sehr mehr vor aus wer oder leben sagen danke weg gehen komm alle gesagt
+einer würde wieder sehen tun ihm soll heute diese bist zeit bis hab vielleicht
+sie zum morgen ganz gerade hatte mann werde viel lass hätte wirklich nie
+gott muss zurück etwas sagte raus richtig meinen wurde zu wo nun klar wäre
+tut dieser können selbst wollte sicher einfach genau gehört jemand kommt
+euch vater recht hallo lange kommen frau geld kinder haus tag abend nacht
+später vorbei müssen wirklich schnell weißt musst sollte natürlich mutter
+ihnen ob sollen zwei allein anderen andere genug dort arbeit sofort glaube
+glaubst brauche brauchen machst macht gemacht geben weil kann hin durch
+unter beim dafür darüber davon dabei daran noch wenig ganze halt einfach
+warte warten stimmt schön tot leid sorgen sorge welt bisschen keiner niemand
+morgen gestern heute woche jahr jahre stunde minuten sekunde moment ende
+erst ersten paar drei leute mensch menschen freund freunde sohn tochter
+bruder schwester herr frau junge mädchen kind geh geht ging gesehen sieh
+sieht hören hörst gehört sprechen spricht reden redest finden gefunden
+nehmen genommen bringen bringt bleiben bleib fahren essen schlafen spielen
+arbeiten verstehen verstanden denke denkst meinst meint gefällt helfen hilfe`,
+
+	"fr": `de je est pas le vous la tu que un il et à a ne les ce en on ça une ai
+pour des moi qui nous mais y me dans du bien elle si tout plus non mon suis
+te au avec va oui toi fait ils as être faire se comme était sur quoi ici sais
+rien veux ma son peut là où dit lui ton es vais peux alors c'est j'ai cette
+votre quand faut aussi sa suis très avez êtes même bon autre êtes vraiment
+tous deux merci aller avoir dois ces pourquoi comment rien quelque chose
+personne jamais toujours encore déjà maintenant après avant temps jour nuit
+vie homme femme père mère monde ans mes tes ses nos vos leur leurs sont
+parce allez viens vient venir voir vu dire dis pense crois sais savoir
+besoin voulez veut peut-être trop peu beaucoup petit grand bonne mal moins
+chez sans sous entre vers contre depuis pendant part fois faites fais
+attends attendez regarde écoute arrête laisse allons on y va bonjour salut
+seul seule sûr sûre vrai rien tout ensemble dehors maison argent travail
+demain hier soir matin heure heures minutes semaine prochaine dernier
+premier première enfant enfants fils fille frère soeur ami amis gens monsieur
+madame mademoiselle chérie chéri putain dieu mort tué aime aimer parler
+parle dites donne prendre prends mettre mets trouver trouvé passer passé
+rester reste partir pars sortir rentrer arriver arrivé comprendre compris`,
+
+	"es": `de que no a la el es y en lo un por qué me una te los se con para mi está
+si bien pero yo eso las sí su tu aquí del al como le más esto ya todo esta
+vamos muy hay ahora algo estoy tengo nada cuando ha este sé estás así puedo
+cómo quiero él sólo solo soy tiene gracias o eres hacer era bueno ser porque
+dónde todos puede favor hola están nos fue tienes ella entonces voy creo
+esa sabes nunca mucho ese hace tan crees dos quién hasta estaba también
+vez siempre alguien tiempo señor otra otro va nadie mejor donde puedes
+bueno casa mira dije ir estar ver ni hay quieres decir sabe debe poco
+hecho hombre vida día noche mañana hoy ayer tarde padre madre hijo hija
+hermano hermana amigo amigos gente mundo dinero trabajo años cosa cosas
+momento verdad nuevo nueva vale claro oye espera esperen siento lo siento
+ahí allí acá ustedes usted nosotros ellos ellas mis tus sus nuestro cada
+dice dijo hablar habla saber sé vete ven venga vamos vaya tienen tenemos
+hacemos hago hizo haces decir digo dices quiere queremos necesito necesitas
+pasa pasó creo cree parece dónde cuándo cuánto mal nunca todavía antes
+después luego aunque mientras sin sobre entre contra desde durante según
+primera primero última último gran grande pequeño mismo misma demasiado
+muerto muerte dios amor chica chico niño niña mujer señora señorita`,
+}
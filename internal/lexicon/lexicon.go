@@ -0,0 +1,186 @@
+// Package lexicon rewrites text with user-supplied pronunciations before
+// synthesis.
+package lexicon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Word replaces a whole word or phrase wherever it appears.
+type Word struct {
+	Word string `json:"word"`
+	Say  string `json:"say"`
+	// CaseSensitive matches Word exactly; by default case is ignored.
+	CaseSensitive bool `json:"case_sensitive,omitempty"`
+	// Voices limits the entry to the named voices; empty means every voice.
+	Voices []string `json:"voices,omitempty"`
+}
+
+// Rule rewrites matches of a regular expression. Replace may refer to groups
+// as $1 or ${name}.
+type Rule struct {
+	Pattern       string   `json:"pattern"`
+	Replace       string   `json:"replace"`
+	CaseSensitive bool     `json:"case_sensitive,omitempty"`
+	Voices        []string `json:"voices,omitempty"`
+}
+
+// File is the JSON layout of a lexicon file. Words are applied before rules,
+// each in file order.
+type File struct {
+	Words []Word `json:"words"`
+	Rules []Rule `json:"rules"`
+}
+
+type entry struct {
+	re      *regexp.Regexp
+	replace string
+	// literal marks word entries, whose matches must sit on word boundaries
+	// and whose replacement is not expanded.
+	literal bool
+	voices  map[string]bool
+}
+
+// Lexicon is a compiled lexicon file. The zero value and nil change nothing.
+type Lexicon struct {
+	entries []entry
+}
+
+// Load reads and compiles a lexicon file.
+func Load(path string) (*Lexicon, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse compiles a lexicon from its JSON form.
+func Parse(data []byte) (*Lexicon, error) {
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse lexicon: %w", err)
+	}
+	return Compile(f)
+}
+
+// Compile validates f and compiles its words and rules.
+func Compile(f File) (*Lexicon, error) {
+	l := &Lexicon{}
+	for i, w := range f.Words {
+		word := strings.TrimSpace(w.Word)
+		if word == "" {
+			return nil, fmt.Errorf("word %d: word is required", i+1)
+		}
+		pattern := regexp.QuoteMeta(word)
+		if !w.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		l.entries = append(l.entries, entry{
+			re:      regexp.MustCompile(pattern),
+			replace: w.Say,
+			literal: true,
+			voices:  voiceSet(w.Voices),
+		})
+	}
+	for i, r := range f.Rules {
+		if r.Pattern == "" {
+			return nil, fmt.Errorf("rule %d: pattern is required", i+1)
+		}
+		pattern := r.Pattern
+		if !r.CaseSensitive {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		l.entries = append(l.entries, entry{re: re, replace: r.Replace, voices: voiceSet(r.Voices)})
+	}
+	return l, nil
+}
+
+// Len returns the number of words and rules in l.
+func (l *Lexicon) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.entries)
+}
+
+// Apply rewrites text for voice. Text no entry matches is returned unchanged,
+// so its cache key is unaffected by the lexicon.
+func (l *Lexicon) Apply(text, voice string) string {
+	if l == nil {
+		return text
+	}
+	for _, e := range l.entries {
+		if e.voices != nil && !e.voices[voice] {
+			continue
+		}
+		if e.literal {
+			text = replaceWord(e.re, text, e.replace)
+		} else {
+			text = e.re.ReplaceAllString(text, e.replace)
+		}
+	}
+	return text
+}
+
+// replaceWord replaces matches of re that are not part of a longer word.
+func replaceWord(re *regexp.Regexp, text, say string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringIndex(text, -1) {
+		if !boundary(text, m[0], m[1]) {
+			continue
+		}
+		b.WriteString(text[last:m[0]])
+		b.WriteString(say)
+		last = m[1]
+	}
+	if last == 0 {
+		return text
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// boundary reports whether text[start:end] is not preceded or followed by a
+// letter, digit or underscore.
+func boundary(text string, start, end int) bool {
+	if start > 0 {
+		r, _ := utf8.DecodeLastRuneInString(text[:start])
+		if isWordRune(r) {
+			return false
+		}
+	}
+	if end < len(text) {
+		r, _ := utf8.DecodeRuneInString(text[end:])
+		if isWordRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func voiceSet(voices []string) map[string]bool {
+	if len(voices) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(voices))
+	for _, v := range voices {
+		set[v] = true
+	}
+	return set
+}
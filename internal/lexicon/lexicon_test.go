@@ -0,0 +1,63 @@
+package lexicon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyWordsAndRules(t *testing.T) {
+	lex, err := Parse([]byte(`{
+		"words": [
+			{"word": "Siobhan", "say": "shi vawn"},
+			{"word": "nginx", "say": "engine x", "case_sensitive": true},
+			{"word": "SQL", "say": "sequel", "voices": ["amy"]}
+		],
+		"rules": [
+			{"pattern": "\\bk8s\\b", "replace": "kubernetes"},
+			{"pattern": "\\b([A-Z])-(\\d+)\\b", "replace": "$1 $2", "case_sensitive": true}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	cases := []struct{ text, voice, want string }{
+		{"Ask SIOBHAN about it", "amy", "Ask shi vawn about it"},
+		{"Siobhans are rare", "amy", "Siobhans are rare"},
+		{"nginx and NGINX", "amy", "engine x and NGINX"},
+		{"SQL and MySQL", "amy", "sequel and MySQL"},
+		{"SQL and MySQL", "ryan", "SQL and MySQL"},
+		{"Deploy K8s to room B-12", "ryan", "Deploy kubernetes to room B 12"},
+		{"nothing to see", "amy", "nothing to see"},
+	}
+	for _, c := range cases {
+		if got := lex.Apply(c.text, c.voice); got != c.want {
+			t.Fatalf("Apply(%q, %q) = %q, want %q", c.text, c.voice, got, c.want)
+		}
+	}
+	if lex.Len() != 5 {
+		t.Fatalf("Len = %d, want 5", lex.Len())
+	}
+
+	var none *Lexicon
+	if got := none.Apply("Siobhan", "amy"); got != "Siobhan" {
+		t.Fatalf("nil lexicon changed text to %q", got)
+	}
+}
+
+func TestLoadRejectsInvalidEntries(t *testing.T) {
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"empty-word.json": `{"words":[{"word":" ","say":"x"}]}`,
+		"bad-regex.json":  `{"rules":[{"pattern":"(","replace":"x"}]}`,
+		"bad-json.json":   `{"words":`,
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
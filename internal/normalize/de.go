@@ -0,0 +1,248 @@
+package normalize
+
+import (
+	"strconv"
+	"strings"
+)
+
+var (
+	deOnes = []string{"null", "eins", "zwei", "drei", "vier", "fünf", "sechs", "sieben", "acht", "neun",
+		"zehn", "elf", "zwölf", "dreizehn", "vierzehn", "fünfzehn", "sechzehn", "siebzehn", "achtzehn", "neunzehn"}
+	deTens   = []string{"", "zehn", "zwanzig", "dreißig", "vierzig", "fünfzig", "sechzig", "siebzig", "achtzig", "neunzig"}
+	deScales = []struct {
+		value       int64
+		one, plural string
+	}{{1e12, "eine Billion", "Billionen"}, {1e9, "eine Milliarde", "Milliarden"}, {1e6, "eine Million", "Millionen"}}
+	deMonths = []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli",
+		"August", "September", "Oktober", "November", "Dezember"}
+)
+
+// deCardinal spells n in German words.
+func deCardinal(n int64) string {
+	switch {
+	case n < 0:
+		return "minus " + deCardinal(-n)
+	case n < 20:
+		return deOnes[n]
+	case n < 100:
+		if n%10 == 0 {
+			return deTens[n/10]
+		}
+		return deCompound(n%10) + "und" + deTens[n/10]
+	case n < 1000:
+		return deCompound(n/100) + "hundert" + deRest(n%100)
+	case n < 1e6:
+		return deCompound(n/1000) + "tausend" + deRest(n%1000)
+	}
+	for _, sc := range deScales {
+		if n >= sc.value {
+			s := sc.one
+			if n/sc.value > 1 {
+				s = deCardinal(n/sc.value) + " " + sc.plural
+			}
+			if n%sc.value != 0 {
+				s += " " + deCardinal(n%sc.value)
+			}
+			return s
+		}
+	}
+	return ""
+}
+
+// deCompound is deCardinal for the first part of a compound ("ein" not "eins").
+func deCompound(n int64) string {
+	s := deCardinal(n)
+	if strings.HasSuffix(s, "eins") {
+		s = strings.TrimSuffix(s, "s")
+	}
+	return s
+}
+
+func deRest(n int64) string {
+	if n == 0 {
+		return ""
+	}
+	return deCardinal(n)
+}
+
+// deOrdinal spells n as a German ordinal in the dative ending "ten"
+// ("sechzehnten"), as dates are read: "am ersten Januar".
+func deOrdinal(n int64) string {
+	prefix, small := "", n%100
+	if small == 0 || small >= 20 {
+		return deCardinal(n) + "sten"
+	}
+	if n >= 100 {
+		prefix = deCardinal(n - small)
+	}
+	switch small {
+	case 1:
+		return prefix + "ersten"
+	case 3:
+		return prefix + "dritten"
+	case 7:
+		return prefix + "siebten"
+	case 8:
+		return prefix + "achten"
+	}
+	return prefix + deCardinal(small) + "ten"
+}
+
+// deYear reads years between 1100 and 1999 in hundreds ("neunzehnhundertfünf").
+func deYear(y int64) string {
+	if y >= 1100 && y < 2000 {
+		return deCardinal(y/100) + "hundert" + deRest(y%100)
+	}
+	return deCardinal(y)
+}
+
+// deNumber reads an integer string, with optional thousands dots, and an
+// optional decimal fraction. Plain four-digit numbers from 1100 to 1999 are
+// read in hundreds, as years are.
+func deNumber(whole, frac string) string {
+	n, err := strconv.ParseInt(strings.ReplaceAll(whole, ".", ""), 10, 64)
+	var s string
+	switch {
+	case err != nil || len(whole) > 15:
+		s = digits(whole, deOnes)
+	case frac == "" && len(whole) == 4 && n >= 1100 && n < 2000:
+		s = deYear(n)
+	default:
+		s = deCardinal(n)
+	}
+	if frac != "" {
+		s += " Komma " + digits(frac, deOnes)
+	}
+	return s
+}
+
+var deCurrencies = map[string][2]string{
+	"$": {"Dollar", "Cent"}, "USD": {"Dollar", "Cent"},
+	"€": {"Euro", "Cent"}, "EUR": {"Euro", "Cent"},
+	"£": {"Pfund", "Pence"}, "GBP": {"Pfund", "Pence"},
+	"₹": {"Rupien", "Paise"}, "INR": {"Rupien", "Paise"},
+}
+
+// deMoney reads an amount: "zwölf Euro fünfzig", "ein Euro", "fünfzig Cent".
+func deMoney(cur, whole, frac string) string {
+	names := deCurrencies[cur]
+	n, err := strconv.ParseInt(strings.ReplaceAll(whole, ".", ""), 10, 64)
+	if err != nil {
+		return deNumber(whole, frac) + " " + names[0]
+	}
+	cents := int64(0)
+	if frac != "" {
+		cents, _ = strconv.ParseInt((frac + "0")[:2], 10, 64)
+	}
+	switch {
+	case n == 0 && cents > 0:
+		return deCardinal(cents) + " " + names[1]
+	case cents == 0:
+		return deCompound(n) + " " + names[0]
+	default:
+		return deCompound(n) + " " + names[0] + " " + deCardinal(cents)
+	}
+}
+
+var deUnits = map[string][2]string{
+	"%": {"Prozent", "Prozent"}, "°C": {"Grad Celsius", "Grad Celsius"}, "°F": {"Grad Fahrenheit", "Grad Fahrenheit"},
+	"°": {"Grad", "Grad"}, "km/h": {"Kilometer pro Stunde", "Kilometer pro Stunde"},
+	"km": {"Kilometer", "Kilometer"}, "m": {"Meter", "Meter"}, "cm": {"Zentimeter", "Zentimeter"}, "mm": {"Millimeter", "Millimeter"},
+	"kg": {"Kilogramm", "Kilogramm"}, "mg": {"Milligramm", "Milligramm"}, "ml": {"Milliliter", "Milliliter"},
+	"KB": {"Kilobyte", "Kilobyte"}, "kB": {"Kilobyte", "Kilobyte"}, "MB": {"Megabyte", "Megabyte"},
+	"GB": {"Gigabyte", "Gigabyte"}, "TB": {"Terabyte", "Terabyte"}, "ms": {"Millisekunde", "Millisekunden"},
+	"Hz": {"Hertz", "Hertz"}, "kHz": {"Kilohertz", "Kilohertz"}, "MHz": {"Megahertz", "Megahertz"}, "GHz": {"Gigahertz", "Gigahertz"},
+	"W": {"Watt", "Watt"}, "kW": {"Kilowatt", "Kilowatt"}, "kWh": {"Kilowattstunde", "Kilowattstunden"},
+}
+
+// deFeminineUnits are the singular unit names counted with "eine".
+var deFeminineUnits = map[string]bool{"Millisekunde": true, "Kilowattstunde": true}
+
+func germanDate(y, mo, d int64) string {
+	if mo < 1 || mo > 12 || d < 1 || d > 31 {
+		return ""
+	}
+	return deOrdinal(d) + " " + deMonths[mo-1] + " " + deYear(y)
+}
+
+func germanRules() []Rule {
+	atoi := func(s string) int64 {
+		n, _ := strconv.ParseInt(s, 10, 64)
+		return n
+	}
+	return []Rule{
+		regexRule("urls", `\b(?:https?://|www\.)[^\s<>"]*[^\s<>".,;:!?)]`, func(m []string) string {
+			return urlHost(m[0], "Punkt")
+		}),
+		regexRule("versions", `\b(v|[Vv]ersion )(\d+(?:\.\d+)+)\b`, func(m []string) string {
+			parts := strings.Split(m[2], ".")
+			for i, p := range parts {
+				parts[i] = deNumber(p, "")
+			}
+			return "Version " + strings.Join(parts, " Punkt ")
+		}),
+		regexRule("dates", `\b(\d{4})-(\d{2})-(\d{2})\b`, func(m []string) string {
+			if s := germanDate(atoi(m[1]), atoi(m[2]), atoi(m[3])); s != "" {
+				return s
+			}
+			return m[0]
+		}),
+		regexRule("dotted dates", `\b(\d{1,2})\.(\d{1,2})\.(\d{4})\b`, func(m []string) string {
+			if s := germanDate(atoi(m[3]), atoi(m[2]), atoi(m[1])); s != "" {
+				return s
+			}
+			return m[0]
+		}),
+		regexRule("times", `\b(\d{1,2}):(\d{2})(?::\d{2})?(\s?Uhr\b)?`, func(m []string) string {
+			h, min := atoi(m[1]), atoi(m[2])
+			if h > 23 || min > 59 {
+				return m[0]
+			}
+			s := deCompound(h) + " Uhr"
+			if min > 0 {
+				s += " " + deCardinal(min)
+			}
+			return s
+		}),
+		regexRule("currencies", `([$€£₹])\s?(\d{1,3}(?:\.\d{3})+|\d+)(?:,(\d{1,2}|-{1,2}))?`, func(m []string) string {
+			return deMoney(m[1], m[2], strings.Trim(m[3], "-"))
+		}),
+		regexRule("currency codes", `\b(\d{1,3}(?:\.\d{3})+|\d+)(?:,(\d{1,2}|-{1,2}))?\s?(€|\$|£|₹|EUR|USD|GBP|INR)`, func(m []string) string {
+			return deMoney(m[3], m[1], strings.Trim(m[2], "-"))
+		}),
+		regexRule("units", `\b(\d{1,3}(?:\.\d{3})+|\d+)(?:,(\d+))?\s?(`+unitPattern(deUnits)+`)([^\p{L}\p{N}]|$)`, func(m []string) string {
+			names := deUnits[m[3]]
+			if m[1] == "1" && m[2] == "" {
+				one := deCompound(1)
+				if deFeminineUnits[names[0]] {
+					one += "e"
+				}
+				return one + " " + names[0] + m[4]
+			}
+			return deNumber(m[1], m[2]) + " " + names[1] + m[4]
+		}),
+		regexRule("number signs", `#(\d+)\b`, func(m []string) string {
+			return "Nummer " + m[1]
+		}),
+		replacer("abbreviations", [][2]string{
+			{`\bz\.\s?B\.`, "zum Beispiel"},
+			{`\bd\.\s?h\.`, "das heißt"},
+			{`\bu\.\s?a\.`, "unter anderem"},
+			{`\busw\.($|\s+\p{Lu})`, "und so weiter.$1"},
+			{`\busw\.`, "und so weiter"},
+			{`\bbzw\.`, "beziehungsweise"},
+			{`\bca\.`, "circa"},
+			{`\bggf\.`, "gegebenenfalls"},
+			{`\bNr\.(\s*\d)`, "Nummer$1"},
+			{`\bDr\.(\s+\p{Lu})`, "Doktor$1"},
+			{`\bStr\.`, "Straße"},
+		}),
+		regexRule("numbers", `(^|[^\w.,])(-)?(\d{1,3}(?:\.\d{3})+|\d+)(?:,(\d+))?\b`, func(m []string) string {
+			s := deNumber(m[3], m[4])
+			if m[2] != "" {
+				s = "minus " + s
+			}
+			return m[1] + s
+		}),
+	}
+}
@@ -0,0 +1,245 @@
+package normalize
+
+import (
+	"strconv"
+	"strings"
+)
+
+var (
+	enOnes = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	enTens   = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+	enScales = []struct {
+		value int64
+		name  string
+	}{{1e12, "trillion"}, {1e9, "billion"}, {1e6, "million"}, {1e3, "thousand"}}
+	enMonths = []string{"January", "February", "March", "April", "May", "June", "July",
+		"August", "September", "October", "November", "December"}
+)
+
+// enCardinal spells n in English words.
+func enCardinal(n int64) string {
+	switch {
+	case n < 0:
+		return "minus " + enCardinal(-n)
+	case n < 20:
+		return enOnes[n]
+	case n < 100:
+		if n%10 == 0 {
+			return enTens[n/10]
+		}
+		return enTens[n/10] + "-" + enOnes[n%10]
+	case n < 1000:
+		s := enOnes[n/100] + " hundred"
+		if n%100 != 0 {
+			s += " " + enCardinal(n%100)
+		}
+		return s
+	}
+	for _, sc := range enScales {
+		if n >= sc.value {
+			s := enCardinal(n/sc.value) + " " + sc.name
+			if n%sc.value != 0 {
+				s += " " + enCardinal(n%sc.value)
+			}
+			return s
+		}
+	}
+	return ""
+}
+
+var enIrregularOrdinals = map[string]string{
+	"one": "first", "two": "second", "three": "third", "five": "fifth",
+	"eight": "eighth", "nine": "ninth", "twelve": "twelfth",
+}
+
+// enOrdinal spells n as an English ordinal ("twenty-first").
+func enOrdinal(n int64) string {
+	words := enCardinal(n)
+	cut := strings.LastIndexAny(words, " -") + 1
+	last := words[cut:]
+	switch {
+	case enIrregularOrdinals[last] != "":
+		last = enIrregularOrdinals[last]
+	case strings.HasSuffix(last, "y"):
+		last = strings.TrimSuffix(last, "y") + "ieth"
+	default:
+		last += "th"
+	}
+	return words[:cut] + last
+}
+
+// enYear reads a year the way it is spoken: "nineteen oh five", "twenty
+// twenty-six", "two thousand five".
+func enYear(y int64) string {
+	switch {
+	case y < 1100 || y > 9999 || (y >= 2000 && y < 2010):
+		return enCardinal(y)
+	case y%100 == 0:
+		return enCardinal(y/100) + " hundred"
+	case y%100 < 10:
+		return enCardinal(y/100) + " oh " + enOnes[y%100]
+	default:
+		return enCardinal(y/100) + " " + enCardinal(y%100)
+	}
+}
+
+// enNumber reads an integer string, with optional thousands commas, and an
+// optional decimal fraction. Plain four-digit numbers from 1100 to 1999 are
+// read in hundreds, which suits both years and counts.
+func enNumber(whole, frac string) string {
+	n, err := strconv.ParseInt(strings.ReplaceAll(whole, ",", ""), 10, 64)
+	var s string
+	switch {
+	case err != nil || len(whole) > 15:
+		s = digits(whole, enOnes)
+	case frac == "" && len(whole) == 4 && n >= 1100 && n < 2000:
+		s = enYear(n)
+	default:
+		s = enCardinal(n)
+	}
+	if frac != "" {
+		s += " point " + digits(frac, enOnes)
+	}
+	return s
+}
+
+var enCurrencies = map[string][4]string{
+	"$": {"dollar", "dollars", "cent", "cents"}, "USD": {"dollar", "dollars", "cent", "cents"},
+	"€": {"euro", "euros", "cent", "cents"}, "EUR": {"euro", "euros", "cent", "cents"},
+	"£": {"pound", "pounds", "penny", "pence"}, "GBP": {"pound", "pounds", "penny", "pence"},
+	"₹": {"rupee", "rupees", "paisa", "paise"}, "INR": {"rupee", "rupees", "paisa", "paise"},
+}
+
+// enMoney reads an amount such as 12.5 in currency cur.
+func enMoney(cur, whole, frac string) string {
+	names := enCurrencies[cur]
+	n, err := strconv.ParseInt(strings.ReplaceAll(whole, ",", ""), 10, 64)
+	if err != nil {
+		return enNumber(whole, frac) + " " + names[1]
+	}
+	cents := int64(0)
+	if frac != "" {
+		cents, _ = strconv.ParseInt((frac + "0")[:2], 10, 64)
+	}
+	plural := func(v int64, one, many string) string {
+		if v == 1 {
+			return enCardinal(v) + " " + one
+		}
+		return enCardinal(v) + " " + many
+	}
+	switch {
+	case cents == 0:
+		return plural(n, names[0], names[1])
+	case n == 0:
+		return plural(cents, names[2], names[3])
+	default:
+		return plural(n, names[0], names[1]) + " and " + plural(cents, names[2], names[3])
+	}
+}
+
+var enUnits = map[string][2]string{
+	"%": {"percent", "percent"}, "°C": {"degree Celsius", "degrees Celsius"}, "°F": {"degree Fahrenheit", "degrees Fahrenheit"},
+	"°": {"degree", "degrees"}, "km/h": {"kilometer per hour", "kilometers per hour"}, "mph": {"mile per hour", "miles per hour"},
+	"km": {"kilometer", "kilometers"}, "m": {"meter", "meters"}, "cm": {"centimeter", "centimeters"}, "mm": {"millimeter", "millimeters"},
+	"kg": {"kilogram", "kilograms"}, "mg": {"milligram", "milligrams"}, "ml": {"milliliter", "milliliters"},
+	"KB": {"kilobyte", "kilobytes"}, "kB": {"kilobyte", "kilobytes"}, "MB": {"megabyte", "megabytes"},
+	"GB": {"gigabyte", "gigabytes"}, "TB": {"terabyte", "terabytes"}, "ms": {"millisecond", "milliseconds"},
+	"Hz": {"hertz", "hertz"}, "kHz": {"kilohertz", "kilohertz"}, "MHz": {"megahertz", "megahertz"}, "GHz": {"gigahertz", "gigahertz"},
+	"W": {"watt", "watts"}, "kW": {"kilowatt", "kilowatts"}, "kWh": {"kilowatt hour", "kilowatt hours"},
+}
+
+func englishRules() []Rule {
+	return []Rule{
+		regexRule("urls", `\b(?:https?://|www\.)[^\s<>"]*[^\s<>".,;:!?)]`, func(m []string) string {
+			return urlHost(m[0], "dot")
+		}),
+		regexRule("versions", `\b(v|version )?(\d+(?:\.\d+){2,}|\d+\.\d+)\b`, func(m []string) string {
+			if m[1] == "" && strings.Count(m[2], ".") < 2 {
+				return m[0] // a plain decimal
+			}
+			parts := strings.Split(m[2], ".")
+			for i, p := range parts {
+				parts[i] = enNumber(p, "")
+			}
+			s := strings.Join(parts, " point ")
+			if m[1] != "" {
+				s = "version " + s
+			}
+			return s
+		}),
+		regexRule("dates", `\b(\d{4})-(\d{2})-(\d{2})\b`, func(m []string) string {
+			y, _ := strconv.ParseInt(m[1], 10, 64)
+			mo, _ := strconv.Atoi(m[2])
+			d, _ := strconv.ParseInt(m[3], 10, 64)
+			if mo < 1 || mo > 12 || d < 1 || d > 31 {
+				return m[0]
+			}
+			return enMonths[mo-1] + " " + enOrdinal(d) + ", " + enYear(y)
+		}),
+		regexRule("times", `\b(\d{1,2}):(\d{2})(?::\d{2})?(?:\s?([aApP])\.?[mM]\b\.?)?`, func(m []string) string {
+			h, _ := strconv.ParseInt(m[1], 10, 64)
+			min, _ := strconv.ParseInt(m[2], 10, 64)
+			if h > 23 || min > 59 || (m[3] != "" && (h < 1 || h > 12)) {
+				return m[0]
+			}
+			s := enCardinal(h)
+			switch {
+			case min == 0 && m[3] == "":
+				s += " o'clock"
+			case min == 0:
+			case min < 10:
+				s += " oh " + enOnes[min]
+			default:
+				s += " " + enCardinal(min)
+			}
+			if m[3] != "" {
+				s += " " + strings.ToLower(m[3]) + " m"
+			}
+			return s
+		}),
+		regexRule("currencies", `([$€£₹])\s?(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d{1,2}))?\b`, func(m []string) string {
+			return enMoney(m[1], m[2], m[3])
+		}),
+		regexRule("currency codes", `\b(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d{1,2}))?\s?(USD|EUR|GBP|INR)\b`, func(m []string) string {
+			return enMoney(m[3], m[1], m[2])
+		}),
+		regexRule("units", `\b(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d+))?\s?(`+unitPattern(enUnits)+`)([^\p{L}\p{N}]|$)`, func(m []string) string {
+			names := enUnits[m[3]]
+			name := names[1]
+			if m[1] == "1" && m[2] == "" {
+				name = names[0]
+			}
+			return enNumber(m[1], m[2]) + " " + name + m[4]
+		}),
+		regexRule("number signs", `#(\d+)\b`, func(m []string) string {
+			return "number " + m[1]
+		}),
+		regexRule("ordinals", `\b(\d+)(?:st|nd|rd|th)\b`, func(m []string) string {
+			n, err := strconv.ParseInt(m[1], 10, 64)
+			if err != nil {
+				return m[0]
+			}
+			return enOrdinal(n)
+		}),
+		replacer("abbreviations", [][2]string{
+			{`\be\.g\.,?`, "for example"},
+			{`\bi\.e\.,?`, "that is"},
+			{`\betc\.($|\s+\p{Lu})`, "et cetera.$1"},
+			{`\betc\.`, "et cetera"},
+			{`\bvs\.`, "versus"},
+			{`\bapprox\.`, "approximately"},
+			{`\bDr\.(\s+\p{Lu})`, "Doctor$1"},
+			{`\bMr\.(\s+\p{Lu})`, "Mister$1"},
+			{`\bMrs\.(\s+\p{Lu})`, "Missus$1"},
+			{`\bNo\.(\s*\d)`, "number$1"},
+		}),
+		regexRule("numbers", `(^|[^\w.,])(-)?(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d+))?\b`, func(m []string) string {
+			s := enNumber(m[3], m[4])
+			if m[2] != "" {
+				s = "minus " + s
+			}
+			return m[1] + s
+		}),
+	}
+}
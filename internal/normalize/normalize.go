@@ -0,0 +1,142 @@
+// Package normalize rewrites text into the words a voice should speak:
+// numbers, dates, times, currencies, units, abbreviations, URLs and version
+// strings. Each language has an ordered list of rules; languages without rules
+// only get whitespace collapsed.
+package normalize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Rule is one named rewrite step.
+type Rule struct {
+	Name  string
+	Apply func(string) string
+}
+
+// Step records the text after a rule that changed it.
+type Step struct {
+	Rule string `json:"rule"`
+	Text string `json:"text"`
+}
+
+// Pipeline holds the rules for each supported language.
+type Pipeline struct {
+	langs map[string][]Rule
+}
+
+// New returns a pipeline with the built-in English and German rules.
+func New() Pipeline {
+	return Pipeline{langs: map[string][]Rule{
+		"en": englishRules(),
+		"de": germanRules(),
+	}}
+}
+
+// Languages lists the languages that have rules.
+func (p Pipeline) Languages() []string {
+	out := make([]string, 0, len(p.langs))
+	for lang := range p.langs {
+		out = append(out, lang)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Normalize applies the rules for lang (a primary language subtag such as
+// "en") to text.
+func (p Pipeline) Normalize(text, lang string) string {
+	out, _ := p.Trace(text, lang)
+	return out
+}
+
+// Trace is Normalize that also reports each rule that changed the text.
+func (p Pipeline) Trace(text, lang string) (string, []Step) {
+	var steps []Step
+	text = collapse(text)
+	for _, r := range p.langs[lang] {
+		next := collapse(r.Apply(text))
+		if next != text {
+			steps = append(steps, Step{Rule: r.Name, Text: next})
+			text = next
+		}
+	}
+	return text, steps
+}
+
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// regexRule builds a rule replacing each match of pattern with fn's result for
+// its submatches.
+func regexRule(name, pattern string, fn func(m []string) string) Rule {
+	re := regexp.MustCompile(pattern)
+	return Rule{Name: name, Apply: func(s string) string {
+		return re.ReplaceAllStringFunc(s, func(match string) string {
+			return fn(re.FindStringSubmatch(match))
+		})
+	}}
+}
+
+// replacer builds a rule of literal word replacements; keys are regexps.
+func replacer(name string, pairs [][2]string) Rule {
+	type pair struct {
+		re  *regexp.Regexp
+		out string
+	}
+	compiled := make([]pair, len(pairs))
+	for i, p := range pairs {
+		compiled[i] = pair{regexp.MustCompile(p[0]), p[1]}
+	}
+	return Rule{Name: name, Apply: func(s string) string {
+		for _, p := range compiled {
+			s = p.re.ReplaceAllString(s, p.out)
+		}
+		return s
+	}}
+}
+
+// digits spells each digit of s with words, e.g. for decimal fractions.
+func digits(s string, words []string) string {
+	out := make([]string, 0, len(s))
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			out = append(out, words[r-'0'])
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// urlHost reduces a URL to its host without "www.", joined by sep.
+func urlHost(url, sep string) string {
+	host := url
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if i := strings.IndexAny(host, "/?#:"); i >= 0 {
+		host = host[:i]
+	}
+	host = strings.TrimPrefix(host, "www.")
+	return strings.Join(strings.Split(host, "."), " "+sep+" ")
+}
+
+// unitPattern joins unit symbols, longest first, into a regexp alternation.
+func unitPattern(units map[string][2]string) string {
+	keys := make([]string, 0, len(units))
+	for k := range units {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) > len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	for i, k := range keys {
+		keys[i] = regexp.QuoteMeta(k)
+	}
+	return strings.Join(keys, "|")
+}
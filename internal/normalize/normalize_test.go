@@ -0,0 +1,61 @@
+package normalize
+
+import "testing"
+
+func TestNormalizeEnglish(t *testing.T) {
+	p := New()
+	cases := map[string]string{
+		"Release on 2026-10-16 at 15:30.":     "Release on October sixteenth, twenty twenty-six at fifteen thirty.",
+		"It is 15°C outside, 98.6 °F inside":  "It is fifteen degrees Celsius outside, ninety-eight point six degrees Fahrenheit inside",
+		"PR #4821 merged":                     "PR number four thousand eight hundred twenty-one merged",
+		"Upgrade to v1.12.0 now":              "Upgrade to version one point twelve point zero now",
+		"The bill is $12.50, or £1":           "The bill is twelve dollars and fifty cents, or one pound",
+		"Meet at 9:05 am, e.g. in room 2":     "Meet at nine oh five a m, for example in room two",
+		"See https://www.example.com/docs/x.": "See example dot com.",
+		"Disk at 95%, 1,024 MB free":          "Disk at ninety-five percent, one thousand twenty-four megabytes free",
+		"The 21st and 112th runs":             "The twenty-first and one hundred twelfth runs",
+		"It dropped to -5 by 10:00":           "It dropped to minus five by ten o'clock",
+		"Dr. Smith owes 3,000,000 USD":        "Doctor Smith owes three million dollars",
+		"mp3 files and x86 builds stay as is": "mp3 files and x86 builds stay as is",
+		"Born 1905, moved 2005, left 1900":    "Born nineteen oh five, moved two thousand five, left nineteen hundred",
+		"Apples, pears etc. More fruit":       "Apples, pears et cetera. More fruit",
+		"   spaced \n  out   ":                "spaced out",
+	}
+	for in, want := range cases {
+		if got := p.Normalize(in, "en"); got != want {
+			t.Errorf("Normalize(%q)\n got  %q\n want %q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizeGerman(t *testing.T) {
+	p := New()
+	cases := map[string]string{
+		"Termin am 16.10.2026 um 15:30 Uhr": "Termin am sechzehnten Oktober zweitausendsechsundzwanzig um fünfzehn Uhr dreißig",
+		"Es sind 21,5 °C und 1 km/h Wind":   "Es sind einundzwanzig Komma fünf Grad Celsius und ein Kilometer pro Stunde Wind",
+		"Kosten: 12,50 € bzw. 1.250 EUR":    "Kosten: zwölf Euro fünfzig beziehungsweise eintausendzweihundertfünfzig Euro",
+		"Ticket #17, z.B. morgen um 1:00":   "Ticket Nummer siebzehn, zum Beispiel morgen um ein Uhr",
+		"Jahr 1989, 101 Tage":               "Jahr neunzehnhundertneunundachtzig, einhunderteins Tage",
+		"Am 2026-01-01 kommt Version 2.1":   "Am ersten Januar zweitausendsechsundzwanzig kommt Version zwei Punkt eins",
+		"Vom 03.07.2026, 1 ms, 1 kWh":       "Vom dritten Juli zweitausendsechsundzwanzig, eine Millisekunde, eine Kilowattstunde",
+	}
+	for in, want := range cases {
+		if got := p.Normalize(in, "de"); got != want {
+			t.Errorf("Normalize(%q)\n got  %q\n want %q", in, got, want)
+		}
+	}
+}
+
+func TestTraceAndUnknownLanguage(t *testing.T) {
+	p := New()
+	out, steps := p.Trace("PR #7 on 2026-10-16", "en")
+	if out != "PR number seven on October sixteenth, twenty twenty-six" || len(steps) != 3 {
+		t.Fatalf("Trace = %q %+v", out, steps)
+	}
+	if steps[0].Rule != "dates" || steps[1].Rule != "number signs" || steps[2].Rule != "numbers" {
+		t.Fatalf("unexpected rule order %+v", steps)
+	}
+	if got := p.Normalize("  Tür   #7 ", "hi"); got != "Tür #7" {
+		t.Fatalf("unknown language should only collapse whitespace, got %q", got)
+	}
+}
@@ -0,0 +1,138 @@
+package peer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when no peer holds the requested cache entry.
+var ErrNotFound = errors.New("not found on any peer")
+
+// Client fetches cached wav files from other tts-cached instances.
+type Client struct {
+	peers    []string
+	timeout  time.Duration
+	maxBytes int64
+	http     *http.Client
+	logger   *log.Logger
+}
+
+// NewClient creates a Client for the given peer base URLs (e.g. http://host:4410).
+// Each peer request is bounded by timeout and responses larger than maxBytes are rejected.
+func NewClient(peers []string, timeout time.Duration, maxBytes int64, logger *log.Logger) *Client {
+	if logger == nil {
+		logger = log.Default()
+	}
+	cleaned := make([]string, 0, len(peers))
+	for _, p := range peers {
+		if p = strings.TrimRight(strings.TrimSpace(p), "/"); p != "" {
+			cleaned = append(cleaned, p)
+		}
+	}
+	return &Client{
+		peers:    cleaned,
+		timeout:  timeout,
+		maxBytes: maxBytes,
+		http:     &http.Client{},
+		logger:   logger,
+	}
+}
+
+// Enabled reports whether any peers are configured.
+func (c *Client) Enabled() bool {
+	return c != nil && len(c.peers) > 0
+}
+
+// Fetch asks each peer in order for the wav stored under key and writes the first
+// valid response to outPath via a temp file. It returns the peer that served it.
+func (c *Client) Fetch(ctx context.Context, key, outPath string) (string, error) {
+	for _, p := range c.peers {
+		err := c.fetchOne(ctx, p, key, outPath)
+		if err == nil {
+			return p, nil
+		}
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		if !errors.Is(err, ErrNotFound) {
+			c.logger.Printf("ERROR: peer fetch from %s failed: %v", p, err)
+		}
+	}
+	return "", ErrNotFound
+}
+
+func (c *Client) fetchOne(ctx context.Context, peer, key, outPath string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, peer+"/cache/"+key+".wav", nil)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	case resp.ContentLength > c.maxBytes:
+		return fmt.Errorf("response too large (%d bytes)", resp.ContentLength)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(outPath), filepath.Base(outPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	n, err := io.Copy(tmp, io.LimitReader(resp.Body, c.maxBytes+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("read body: %w", err)
+	}
+	if n > c.maxBytes {
+		return fmt.Errorf("response exceeds %d bytes", c.maxBytes)
+	}
+	if err := checkWavHeader(tmpPath); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, outPath); err != nil {
+		return fmt.Errorf("rename peer output failed: %w", err)
+	}
+	return nil
+}
+
+// checkWavHeader guards against caching error pages or truncated bodies.
+func checkWavHeader(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hdr := make([]byte, 12)
+	if _, err := io.ReadFull(f, hdr); err != nil {
+		return errors.New("response is not a wav file")
+	}
+	if string(hdr[0:4]) != "RIFF" || string(hdr[8:12]) != "WAVE" {
+		return errors.New("response is not a wav file")
+	}
+	return nil
+}
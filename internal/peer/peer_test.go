@@ -0,0 +1,94 @@
+package peer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var wavBytes = []byte("RIFF\x00\x00\x00\x00WAVEfmt ")
+
+func TestFetchFallsThroughToPeerWithEntry(t *testing.T) {
+	missing := httptest.NewServer(http.NotFoundHandler())
+	defer missing.Close()
+
+	var gotPath string
+	holder := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		_, _ = w.Write(wavBytes)
+	}))
+	defer holder.Close()
+
+	out := filepath.Join(t.TempDir(), "abc.wav")
+	c := NewClient([]string{missing.URL, holder.URL + "/"}, time.Second, 1024, logDiscard)
+
+	from, err := c.Fetch(context.Background(), "abc", out)
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if from != holder.URL {
+		t.Fatalf("expected entry from %s, got %s", holder.URL, from)
+	}
+	if gotPath != "/cache/abc.wav" {
+		t.Fatalf("unexpected request path %s", gotPath)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if string(data) != string(wavBytes) {
+		t.Fatalf("unexpected output %q", data)
+	}
+}
+
+func TestFetchRejectsOversizedAndInvalid(t *testing.T) {
+	big := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/wav")
+		_, _ = w.Write(append(append([]byte{}, wavBytes...), make([]byte, 64)...))
+	}))
+	defer big.Close()
+	notWav := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html>oops</html>"))
+	}))
+	defer notWav.Close()
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "abc.wav")
+	c := NewClient([]string{big.URL, notWav.URL}, time.Second, 32, logDiscard)
+
+	if _, err := c.Fetch(context.Background(), "abc", out); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Fatalf("expected no files left behind, found %d", len(entries))
+	}
+}
+
+func TestFetchTimeout(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+	}))
+	defer slow.Close()
+
+	c := NewClient([]string{slow.URL}, 50*time.Millisecond, 1024, logDiscard)
+	start := time.Now()
+	if _, err := c.Fetch(context.Background(), "abc", filepath.Join(t.TempDir(), "abc.wav")); err == nil {
+		t.Fatalf("expected error from slow peer")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("peer timeout not honoured, took %s", elapsed)
+	}
+}
+
+var logDiscard = log.New(io.Discard, "", 0)
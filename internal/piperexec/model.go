@@ -0,0 +1,49 @@
+package piperexec
+
+import (
+	"strconv"
+
+	"github.com/venkytv/tts-cached/internal/engine"
+	"github.com/venkytv/tts-cached/internal/pipermodel"
+)
+
+// params are the per-request settings piper accepts as flags.
+var params = []string{
+	engine.ParamSpeaker,
+	engine.ParamLengthScale,
+	engine.ParamNoiseScale,
+	engine.ParamNoiseW,
+	engine.ParamSentenceSilence,
+}
+
+// capabilities describes a piper model; with metadata it includes the model's
+// language and speakers, and single-speaker models drop the speaker parameter.
+func capabilities(meta *pipermodel.Config, sampleRate int, streaming bool) engine.Capabilities {
+	caps := engine.Capabilities{SampleRate: sampleRate, Streaming: streaming, Params: params}
+	if meta == nil {
+		return caps
+	}
+	if lang := meta.LanguageCode(); lang != "" {
+		caps.Languages = []string{lang}
+	}
+	caps.Speakers = meta.Speakers()
+	caps.SpeakerAliases = meta.SpeakerAliases()
+	if len(caps.Speakers) == 0 {
+		caps.Params = params[1:]
+	}
+	return caps
+}
+
+// resolveSpeaker replaces a speaker name from the model's speaker_id_map with
+// the numeric id piper expects.
+func resolveSpeaker(meta *pipermodel.Config, req engine.Request) (engine.Request, error) {
+	if meta == nil || req.Params.Speaker == "" {
+		return req, nil
+	}
+	id, err := meta.SpeakerID(req.Params.Speaker)
+	if err != nil {
+		return req, err
+	}
+	req.Params.Speaker = strconv.Itoa(id)
+	return req, nil
+}
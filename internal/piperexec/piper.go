@@ -1,53 +1,98 @@
 package piperexec
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/venkytv/tts-cached/internal/engine"
+	"github.com/venkytv/tts-cached/internal/pipermodel"
 )
 
 // Runner executes the Piper CLI to synthesize audio.
 type Runner struct {
-	execPath string
-	model    string
-	flags    []string
-	logger   *log.Logger
+	execPath   string
+	model      string
+	flags      []string
+	sampleRate int
+	meta       *pipermodel.Config
+	logger     *log.Logger
 }
 
-// New creates a Runner for the given executable, model, and flags.
-func New(execPath, model string, flags []string, logger *log.Logger) Runner {
+// New creates a Runner for the given executable, model, flags, and the
+// model's output sample rate.
+func New(execPath, model string, flags []string, sampleRate int, logger *log.Logger) Runner {
 	if logger == nil {
 		logger = log.Default()
 	}
 	return Runner{
-		execPath: execPath,
-		model:    model,
-		flags:    flags,
-		logger:   logger,
+		execPath:   execPath,
+		model:      model,
+		flags:      flags,
+		sampleRate: sampleRate,
+		logger:     logger,
 	}
 }
 
-// Synthesize runs piper with stdin text and writes output to outPath using a temp file.
-func (r Runner) Synthesize(ctx context.Context, text, outPath string) error {
-	tmpPath := outPath + ".tmp"
-	_ = os.Remove(tmpPath)
+// WithMetadata returns a copy of r using the model's .onnx.json for its sample
+// rate, language and speaker names.
+func (r Runner) WithMetadata(meta pipermodel.Config) Runner {
+	r.meta = &meta
+	r.sampleRate = meta.Audio.SampleRate
+	return r
+}
+
+// Capabilities reports the model's sample rate; raw output makes it streamable.
+func (r Runner) Capabilities() engine.Capabilities {
+	return capabilities(r.meta, r.sampleRate, true)
+}
 
-	args := []string{
-		"-m", r.model,
-		"-f", tmpPath,
+func (r Runner) args(req engine.Request, output ...string) ([]string, error) {
+	req, err := resolveSpeaker(r.meta, req)
+	if err != nil {
+		return nil, err
+	}
+	args := append([]string{"-m", r.model}, output...)
+	args = append(args, r.flags...)
+	// Request parameters follow PIPER_FLAGS so they take precedence.
+	if req.Params.Speaker != "" {
+		args = append(args, "--speaker", req.Params.Speaker)
+	}
+	for _, name := range params[1:] {
+		if v, ok := req.Params.Floats()[name]; ok {
+			args = append(args, "--"+name, engine.FormatFloat(v))
+		}
 	}
-	if len(r.flags) > 0 {
-		args = append(args, r.flags...)
+	return args, nil
+}
+
+// Synthesize runs piper with stdin text and writes output to outPath using a
+// uniquely named temp file, so concurrent writers never share a partial file.
+func (r Runner) Synthesize(ctx context.Context, req engine.Request, outPath string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(outPath), filepath.Base(outPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+
+	args, err := r.args(req, "-f", tmpPath)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
 	}
 
 	r.logger.Printf("INFO: invoking piper exec=%s args=%v", r.execPath, args)
 
 	cmd := exec.CommandContext(ctx, r.execPath, args...)
-	cmd.Stdin = strings.NewReader(text)
+	cmd.Stdin = strings.NewReader(req.Text)
 
 	start := time.Now()
 	if output, err := cmd.CombinedOutput(); err != nil {
@@ -63,3 +108,28 @@ func (r Runner) Synthesize(ctx context.Context, text, outPath string) error {
 	}
 	return nil
 }
+
+// SynthesizeRaw runs piper with --output_raw and copies 16-bit mono PCM to w as
+// it is produced, so playback can begin before synthesis finishes.
+func (r Runner) SynthesizeRaw(ctx context.Context, req engine.Request, w io.Writer) error {
+	args, err := r.args(req, "--output_raw")
+	if err != nil {
+		return err
+	}
+
+	r.logger.Printf("INFO: invoking piper (raw) exec=%s args=%v", r.execPath, args)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.execPath, args...)
+	cmd.Stdin = strings.NewReader(req.Text)
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	if err := cmd.Run(); err != nil {
+		r.logger.Printf("ERROR: piper failed after %s: %v (output: %s)", time.Since(start).Round(time.Millisecond), err, strings.TrimSpace(stderr.String()))
+		return fmt.Errorf("piper exec failed: %w", err)
+	}
+	r.logger.Printf("INFO: piper (raw) completed in %s", time.Since(start).Round(time.Millisecond))
+	return nil
+}
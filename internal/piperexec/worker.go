@@ -0,0 +1,317 @@
+package piperexec
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/engine"
+	"github.com/venkytv/tts-cached/internal/pipermodel"
+)
+
+// ErrPoolClosed is returned by Synthesize after Close.
+var ErrPoolClosed = errors.New("piper worker pool closed")
+
+const workerStopTimeout = 5 * time.Second
+
+// Pool keeps resident Piper processes running in --json-input mode so the model
+// is loaded once instead of on every request. Each worker handles one request at
+// a time; crashed or cancelled workers are restarted on next use.
+type Pool struct {
+	execPath   string
+	model      string
+	flags      []string
+	sampleRate int
+	meta       *pipermodel.Config
+	logger     *log.Logger
+	// oneShot handles requests with settings JSON input cannot carry.
+	oneShot Runner
+
+	idle chan *worker
+
+	mu     sync.Mutex
+	closed bool
+	all    []*worker
+}
+
+// NewPool creates a pool of size resident workers. Processes start lazily.
+func NewPool(execPath, model string, flags []string, sampleRate, size int, logger *log.Logger) *Pool {
+	if logger == nil {
+		logger = log.Default()
+	}
+	if size < 1 {
+		size = 1
+	}
+	p := &Pool{
+		execPath:   execPath,
+		model:      model,
+		flags:      flags,
+		sampleRate: sampleRate,
+		logger:     logger,
+		oneShot:    New(execPath, model, flags, sampleRate, logger),
+		idle:       make(chan *worker, size),
+	}
+	for i := 0; i < size; i++ {
+		w := &worker{id: i + 1}
+		p.all = append(p.all, w)
+		p.idle <- w
+	}
+	return p
+}
+
+// WithMetadata applies the model's .onnx.json for the sample rate, language
+// and speaker names. Call it before the first Synthesize.
+func (p *Pool) WithMetadata(meta pipermodel.Config) *Pool {
+	p.meta = &meta
+	p.sampleRate = meta.Audio.SampleRate
+	p.oneShot = p.oneShot.WithMetadata(meta)
+	return p
+}
+
+// Capabilities reports the model's sample rate. Workers write files only, so
+// the pool cannot stream.
+func (p *Pool) Capabilities() engine.Capabilities {
+	return capabilities(p.meta, p.sampleRate, false)
+}
+
+// Synthesize sends text to an idle worker and waits for it to report outPath's temp file.
+func (p *Pool) Synthesize(ctx context.Context, req engine.Request, outPath string) error {
+	if p.isClosed() {
+		return ErrPoolClosed
+	}
+	if len(req.Params.Floats()) > 0 {
+		// Piper's JSON input only accepts a speaker; scales need a fresh process.
+		return p.oneShot.Synthesize(ctx, req, outPath)
+	}
+	req, err := resolveSpeaker(p.meta, req)
+	if err != nil {
+		return err
+	}
+
+	var w *worker
+	select {
+	case w = <-p.idle:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { p.idle <- w }()
+
+	if p.isClosed() {
+		return ErrPoolClosed
+	}
+
+	if !w.alive() {
+		if err := w.start(p); err != nil {
+			return fmt.Errorf("start piper worker: %w", err)
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(outPath), filepath.Base(outPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+
+	start := time.Now()
+	if err := w.synthesize(ctx, req, tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
+		p.logger.Printf("ERROR: piper worker %d failed after %s: %v", w.id, time.Since(start).Round(time.Millisecond), err)
+		return fmt.Errorf("piper worker failed: %w", err)
+	}
+	p.logger.Printf("INFO: piper worker %d completed in %s", w.id, time.Since(start).Round(time.Millisecond))
+
+	if err := os.Rename(tmpPath, outPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("rename piper output failed: %w", err)
+	}
+	return nil
+}
+
+// Close stops all workers, giving each a chance to exit after its stdin closes.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	// Wait for in-flight requests to hand their workers back.
+	stopped := make([]*worker, 0, len(p.all))
+	for range p.all {
+		w := <-p.idle
+		w.stop(p.logger)
+		stopped = append(stopped, w)
+	}
+	for _, w := range stopped {
+		p.idle <- w
+	}
+	return nil
+}
+
+func (p *Pool) isClosed() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.closed
+}
+
+type worker struct {
+	id    int
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan string
+	done  chan struct{}
+	// quit stops the stdout reader forwarding lines once the worker is being
+	// killed, so it drains the pipe instead of blocking on a full lines.
+	quit     chan struct{}
+	quitOnce sync.Once
+}
+
+func (w *worker) alive() bool {
+	if w.cmd == nil {
+		return false
+	}
+	select {
+	case <-w.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (w *worker) start(p *Pool) error {
+	args := []string{"-m", p.model, "--json-input"}
+	args = append(args, p.flags...)
+
+	cmd := exec.Command(p.execPath, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+
+	p.logger.Printf("INFO: starting piper worker %d exec=%s args=%v", w.id, p.execPath, args)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	w.cmd = cmd
+	w.stdin = stdin
+	w.lines = make(chan string, 16)
+	w.done = make(chan struct{})
+	w.quit = make(chan struct{})
+	w.quitOnce = sync.Once{}
+
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go func(lines chan<- string, quit <-chan struct{}) {
+		defer readers.Done()
+		defer close(lines)
+		sc := bufio.NewScanner(stdout)
+		for sc.Scan() {
+			select {
+			case lines <- strings.TrimSpace(sc.Text()):
+			case <-quit:
+			}
+		}
+	}(w.lines, w.quit)
+	go func() {
+		defer readers.Done()
+		sc := bufio.NewScanner(stderr)
+		for sc.Scan() {
+			p.logger.Printf("INFO: piper worker %d: %s", w.id, sc.Text())
+		}
+	}()
+	go func(done chan struct{}) {
+		readers.Wait()
+		err := cmd.Wait()
+		p.logger.Printf("INFO: piper worker %d exited: %v", w.id, err)
+		close(done)
+	}(w.done)
+	return nil
+}
+
+type jsonRequest struct {
+	Text       string `json:"text"`
+	OutputFile string `json:"output_file"`
+	SpeakerID  *int   `json:"speaker_id,omitempty"`
+}
+
+func (w *worker) synthesize(ctx context.Context, req engine.Request, path string) error {
+	jr := jsonRequest{Text: req.Text, OutputFile: path}
+	if req.Params.Speaker != "" {
+		id, err := strconv.Atoi(req.Params.Speaker)
+		if err != nil {
+			return fmt.Errorf("invalid speaker id %q", req.Params.Speaker)
+		}
+		jr.SpeakerID = &id
+	}
+	line, err := json.Marshal(jr)
+	if err != nil {
+		return err
+	}
+	if _, err := w.stdin.Write(append(line, '\n')); err != nil {
+		w.kill()
+		return fmt.Errorf("write request: %w", err)
+	}
+
+	for {
+		select {
+		case got, ok := <-w.lines:
+			if !ok {
+				<-w.done
+				return errors.New("worker exited")
+			}
+			if got == path {
+				return nil
+			}
+		case <-ctx.Done():
+			// Piper cannot abandon a request midway; restart the worker instead.
+			w.kill()
+			return ctx.Err()
+		}
+	}
+}
+
+// kill terminates the process and waits for it to be reaped so the next use
+// sees a dead worker and restarts it.
+func (w *worker) kill() {
+	if w.cmd != nil && w.cmd.Process != nil {
+		w.quitOnce.Do(func() { close(w.quit) })
+		_ = w.cmd.Process.Kill()
+		<-w.done
+	}
+}
+
+func (w *worker) stop(logger *log.Logger) {
+	if !w.alive() {
+		return
+	}
+	_ = w.stdin.Close()
+	select {
+	case <-w.done:
+	case <-time.After(workerStopTimeout):
+		logger.Printf("ERROR: piper worker %d did not exit, killing", w.id)
+		w.kill()
+	}
+}
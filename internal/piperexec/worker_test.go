@@ -0,0 +1,188 @@
+package piperexec
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/engine"
+	"github.com/venkytv/tts-cached/internal/pipermodel"
+)
+
+// newTestPool returns a pool whose "piper" is this test binary running TestHelperPiper.
+func newTestPool(t *testing.T, size int) *Pool {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("helper wrapper script needs a unix shell")
+	}
+	t.Setenv("PIPEREXEC_HELPER", "1")
+	script := filepath.Join(t.TempDir(), "piper")
+	body := fmt.Sprintf("#!/bin/sh\nexec %q -test.run='^TestHelperPiper$' -- \"$@\"\n", os.Args[0])
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatalf("write helper script: %v", err)
+	}
+	p := NewPool(script, "model.onnx", nil, 22050, size, logDiscard)
+	t.Cleanup(func() { _ = p.Close() })
+	return p
+}
+
+func TestPoolReusesWorkerProcess(t *testing.T) {
+	p := newTestPool(t, 1)
+	dir := t.TempDir()
+
+	var pids []string
+	for i := 0; i < 3; i++ {
+		out := filepath.Join(dir, fmt.Sprintf("%d.wav", i))
+		if err := p.Synthesize(context.Background(), engine.Request{Text: "hello"}, out); err != nil {
+			t.Fatalf("synthesize: %v", err)
+		}
+		data, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("read output: %v", err)
+		}
+		pids = append(pids, strings.TrimPrefix(string(data), "RIFF:"))
+	}
+	if pids[0] != pids[1] || pids[1] != pids[2] {
+		t.Fatalf("expected one resident process, got pids %v", pids)
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(dir, "*.tmp")); len(leftovers) != 0 {
+		t.Fatalf("temp files left behind: %v", leftovers)
+	}
+}
+
+func TestRunnerArgsIncludeParams(t *testing.T) {
+	r := New("piper", "model.onnx", []string{"--length_scale", "1"}, 22050, logDiscard)
+	ls, sil := 1.25, 0.5
+	args, err := r.args(engine.Request{Params: engine.Params{Speaker: "2", LengthScale: &ls, SentenceSilence: &sil}}, "-f", "out.wav")
+	if err != nil {
+		t.Fatalf("args: %v", err)
+	}
+	want := "-m model.onnx -f out.wav --length_scale 1 --speaker 2 --length_scale 1.25 --sentence_silence 0.5"
+	if got := strings.Join(args, " "); got != want {
+		t.Fatalf("args = %q, want %q", got, want)
+	}
+}
+
+func TestRunnerResolvesSpeakerNames(t *testing.T) {
+	var meta pipermodel.Config
+	meta.Audio.SampleRate = 16000
+	meta.Language.Code = "en_GB"
+	meta.NumSpeakers = 2
+	meta.SpeakerIDMap = map[string]int{"alba": 0, "jenny": 1}
+	r := New("piper", "model.onnx", nil, 22050, logDiscard).WithMetadata(meta)
+
+	caps := r.Capabilities()
+	if caps.SampleRate != 16000 || caps.Languages[0] != "en_GB" || strings.Join(caps.Speakers, ",") != "alba,jenny" {
+		t.Fatalf("unexpected capabilities %+v", caps)
+	}
+	args, err := r.args(engine.Request{Params: engine.Params{Speaker: "jenny"}})
+	if err != nil || strings.Join(args, " ") != "-m model.onnx --speaker 1" {
+		t.Fatalf("args = %v, %v", args, err)
+	}
+	if _, err := r.args(engine.Request{Params: engine.Params{Speaker: "nobody"}}); err == nil {
+		t.Fatalf("unknown speaker should fail")
+	}
+}
+
+func TestPoolRestartsAfterCrash(t *testing.T) {
+	p := newTestPool(t, 1)
+	dir := t.TempDir()
+
+	if err := p.Synthesize(context.Background(), engine.Request{Text: "crash"}, filepath.Join(dir, "a.wav")); err == nil {
+		t.Fatalf("expected error when worker crashes")
+	}
+	if err := p.Synthesize(context.Background(), engine.Request{Text: "hello"}, filepath.Join(dir, "b.wav")); err != nil {
+		t.Fatalf("synthesize after crash: %v", err)
+	}
+}
+
+func TestPoolCancelKillsHungWorker(t *testing.T) {
+	p := newTestPool(t, 1)
+	dir := t.TempDir()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := p.Synthesize(ctx, engine.Request{Text: "hang"}, filepath.Join(dir, "a.wav")); err == nil {
+		t.Fatalf("expected context error")
+	}
+	if err := p.Synthesize(context.Background(), engine.Request{Text: "hello"}, filepath.Join(dir, "b.wav")); err != nil {
+		t.Fatalf("synthesize after cancel: %v", err)
+	}
+}
+
+func TestPoolCancelKillsChattyWorker(t *testing.T) {
+	p := newTestPool(t, 1)
+	dir := t.TempDir()
+
+	// The worker floods stdout, so its reader is blocked on a full channel
+	// when the request is cancelled.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	errc := make(chan error, 1)
+	go func() { errc <- p.Synthesize(ctx, engine.Request{Text: "flood"}, filepath.Join(dir, "a.wav")) }()
+	select {
+	case err := <-errc:
+		if err == nil {
+			t.Fatalf("expected context error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("cancel did not return: kill hung on the stdout reader")
+	}
+	if err := p.Synthesize(context.Background(), engine.Request{Text: "hello"}, filepath.Join(dir, "b.wav")); err != nil {
+		t.Fatalf("synthesize after cancel: %v", err)
+	}
+}
+
+func TestPoolClose(t *testing.T) {
+	p := newTestPool(t, 2)
+	if err := p.Synthesize(context.Background(), engine.Request{Text: "hello"}, filepath.Join(t.TempDir(), "a.wav")); err != nil {
+		t.Fatalf("synthesize: %v", err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if err := p.Synthesize(context.Background(), engine.Request{Text: "hello"}, filepath.Join(t.TempDir(), "b.wav")); err != ErrPoolClosed {
+		t.Fatalf("expected ErrPoolClosed, got %v", err)
+	}
+}
+
+// TestHelperPiper mimics `piper --json-input`: one JSON request per stdin line,
+// writing the wav and echoing its path on stdout.
+func TestHelperPiper(t *testing.T) {
+	if os.Getenv("PIPEREXEC_HELPER") != "1" {
+		return
+	}
+	sc := bufio.NewScanner(os.Stdin)
+	for sc.Scan() {
+		var req jsonRequest
+		if err := json.Unmarshal(sc.Bytes(), &req); err != nil {
+			os.Exit(2)
+		}
+		switch req.Text {
+		case "crash":
+			os.Exit(1)
+		case "hang":
+			time.Sleep(time.Minute)
+		case "flood":
+			for {
+				fmt.Println("progress")
+			}
+		}
+		if err := os.WriteFile(req.OutputFile, []byte(fmt.Sprintf("RIFF:%d", os.Getpid())), 0o644); err != nil {
+			os.Exit(3)
+		}
+		fmt.Println(req.OutputFile)
+	}
+	os.Exit(0)
+}
+
+var logDiscard = log.New(io.Discard, "", 0)
@@ -0,0 +1,130 @@
+// Package pipermodel reads the .onnx.json metadata that ships with Piper voice
+// models.
+package pipermodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// Config is the subset of a Piper model's .onnx.json used by tts-cached.
+type Config struct {
+	Audio struct {
+		SampleRate int    `json:"sample_rate"`
+		Quality    string `json:"quality"`
+	} `json:"audio"`
+	Espeak struct {
+		Voice string `json:"voice"`
+	} `json:"espeak"`
+	Language struct {
+		Code   string `json:"code"`
+		Family string `json:"family"`
+	} `json:"language"`
+	Inference struct {
+		NoiseScale  float64 `json:"noise_scale"`
+		LengthScale float64 `json:"length_scale"`
+		NoiseW      float64 `json:"noise_w"`
+	} `json:"inference"`
+	PhonemeType  string         `json:"phoneme_type"`
+	NumSpeakers  int            `json:"num_speakers"`
+	SpeakerIDMap map[string]int `json:"speaker_id_map"`
+	Dataset      string         `json:"dataset"`
+}
+
+// ConfigPath returns the metadata path Piper expects next to modelPath.
+func ConfigPath(modelPath string) string {
+	return modelPath + ".json"
+}
+
+// Load checks that modelPath exists and parses its .onnx.json.
+func Load(modelPath string) (Config, error) {
+	if _, err := os.Stat(modelPath); err != nil {
+		return Config{}, fmt.Errorf("piper model: %w", err)
+	}
+	data, err := os.ReadFile(ConfigPath(modelPath))
+	if err != nil {
+		return Config{}, fmt.Errorf("piper model config: %w", err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("parse %s: %w", ConfigPath(modelPath), err)
+	}
+	if cfg.Audio.SampleRate <= 0 {
+		return Config{}, fmt.Errorf("%s: missing audio.sample_rate", ConfigPath(modelPath))
+	}
+	return cfg, nil
+}
+
+// LanguageCode returns the model's language (e.g. "en_US"), falling back to its
+// espeak voice.
+func (c Config) LanguageCode() string {
+	if c.Language.Code != "" {
+		return c.Language.Code
+	}
+	return c.Espeak.Voice
+}
+
+// Speakers lists the speakers requests may choose, ordered by id: names from
+// speaker_id_map, or numeric ids for multi-speaker models without names. It is
+// empty for single-speaker models.
+func (c Config) Speakers() []string {
+	if len(c.SpeakerIDMap) > 0 {
+		names := make([]string, 0, len(c.SpeakerIDMap))
+		for name := range c.SpeakerIDMap {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			a, b := c.SpeakerIDMap[names[i]], c.SpeakerIDMap[names[j]]
+			if a != b {
+				return a < b
+			}
+			return names[i] < names[j]
+		})
+		return names
+	}
+	if c.NumSpeakers <= 1 {
+		return nil
+	}
+	ids := make([]string, c.NumSpeakers)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+	return ids
+}
+
+// SpeakerAliases maps each numeric id in speaker_id_map, and each further name
+// for an id, to the name Speakers lists for it.
+func (c Config) SpeakerAliases() map[string]string {
+	if len(c.SpeakerIDMap) == 0 {
+		return nil
+	}
+	aliases := make(map[string]string)
+	listed := make(map[int]string)
+	for _, name := range c.Speakers() {
+		id := c.SpeakerIDMap[name]
+		if first, ok := listed[id]; ok {
+			aliases[name] = first
+			continue
+		}
+		listed[id] = name
+		if _, taken := c.SpeakerIDMap[strconv.Itoa(id)]; !taken {
+			aliases[strconv.Itoa(id)] = name
+		}
+	}
+	return aliases
+}
+
+// SpeakerID resolves a speaker name, or a numeric id, to Piper's speaker id.
+func (c Config) SpeakerID(speaker string) (int, error) {
+	if id, ok := c.SpeakerIDMap[speaker]; ok {
+		return id, nil
+	}
+	id, err := strconv.Atoi(speaker)
+	if err != nil || id < 0 || (c.NumSpeakers > 0 && id >= c.NumSpeakers) {
+		return 0, fmt.Errorf("unknown speaker %q", speaker)
+	}
+	return id, nil
+}
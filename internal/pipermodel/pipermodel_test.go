@@ -0,0 +1,75 @@
+package pipermodel
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const multiSpeaker = `{
+  "audio": {"sample_rate": 22050, "quality": "medium"},
+  "espeak": {"voice": "en-us"},
+  "language": {"code": "en_US", "family": "en"},
+  "inference": {"noise_scale": 0.667, "length_scale": 1, "noise_w": 0.8},
+  "phoneme_type": "espeak",
+  "num_speakers": 3,
+  "speaker_id_map": {"p239": 2, "p225": 0, "p228": 1}
+}`
+
+func writeModel(t *testing.T, config string) string {
+	t.Helper()
+	model := filepath.Join(t.TempDir(), "voice.onnx")
+	if err := os.WriteFile(model, []byte("onnx"), 0o644); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+	if config != "" {
+		if err := os.WriteFile(ConfigPath(model), []byte(config), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	return model
+}
+
+func TestLoadMultiSpeaker(t *testing.T) {
+	cfg, err := Load(writeModel(t, multiSpeaker))
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.Audio.SampleRate != 22050 || cfg.LanguageCode() != "en_US" {
+		t.Fatalf("unexpected metadata %+v", cfg)
+	}
+	if got := cfg.Speakers(); !reflect.DeepEqual(got, []string{"p225", "p228", "p239"}) {
+		t.Fatalf("speakers = %v", got)
+	}
+	for speaker, want := range map[string]int{"p239": 2, "1": 1} {
+		if id, err := cfg.SpeakerID(speaker); err != nil || id != want {
+			t.Fatalf("SpeakerID(%q) = %d, %v", speaker, id, err)
+		}
+	}
+	for _, bad := range []string{"nobody", "3", "-1"} {
+		if _, err := cfg.SpeakerID(bad); err == nil {
+			t.Fatalf("SpeakerID(%q) should fail", bad)
+		}
+	}
+	want := map[string]string{"0": "p225", "1": "p228", "2": "p239"}
+	if got := cfg.SpeakerAliases(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("speaker aliases = %v, want %v", got, want)
+	}
+}
+
+func TestLoadRequiresModelAndConfig(t *testing.T) {
+	if _, err := Load(writeModel(t, "")); err == nil {
+		t.Fatalf("expected error for missing config")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.onnx")); err == nil {
+		t.Fatalf("expected error for missing model")
+	}
+	if _, err := Load(writeModel(t, `{"audio": {}}`)); err == nil {
+		t.Fatalf("expected error for missing sample rate")
+	}
+	cfg, err := Load(writeModel(t, `{"audio": {"sample_rate": 16000}, "num_speakers": 1}`))
+	if err != nil || cfg.Speakers() != nil {
+		t.Fatalf("single speaker model: %v %v", cfg.Speakers(), err)
+	}
+}
@@ -0,0 +1,429 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/engine"
+)
+
+// ErrClosed is returned by Synthesize after Close.
+var ErrClosed = errors.New("plugin engine closed")
+
+var errExited = errors.New("plugin exited")
+
+const (
+	handshakeTimeout = 10 * time.Second
+	pingTimeout      = 5 * time.Second
+	stopTimeout      = 5 * time.Second
+)
+
+// cancelGrace is how long a plugin may take to answer a cancelled request
+// before it is considered stuck and restarted.
+var cancelGrace = 10 * time.Second
+
+// Engine runs a plugin process and sends it synthesis requests. The process is
+// started on first use (or by Start), checked with periodic pings, and
+// restarted on next use after it crashes or stops answering.
+type Engine struct {
+	execPath     string
+	args         []string
+	pingInterval time.Duration
+	logger       *log.Logger
+
+	// startMu serialises starting the process, so the handshake does not
+	// hold mu and block Capabilities on the request path.
+	startMu sync.Mutex
+
+	mu     sync.Mutex
+	proc   *process
+	name   string
+	caps   engine.Capabilities
+	closed bool
+	nextID uint64
+}
+
+// New creates an Engine for the plugin executable and arguments. A
+// pingInterval of 0 disables health pings.
+func New(execPath string, args []string, pingInterval time.Duration, logger *log.Logger) *Engine {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &Engine{execPath: execPath, args: args, pingInterval: pingInterval, logger: logger}
+}
+
+// Start launches the plugin and completes the handshake if it is not running.
+func (e *Engine) Start() error {
+	_, err := e.running()
+	return err
+}
+
+// Capabilities reports what the plugin announced in its last handshake.
+func (e *Engine) Capabilities() engine.Capabilities {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.caps
+}
+
+// Synthesize sends a synthesize request and waits for the plugin to write the
+// wav to a temp file, which is then renamed to outPath. A request lost because
+// the daemon restarted a stuck plugin is retried once on the new process.
+func (e *Engine) Synthesize(ctx context.Context, req engine.Request, outPath string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(outPath), filepath.Base(outPath)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	tmp.Close()
+
+	start := time.Now()
+	var reply Message
+	for attempt := 0; ; attempt++ {
+		var p *process
+		p, err = e.running()
+		if err != nil {
+			_ = os.Remove(tmpPath)
+			return err
+		}
+		reply, err = e.request(ctx, p, req, tmpPath)
+		if err == nil || attempt > 0 || !errors.Is(err, errExited) || !p.wasKilled() {
+			break
+		}
+	}
+	if err != nil {
+		e.logger.Printf("ERROR: plugin %s failed after %s: %v", e.execPath, time.Since(start).Round(time.Millisecond), err)
+		return fmt.Errorf("plugin request failed: %w", err)
+	}
+	if reply.Status != StatusOK {
+		_ = os.Remove(tmpPath)
+		e.logger.Printf("ERROR: plugin %s failed after %s: %s", e.execPath, time.Since(start).Round(time.Millisecond), reply.Error)
+		return fmt.Errorf("plugin synthesis failed: %s", reply.Error)
+	}
+	e.logger.Printf("INFO: plugin %s completed in %s (audio %dms at %dHz)", e.execPath, time.Since(start).Round(time.Millisecond), reply.DurationMs, reply.SampleRate)
+
+	if err := os.Rename(tmpPath, outPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("rename plugin output failed: %w", err)
+	}
+	return nil
+}
+
+// request sends one synthesize message. On failure tmpPath is removed, after
+// the plugin has answered if the request was cancelled.
+func (e *Engine) request(ctx context.Context, p *process, req engine.Request, tmpPath string) (Message, error) {
+	msg := Message{Type: TypeSynthesize, ID: e.id(), Text: req.Text, Voice: req.Voice, OutPath: tmpPath}
+	if !req.Params.IsZero() {
+		msg.Params = make(map[string]interface{})
+		if req.Params.Speaker != "" {
+			msg.Params[engine.ParamSpeaker] = req.Params.Speaker
+		}
+		for name, v := range req.Params.Floats() {
+			msg.Params[name] = v
+		}
+	}
+
+	reply, wait, err := p.call(ctx, msg)
+	if err == nil {
+		return reply, nil
+	}
+	if wait == nil {
+		_ = os.Remove(tmpPath)
+		return reply, err
+	}
+	// The plugin may still write the file; clean up once it answers,
+	// restarting it if it never does.
+	_ = p.send(Message{Type: TypeCancel, ID: msg.ID})
+	go func() {
+		if !wait(cancelGrace) {
+			e.logger.Printf("ERROR: plugin %s did not answer cancelled request %s, killing", e.execPath, msg.ID)
+			p.kill()
+		}
+		_ = os.Remove(tmpPath)
+	}()
+	return reply, err
+}
+
+// Close asks the plugin to exit by closing its stdin, killing it if it lingers.
+func (e *Engine) Close() error {
+	e.mu.Lock()
+	e.closed = true
+	p := e.proc
+	e.mu.Unlock()
+	if p != nil {
+		p.stop(e.logger)
+	}
+	return nil
+}
+
+func (e *Engine) id() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.nextID++
+	return strconv.FormatUint(e.nextID, 10)
+}
+
+// running returns the live plugin process, starting one if needed.
+func (e *Engine) running() (*process, error) {
+	if p, _, err := e.current(); p != nil || err != nil {
+		return p, err
+	}
+	e.startMu.Lock()
+	defer e.startMu.Unlock()
+	// Another caller may have started the plugin while this one waited.
+	p, restart, err := e.current()
+	if p != nil || err != nil {
+		return p, err
+	}
+	if restart {
+		e.logger.Printf("INFO: restarting plugin %s", e.execPath)
+	}
+
+	p, err = startProcess(e.execPath, e.args, e.logger)
+	if err != nil {
+		return nil, fmt.Errorf("start plugin: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), handshakeTimeout)
+	defer cancel()
+	reply, _, err := p.call(ctx, Message{Type: TypeHello, ID: "0", Versions: SupportedVersions})
+	if err == nil && reply.Type != TypeHello {
+		err = fmt.Errorf("handshake refused: %s", reply.Error)
+	}
+	if err == nil && negotiate([]int{reply.Version}, SupportedVersions) == 0 {
+		err = fmt.Errorf("plugin chose unsupported protocol version %d", reply.Version)
+	}
+	if err != nil {
+		p.kill()
+		return nil, fmt.Errorf("plugin handshake: %w", err)
+	}
+
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		p.stop(e.logger)
+		return nil, ErrClosed
+	}
+	e.proc = p
+	e.name = reply.Name
+	if reply.Capabilities != nil {
+		e.caps = *reply.Capabilities
+	}
+	caps := e.caps
+	e.mu.Unlock()
+
+	e.logger.Printf("INFO: plugin %s (%s) ready, protocol v%d, capabilities %+v", reply.Name, e.execPath, reply.Version, caps)
+	if e.pingInterval > 0 {
+		go e.ping(p)
+	}
+	return p, nil
+}
+
+// current returns the live plugin process, or nil and whether an earlier one
+// has died.
+func (e *Engine) current() (p *process, restart bool, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return nil, false, ErrClosed
+	}
+	if e.proc != nil && e.proc.alive() {
+		return e.proc, false, nil
+	}
+	return nil, e.proc != nil, nil
+}
+
+// ping checks the process periodically and kills it when it stops answering,
+// so the next request starts a fresh one.
+func (e *Engine) ping(p *process) {
+	t := time.NewTicker(e.pingInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-t.C:
+		}
+		timeout := e.pingInterval
+		if timeout > pingTimeout {
+			timeout = pingTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		reply, _, err := p.call(ctx, Message{Type: TypePing, ID: e.id()})
+		cancel()
+		if err == nil && reply.Type != TypePong {
+			err = fmt.Errorf("unexpected reply %q", reply.Type)
+		}
+		if err != nil {
+			e.logger.Printf("ERROR: plugin %s failed health check, killing: %v", e.execPath, err)
+			p.kill()
+			return
+		}
+	}
+}
+
+// process is one running plugin.
+type process struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	done  chan struct{}
+
+	// killed is set when the daemon killed the process as unhealthy.
+	killed atomic.Bool
+
+	wmu sync.Mutex
+
+	pmu     sync.Mutex
+	pending map[string]chan Message
+}
+
+func startProcess(execPath string, args []string, logger *log.Logger) (*process, error) {
+	cmd := exec.Command(execPath, args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+	logger.Printf("INFO: starting plugin exec=%s args=%v", execPath, args)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	p := &process{cmd: cmd, stdin: stdin, done: make(chan struct{}), pending: make(map[string]chan Message)}
+
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go func() {
+		defer readers.Done()
+		sc := bufio.NewScanner(stdout)
+		sc.Buffer(make([]byte, 64*1024), maxLine)
+		for sc.Scan() {
+			var m Message
+			if err := json.Unmarshal(sc.Bytes(), &m); err != nil {
+				logger.Printf("ERROR: plugin %s sent invalid message: %v", execPath, err)
+				continue
+			}
+			p.deliver(m)
+		}
+	}()
+	go func() {
+		defer readers.Done()
+		sc := bufio.NewScanner(stderr)
+		for sc.Scan() {
+			logger.Printf("INFO: plugin %s: %s", execPath, sc.Text())
+		}
+	}()
+	go func() {
+		readers.Wait()
+		err := cmd.Wait()
+		logger.Printf("INFO: plugin %s exited: %v", execPath, err)
+		close(p.done)
+	}()
+	return p, nil
+}
+
+func (p *process) alive() bool {
+	select {
+	case <-p.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (p *process) deliver(m Message) {
+	p.pmu.Lock()
+	ch, ok := p.pending[m.ID]
+	delete(p.pending, m.ID)
+	p.pmu.Unlock()
+	if ok {
+		ch <- m
+	}
+}
+
+func (p *process) send(m Message) error {
+	line, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	p.wmu.Lock()
+	defer p.wmu.Unlock()
+	_, err = p.stdin.Write(append(line, '\n'))
+	return err
+}
+
+// call sends m and waits for the reply with the same id. When ctx ends first,
+// the returned wait func blocks until the reply arrives, the process exits, or
+// the timeout passes; it reports false only on timeout.
+func (p *process) call(ctx context.Context, m Message) (Message, func(time.Duration) bool, error) {
+	ch := make(chan Message, 1)
+	p.pmu.Lock()
+	p.pending[m.ID] = ch
+	p.pmu.Unlock()
+
+	if err := p.send(m); err != nil {
+		p.pmu.Lock()
+		delete(p.pending, m.ID)
+		p.pmu.Unlock()
+		return Message{}, nil, fmt.Errorf("write request: %w", err)
+	}
+
+	select {
+	case reply := <-ch:
+		return reply, nil, nil
+	case <-p.done:
+		return Message{}, nil, errExited
+	case <-ctx.Done():
+		wait := func(d time.Duration) bool {
+			select {
+			case <-ch:
+			case <-p.done:
+			case <-time.After(d):
+				return false
+			}
+			return true
+		}
+		return Message{}, wait, ctx.Err()
+	}
+}
+
+// kill terminates the process and waits for it to be reaped.
+func (p *process) kill() {
+	p.killed.Store(true)
+	_ = p.cmd.Process.Kill()
+	<-p.done
+}
+
+func (p *process) wasKilled() bool {
+	return p.killed.Load()
+}
+
+func (p *process) stop(logger *log.Logger) {
+	if !p.alive() {
+		return
+	}
+	_ = p.stdin.Close()
+	select {
+	case <-p.done:
+	case <-time.After(stopTimeout):
+		logger.Printf("ERROR: plugin did not exit, killing")
+		p.kill()
+	}
+}
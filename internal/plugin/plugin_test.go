@@ -0,0 +1,215 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/audio"
+	"github.com/venkytv/tts-cached/internal/engine"
+)
+
+// newTestEngine returns an Engine whose plugin is this test binary running
+// TestHelperPlugin in the given mode.
+func newTestEngine(t *testing.T, mode string, ping time.Duration) *Engine {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("helper wrapper script needs a unix shell")
+	}
+	t.Setenv("PLUGIN_HELPER", mode)
+	script := filepath.Join(t.TempDir(), "plugin")
+	body := fmt.Sprintf("#!/bin/sh\nexec %q -test.run='^TestHelperPlugin$' -- \"$@\"\n", os.Args[0])
+	if err := os.WriteFile(script, []byte(body), 0o755); err != nil {
+		t.Fatalf("write helper script: %v", err)
+	}
+	e := New(script, nil, ping, logDiscard)
+	t.Cleanup(func() { _ = e.Close() })
+	return e
+}
+
+func TestEngineWithReferencePlugin(t *testing.T) {
+	e := newTestEngine(t, "tone", 0)
+	if err := e.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if caps := e.Capabilities(); caps.SampleRate != 16000 {
+		t.Fatalf("unexpected capabilities %+v", caps)
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "a.wav")
+	if err := e.Synthesize(context.Background(), engine.Request{Text: "hello there world"}, out); err != nil {
+		t.Fatalf("synthesize: %v", err)
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+	format, pcm, err := audio.ReadWAV(f)
+	if err != nil {
+		t.Fatalf("read wav: %v", err)
+	}
+	if want := 3 * format.BytesPerSecond() * 160 / 1000; format.SampleRate != 16000 || len(pcm) != want {
+		t.Fatalf("unexpected audio %+v len=%d want %d", format, len(pcm), want)
+	}
+
+	if err := e.Synthesize(context.Background(), engine.Request{Text: "fail"}, filepath.Join(dir, "b.wav")); err == nil || !strings.Contains(err.Error(), "refusing") {
+		t.Fatalf("expected plugin error, got %v", err)
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(dir, "*.tmp")); len(leftovers) != 0 {
+		t.Fatalf("temp files left behind: %v", leftovers)
+	}
+}
+
+func TestEngineRestartsAfterCrash(t *testing.T) {
+	e := newTestEngine(t, "tone", 0)
+	dir := t.TempDir()
+	if err := e.Synthesize(context.Background(), engine.Request{Text: "crash"}, filepath.Join(dir, "a.wav")); !errors.Is(err, errExited) {
+		t.Fatalf("expected exit error, got %v", err)
+	}
+	if err := e.Synthesize(context.Background(), engine.Request{Text: "hello"}, filepath.Join(dir, "b.wav")); err != nil {
+		t.Fatalf("synthesize after crash: %v", err)
+	}
+}
+
+func TestEngineRejectsUnsupportedVersion(t *testing.T) {
+	e := newTestEngine(t, "v99", 0)
+	if err := e.Start(); err == nil || !strings.Contains(err.Error(), "version") {
+		t.Fatalf("expected version error, got %v", err)
+	}
+}
+
+func TestEngineCapabilitiesDuringHandshake(t *testing.T) {
+	e := newTestEngine(t, "slowhello", 0)
+	started := make(chan error, 1)
+	go func() { started <- e.Start() }()
+	time.Sleep(50 * time.Millisecond)
+
+	got := make(chan struct{})
+	go func() {
+		e.Capabilities()
+		close(got)
+	}()
+	select {
+	case <-got:
+	case <-time.After(200 * time.Millisecond):
+		t.Fatalf("Capabilities blocked on the plugin handshake")
+	}
+	if err := <-started; err != nil {
+		t.Fatalf("start: %v", err)
+	}
+}
+
+func TestEngineHealthPingRestartsUnresponsivePlugin(t *testing.T) {
+	e := newTestEngine(t, "nopong", 50*time.Millisecond)
+	if err := e.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	first := e.name
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		e.mu.Lock()
+		alive := e.proc.alive()
+		e.mu.Unlock()
+		if !alive {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("unresponsive plugin was not killed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err := e.Start(); err != nil {
+		t.Fatalf("restart: %v", err)
+	}
+	if e.name == first {
+		t.Fatalf("expected a new plugin process, still %s", first)
+	}
+}
+
+func TestEngineCancelRestartsStuckPlugin(t *testing.T) {
+	cancelGrace = 100 * time.Millisecond
+	defer func() { cancelGrace = 10 * time.Second }()
+
+	e := newTestEngine(t, "tone", 0)
+	dir := t.TempDir()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := e.Synthesize(ctx, engine.Request{Text: "hang"}, filepath.Join(dir, "a.wav")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if err := e.Synthesize(context.Background(), engine.Request{Text: "hello"}, filepath.Join(dir, "b.wav")); err != nil {
+		t.Fatalf("synthesize after cancel: %v", err)
+	}
+}
+
+// helperTone wraps the reference plugin with words that misbehave.
+type helperTone struct{ Tone }
+
+func (h helperTone) Info() (string, engine.Capabilities) {
+	_, caps := h.Tone.Info()
+	return fmt.Sprintf("tone-%d", os.Getpid()), caps
+}
+
+func (h helperTone) Synthesize(req SynthRequest) (SynthResult, error) {
+	switch req.Text {
+	case "crash":
+		os.Exit(1)
+	case "hang":
+		time.Sleep(time.Minute)
+	case "fail":
+		return SynthResult{}, errors.New("refusing to say fail")
+	}
+	return h.Tone.Synthesize(req)
+}
+
+// TestHelperPlugin is the plugin process used by the tests above.
+func TestHelperPlugin(t *testing.T) {
+	mode := os.Getenv("PLUGIN_HELPER")
+	switch mode {
+	case "":
+		return
+	case "tone":
+		if err := Serve(os.Stdin, os.Stdout, helperTone{}); err != nil {
+			os.Exit(2)
+		}
+		os.Exit(0)
+	}
+
+	// Hand-rolled misbehaving plugins.
+	enc := json.NewEncoder(os.Stdout)
+	sc := bufio.NewScanner(os.Stdin)
+	for sc.Scan() {
+		var m Message
+		if err := json.Unmarshal(sc.Bytes(), &m); err != nil {
+			os.Exit(2)
+		}
+		switch {
+		case m.Type == TypeHello && mode == "v99":
+			_ = enc.Encode(Message{Type: TypeHello, ID: m.ID, Version: 99})
+		case m.Type == TypeHello:
+			if mode == "slowhello" {
+				time.Sleep(500 * time.Millisecond)
+			}
+			_ = enc.Encode(Message{Type: TypeHello, ID: m.ID, Version: 1, Name: fmt.Sprintf("mute-%d", os.Getpid())})
+		case m.Type == TypePing:
+			// nopong: never answer.
+		}
+	}
+	os.Exit(0)
+}
+
+var logDiscard = log.New(io.Discard, "", 0)
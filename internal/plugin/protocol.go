@@ -0,0 +1,74 @@
+// Package plugin implements tts-cached's engine plugin protocol: line-delimited
+// JSON messages exchanged with a plugin process over its stdin and stdout.
+//
+// The daemon opens with a hello listing the protocol versions it speaks; the
+// plugin answers with the version it chose, its name and capabilities. The
+// daemon then sends synthesize requests and periodic pings, each carrying an id
+// echoed in the reply. Requests may be pipelined; replies may arrive in any
+// order. Closing stdin asks the plugin to exit.
+package plugin
+
+import "github.com/venkytv/tts-cached/internal/engine"
+
+// Version is the newest protocol version this package speaks.
+const Version = 1
+
+// SupportedVersions lists the protocol versions this package speaks.
+var SupportedVersions = []int{1}
+
+// Message types.
+const (
+	TypeHello      = "hello"
+	TypeSynthesize = "synthesize"
+	TypeResult     = "result"
+	TypeCancel     = "cancel"
+	TypePing       = "ping"
+	TypePong       = "pong"
+	TypeError      = "error"
+)
+
+// Result statuses.
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// Message is the single wire format; fields are used depending on Type.
+type Message struct {
+	Type string `json:"type"`
+	ID   string `json:"id,omitempty"`
+
+	// hello (daemon → plugin)
+	Versions []int `json:"versions,omitempty"`
+	// hello (plugin → daemon)
+	Version      int                  `json:"version,omitempty"`
+	Name         string               `json:"name,omitempty"`
+	Capabilities *engine.Capabilities `json:"capabilities,omitempty"`
+
+	// synthesize
+	Text    string                 `json:"text,omitempty"`
+	Voice   string                 `json:"voice,omitempty"`
+	Params  map[string]interface{} `json:"params,omitempty"`
+	OutPath string                 `json:"out_path,omitempty"`
+
+	// result
+	Status     string `json:"status,omitempty"`
+	SampleRate int    `json:"sample_rate,omitempty"`
+	DurationMs int64  `json:"duration_ms,omitempty"`
+
+	// result and error
+	Error string `json:"error,omitempty"`
+}
+
+// negotiate picks the newest version offered by both sides, or 0.
+func negotiate(offered, supported []int) int {
+	best := 0
+	for _, o := range offered {
+		for _, s := range supported {
+			if o == s && o > best {
+				best = o
+			}
+		}
+	}
+	return best
+}
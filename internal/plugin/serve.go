@@ -0,0 +1,117 @@
+package plugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/venkytv/tts-cached/internal/engine"
+)
+
+// SynthRequest is a synthesize message as seen by a plugin.
+type SynthRequest struct {
+	Text    string
+	Voice   string
+	Params  map[string]interface{}
+	OutPath string
+}
+
+// SynthResult describes the wav a plugin wrote to SynthRequest.OutPath.
+type SynthResult struct {
+	SampleRate int
+	DurationMs int64
+}
+
+// Handler is implemented by plugins. Synthesize must write a wav to req.OutPath.
+type Handler interface {
+	Info() (name string, caps engine.Capabilities)
+	Synthesize(req SynthRequest) (SynthResult, error)
+}
+
+// Serve runs the plugin side of the protocol, reading requests from r and
+// writing replies to w until r is closed. Synthesize requests run one at a
+// time while pings keep being answered; a request cancelled before it starts
+// is answered with an error without running.
+func Serve(r io.Reader, w io.Writer, h Handler) error {
+	var wmu sync.Mutex
+	enc := json.NewEncoder(w)
+	reply := func(m Message) error {
+		wmu.Lock()
+		defer wmu.Unlock()
+		return enc.Encode(m)
+	}
+
+	var (
+		synthMu   sync.Mutex // serializes the handler
+		cancelMu  sync.Mutex
+		cancelled = make(map[string]bool)
+		inflight  sync.WaitGroup
+	)
+	defer inflight.Wait()
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), maxLine)
+	for sc.Scan() {
+		var m Message
+		if err := json.Unmarshal(sc.Bytes(), &m); err != nil {
+			if err := reply(Message{Type: TypeError, Error: fmt.Sprintf("invalid message: %v", err)}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var out Message
+		switch m.Type {
+		case TypeHello:
+			v := negotiate(m.Versions, SupportedVersions)
+			if v == 0 {
+				_ = reply(Message{Type: TypeError, ID: m.ID, Error: fmt.Sprintf("no common protocol version in %v", m.Versions)})
+				return fmt.Errorf("no common protocol version in %v", m.Versions)
+			}
+			name, caps := h.Info()
+			out = Message{Type: TypeHello, ID: m.ID, Version: v, Name: name, Capabilities: &caps}
+		case TypePing:
+			out = Message{Type: TypePong, ID: m.ID}
+		case TypeCancel:
+			cancelMu.Lock()
+			cancelled[m.ID] = true
+			cancelMu.Unlock()
+			continue
+		case TypeSynthesize:
+			inflight.Add(1)
+			go func(m Message) {
+				defer inflight.Done()
+				synthMu.Lock()
+				defer synthMu.Unlock()
+
+				cancelMu.Lock()
+				skip := cancelled[m.ID]
+				delete(cancelled, m.ID)
+				cancelMu.Unlock()
+				if skip {
+					_ = reply(Message{Type: TypeResult, ID: m.ID, Status: StatusError, Error: "cancelled"})
+					return
+				}
+
+				res, err := h.Synthesize(SynthRequest{Text: m.Text, Voice: m.Voice, Params: m.Params, OutPath: m.OutPath})
+				if err != nil {
+					_ = reply(Message{Type: TypeResult, ID: m.ID, Status: StatusError, Error: err.Error()})
+					return
+				}
+				_ = reply(Message{Type: TypeResult, ID: m.ID, Status: StatusOK, SampleRate: res.SampleRate, DurationMs: res.DurationMs})
+			}(m)
+			continue
+		default:
+			out = Message{Type: TypeError, ID: m.ID, Error: fmt.Sprintf("unknown message type %q", m.Type)}
+		}
+		if err := reply(out); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// maxLine bounds a single protocol message.
+const maxLine = 4 << 20
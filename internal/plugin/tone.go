@@ -0,0 +1,81 @@
+package plugin
+
+import (
+	"errors"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/audio"
+	"github.com/venkytv/tts-cached/internal/engine"
+)
+
+// Tone is the reference plugin: it "speaks" each word as a short beep whose
+// pitch depends on the word's length. It needs no model, which makes it useful
+// for testing the protocol and as a template for real plugins.
+type Tone struct {
+	SampleRate int
+	Word       time.Duration
+	Gap        time.Duration
+}
+
+// Info reports the plugin name and capabilities.
+func (t Tone) Info() (string, engine.Capabilities) {
+	return "tone", engine.Capabilities{SampleRate: t.rate(), Languages: []string{"und"}}
+}
+
+// Synthesize writes one beep per word to req.OutPath.
+func (t Tone) Synthesize(req SynthRequest) (SynthResult, error) {
+	words := strings.Fields(req.Text)
+	if len(words) == 0 {
+		return SynthResult{}, errors.New("text is required")
+	}
+	word, gap := t.Word, t.Gap
+	if word <= 0 {
+		word = 120 * time.Millisecond
+	}
+	if gap <= 0 {
+		gap = 40 * time.Millisecond
+	}
+
+	format := audio.PiperFormat(t.rate())
+	var pcm []byte
+	for _, w := range words {
+		pcm = append(pcm, beep(format, 300+40*float64(len(w)), word)...)
+		pcm = append(pcm, audio.Silence(format, gap)...)
+	}
+
+	f, err := os.Create(req.OutPath)
+	if err != nil {
+		return SynthResult{}, err
+	}
+	if err := audio.WriteWAV(f, format, pcm); err != nil {
+		f.Close()
+		return SynthResult{}, err
+	}
+	if err := f.Close(); err != nil {
+		return SynthResult{}, err
+	}
+	ms := int64(len(pcm)) * 1000 / int64(format.BytesPerSecond())
+	return SynthResult{SampleRate: format.SampleRate, DurationMs: ms}, nil
+}
+
+func (t Tone) rate() int {
+	if t.SampleRate > 0 {
+		return t.SampleRate
+	}
+	return 16000
+}
+
+// beep returns 16-bit mono PCM of a sine tone at freq Hz.
+func beep(f audio.Format, freq float64, d time.Duration) []byte {
+	n := int(float64(f.SampleRate) * d.Seconds())
+	out := make([]byte, 2*n)
+	for i := 0; i < n; i++ {
+		v := int16(8000 * math.Sin(2*math.Pi*freq*float64(i)/float64(f.SampleRate)))
+		out[2*i] = byte(v)
+		out[2*i+1] = byte(uint16(v) >> 8)
+	}
+	return out
+}
@@ -0,0 +1,162 @@
+// Package redact replaces secrets and personal data in text with a spoken
+// placeholder.
+package redact
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Built-in detector names.
+const (
+	JWT    = "jwt"
+	APIKey = "api_key"
+	Email  = "email"
+	IP     = "ip"
+	Hex    = "hex"
+)
+
+// All enables every built-in detector in Parse lists.
+const All = "all"
+
+// detectors run in this order, so a JWT is not half-matched as an API key and
+// a hex digest is reported as hex.
+// A match is only replaced if check, when set, accepts it.
+var detectors = []struct {
+	name  string
+	re    *regexp.Regexp
+	check func(string) bool
+}{
+	{JWT, regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]*`), nil},
+	{APIKey, regexp.MustCompile(`\b(?:` +
+		`sk-[A-Za-z0-9_-]{16,}|` + // OpenAI, Anthropic and similar
+		`gh[pousr]_[A-Za-z0-9]{30,}|github_pat_[A-Za-z0-9_]{30,}|` +
+		`glpat-[A-Za-z0-9_-]{20,}|` +
+		`xox[abprs]-[A-Za-z0-9-]{10,}|` +
+		`(?:AKIA|ASIA)[0-9A-Z]{16}|` +
+		`AIza[0-9A-Za-z_-]{35}|` +
+		`[rs]k_(?:live|test)_[0-9A-Za-z]{16,}` +
+		`)`), nil},
+	{Email, regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`), nil},
+	{IP, regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b|` +
+		`(?i:\b(?:[0-9a-f]{1,4}:){7}[0-9a-f]{1,4}\b|\b(?:[0-9a-f]{1,4}:){1,6}:(?:[0-9a-f]{1,4}\b)?)`), nil},
+	{Hex, regexp.MustCompile(`\b(?:0x)?[0-9a-fA-F]{24,}\b`), nil},
+	// Other long tokens mixing letters and digits, e.g. bearer tokens.
+	{APIKey, regexp.MustCompile(`\b[A-Za-z0-9_-]{32,}`), mixed},
+}
+
+// Names returns the built-in detector names.
+func Names() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, d := range detectors {
+		if !seen[d.name] {
+			seen[d.name] = true
+			names = append(names, d.name)
+		}
+	}
+	return names
+}
+
+// mixed reports whether s contains both a letter and a digit.
+func mixed(s string) bool {
+	return strings.ContainsAny(s, "0123456789") && strings.IndexFunc(s, unicode.IsLetter) >= 0
+}
+
+type rule struct {
+	name  string
+	re    *regexp.Regexp
+	check func(string) bool
+}
+
+// Redactor replaces matches of its detectors with a placeholder.
+type Redactor struct {
+	rules       []rule
+	placeholder string
+}
+
+// New builds a Redactor from built-in detector names (or All) and custom
+// regular expressions. Matches are replaced with placeholder.
+func New(builtin, custom []string, placeholder string) (*Redactor, error) {
+	enabled := make(map[string]bool, len(builtin))
+	for _, name := range builtin {
+		enabled[name] = true
+	}
+	r := &Redactor{placeholder: placeholder}
+	for _, d := range detectors {
+		if enabled[d.name] || enabled[All] {
+			r.rules = append(r.rules, rule{d.name, d.re, d.check})
+		}
+	}
+	for _, name := range Names() {
+		delete(enabled, name)
+	}
+	delete(enabled, All)
+	if len(enabled) > 0 {
+		unknown := make([]string, 0, len(enabled))
+		for name := range enabled {
+			unknown = append(unknown, name)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown redaction detectors %v (known: %s)", unknown, strings.Join(Names(), ", "))
+	}
+	for i, pattern := range custom {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("redaction pattern %d: %w", i+1, err)
+		}
+		r.rules = append(r.rules, rule{fmt.Sprintf("custom%d", i+1), re, nil})
+	}
+	return r, nil
+}
+
+// LoadPatterns reads custom patterns from a file, one regular expression per
+// line; blank lines and lines starting with '#' are ignored.
+func LoadPatterns(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var patterns []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	return patterns, sc.Err()
+}
+
+// Redact replaces every match in text and returns how many matches of each
+// detector were replaced. A nil Redactor returns text unchanged.
+func (r *Redactor) Redact(text string) (string, map[string]int) {
+	if r == nil {
+		return text, nil
+	}
+	var counts map[string]int
+	for _, ru := range r.rules {
+		n := 0
+		text = ru.re.ReplaceAllStringFunc(text, func(m string) string {
+			if ru.check != nil && !ru.check(m) {
+				return m
+			}
+			n++
+			return r.placeholder
+		})
+		if n > 0 {
+			if counts == nil {
+				counts = make(map[string]int)
+			}
+			counts[ru.name] += n
+		}
+	}
+	return text, counts
+}
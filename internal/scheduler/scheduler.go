@@ -0,0 +1,194 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrQueueFull is returned by Acquire when every slot is busy and the wait queue is full.
+var ErrQueueFull = errors.New("synthesis queue full")
+
+// Scheduler caps concurrent syntheses and bounds how many callers may wait for a slot.
+type Scheduler struct {
+	slots    chan struct{}
+	maxQueue int
+
+	mu         sync.Mutex
+	queued     int
+	background int // Wait callers, which do not count toward maxQueue
+	rejected   int64
+	completed  int64
+	waitTotal  time.Duration
+	waitMax    time.Duration
+	waits      int64
+	runTotal   time.Duration
+}
+
+// Stats is a point-in-time view of scheduler load.
+type Stats struct {
+	Concurrency int     `json:"concurrency"`
+	Running     int     `json:"running"`
+	Queued      int     `json:"queued"`
+	Background  int     `json:"queued_background"`
+	MaxQueue    int     `json:"max_queue"`
+	Completed   int64   `json:"completed"`
+	Rejected    int64   `json:"rejected"`
+	AvgWaitMs   float64 `json:"avg_wait_ms"`
+	MaxWaitMs   float64 `json:"max_wait_ms"`
+	AvgRunMs    float64 `json:"avg_run_ms"`
+}
+
+// New creates a Scheduler running at most concurrency jobs with up to maxQueue waiters.
+func New(concurrency, maxQueue int) *Scheduler {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if maxQueue < 0 {
+		maxQueue = 0
+	}
+	return &Scheduler{slots: make(chan struct{}, concurrency), maxQueue: maxQueue}
+}
+
+// Acquire waits for a slot, failing fast with ErrQueueFull when the queue is full.
+// Call the returned func when the work is done.
+func (s *Scheduler) Acquire(ctx context.Context) (func(), error) {
+	return s.acquire(ctx, true)
+}
+
+// Wait is like Acquire but never rejects, and its callers do not count toward
+// the queue bound, so background work such as warmups cannot push interactive
+// requests into ErrQueueFull. Waiting callers still compete with queued
+// Acquire callers for free slots.
+func (s *Scheduler) Wait(ctx context.Context) (func(), error) {
+	return s.acquire(ctx, false)
+}
+
+// Admit lets in a request whose syntheses will each take a slot with Wait,
+// such as a long text synthesized in several units. It fails with
+// ErrQueueFull when Acquire would, and otherwise holds a queue place until the
+// returned func is called, so admitted requests count toward the bound for as
+// long as they run.
+func (s *Scheduler) Admit() (func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.queued >= s.maxQueue && len(s.slots) == cap(s.slots) {
+		s.rejected++
+		return nil, ErrQueueFull
+	}
+	s.queued++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			s.queued--
+			s.mu.Unlock()
+		})
+	}, nil
+}
+
+func (s *Scheduler) acquire(ctx context.Context, bounded bool) (func(), error) {
+	start := time.Now()
+
+	select {
+	case s.slots <- struct{}{}:
+		s.recordWait(0)
+		return s.releaser(time.Now()), nil
+	default:
+	}
+
+	waiting := &s.background
+	if bounded {
+		waiting = &s.queued
+	}
+	s.mu.Lock()
+	if bounded && s.queued >= s.maxQueue {
+		s.rejected++
+		s.mu.Unlock()
+		return nil, ErrQueueFull
+	}
+	*waiting++
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		*waiting--
+		s.mu.Unlock()
+	}()
+
+	select {
+	case s.slots <- struct{}{}:
+		s.recordWait(time.Since(start))
+		return s.releaser(time.Now()), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *Scheduler) recordWait(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.waits++
+	s.waitTotal += d
+	if d > s.waitMax {
+		s.waitMax = d
+	}
+}
+
+func (s *Scheduler) releaser(started time.Time) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			s.completed++
+			s.runTotal += time.Since(started)
+			s.mu.Unlock()
+			<-s.slots
+		})
+	}
+}
+
+// Stats returns current load and wait-time figures.
+func (s *Scheduler) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := Stats{
+		Concurrency: cap(s.slots),
+		Running:     len(s.slots),
+		Queued:      s.queued,
+		Background:  s.background,
+		MaxQueue:    s.maxQueue,
+		Completed:   s.completed,
+		Rejected:    s.rejected,
+		MaxWaitMs:   ms(s.waitMax),
+	}
+	if s.waits > 0 {
+		st.AvgWaitMs = ms(s.waitTotal / time.Duration(s.waits))
+	}
+	if s.completed > 0 {
+		st.AvgRunMs = ms(s.runTotal / time.Duration(s.completed))
+	}
+	return st
+}
+
+// RetryAfter estimates how long a rejected caller should wait before retrying:
+// the time for the current queue to drain at the observed synthesis rate.
+func (s *Scheduler) RetryAfter() time.Duration {
+	st := s.Stats()
+	avg := time.Duration(st.AvgRunMs * float64(time.Millisecond))
+	if avg <= 0 {
+		avg = time.Second
+	}
+	batches := math.Ceil(float64(st.Queued+1) / float64(st.Concurrency))
+	d := time.Duration(batches) * avg
+	if d < time.Second {
+		d = time.Second
+	}
+	return d.Round(time.Second)
+}
+
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAcquireRejectsWhenQueueFull(t *testing.T) {
+	s := New(1, 1)
+
+	release, err := s.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("first acquire: %v", err)
+	}
+
+	waiterDone := make(chan error, 1)
+	go func() {
+		rel, err := s.Acquire(context.Background())
+		if err == nil {
+			rel()
+		}
+		waiterDone <- err
+	}()
+	waitFor(t, func() bool { return s.Stats().Queued == 1 })
+
+	if _, err := s.Acquire(context.Background()); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+
+	release()
+	if err := <-waiterDone; err != nil {
+		t.Fatalf("queued acquire failed: %v", err)
+	}
+
+	st := s.Stats()
+	if st.Rejected != 1 || st.Completed != 2 || st.Running != 0 || st.Queued != 0 {
+		t.Fatalf("unexpected stats %+v", st)
+	}
+	if st.MaxWaitMs <= 0 {
+		t.Fatalf("expected recorded wait time, got %+v", st)
+	}
+}
+
+func TestWaitIgnoresQueueBound(t *testing.T) {
+	s := New(1, 0)
+	release, _ := s.Acquire(context.Background())
+
+	if _, err := s.Acquire(context.Background()); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull, got %v", err)
+	}
+
+	got := make(chan struct{})
+	go func() {
+		rel, err := s.Wait(context.Background())
+		if err == nil {
+			rel()
+		}
+		close(got)
+	}()
+	release()
+	select {
+	case <-got:
+	case <-time.After(time.Second):
+		t.Fatalf("background waiter never ran")
+	}
+}
+
+func TestWaitersDoNotFillQueue(t *testing.T) {
+	s := New(1, 1)
+	release, _ := s.Acquire(context.Background())
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	for i := 0; i < 3; i++ {
+		go s.Wait(ctx)
+	}
+	deadline := time.Now().Add(time.Second)
+	for s.Stats().Background < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("background waiters not counted: %+v", s.Stats())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	queued := make(chan error, 1)
+	go func() {
+		_, err := s.Acquire(ctx)
+		queued <- err
+	}()
+	for s.Stats().Queued < 1 {
+		select {
+		case err := <-queued:
+			t.Fatalf("interactive request rejected behind background waiters: %v", err)
+		case <-time.After(5 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("interactive request not queued: %+v", s.Stats())
+		}
+	}
+}
+
+func TestAdmitHoldsQueuePlace(t *testing.T) {
+	s := New(1, 1)
+	release, _ := s.Acquire(context.Background())
+
+	leave, err := s.Admit()
+	if err != nil {
+		t.Fatalf("admit: %v", err)
+	}
+	if _, err := s.Admit(); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull for second admission, got %v", err)
+	}
+	if _, err := s.Acquire(context.Background()); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected admitted request to fill the queue, got %v", err)
+	}
+	leave()
+	leave()
+	if st := s.Stats(); st.Queued != 0 {
+		t.Fatalf("queue place not released: %+v", st)
+	}
+	release()
+
+	// With no queue, a request is admitted only while a slot is free.
+	s = New(1, 0)
+	if leave, err := s.Admit(); err != nil {
+		t.Fatalf("admit with free slot: %v", err)
+	} else {
+		leave()
+	}
+	release, _ = s.Acquire(context.Background())
+	defer release()
+	if _, err := s.Admit(); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull without queue, got %v", err)
+	}
+}
+
+func TestAcquireHonoursContext(t *testing.T) {
+	s := New(1, 4)
+	release, _ := s.Acquire(context.Background())
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := s.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+	if q := s.Stats().Queued; q != 0 {
+		t.Fatalf("queue not drained after cancel: %d", q)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
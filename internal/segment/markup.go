@@ -0,0 +1,66 @@
+package segment
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Part is one stretch of a multi-voice script.
+type Part struct {
+	// Voice is the voice named by the nearest preceding [voice=...] tag; empty
+	// before the first tag.
+	Voice string
+	Text  string
+	// Pause is silence inserted after the part.
+	Pause time.Duration
+	// Rate scales the speaking rate (1.5 is 50% faster); 0 leaves it unchanged.
+	Rate float64
+}
+
+var markupTag = regexp.MustCompile(`\[(voice|pause)=([^\]]*)\]`)
+
+// ParseMarkup splits text containing inline [voice=name] and [pause=duration]
+// tags into parts. ok is false when text contains no tags. A pause before any
+// text yields a leading part with empty Text.
+func ParseMarkup(text string) (parts []Part, ok bool, err error) {
+	matches := markupTag.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return nil, false, nil
+	}
+
+	voice := ""
+	addText := func(s string) {
+		if s = strings.Join(strings.Fields(s), " "); s == "" {
+			return
+		}
+		if n := len(parts); n > 0 && parts[n-1].Voice == voice && parts[n-1].Pause == 0 && parts[n-1].Text != "" {
+			parts[n-1].Text += " " + s
+			return
+		}
+		parts = append(parts, Part{Voice: voice, Text: s})
+	}
+
+	prev := 0
+	for _, m := range matches {
+		addText(text[prev:m[0]])
+		prev = m[1]
+		name, value := text[m[2]:m[3]], strings.TrimSpace(text[m[4]:m[5]])
+		switch name {
+		case "voice":
+			voice = value
+		case "pause":
+			d, err := time.ParseDuration(value)
+			if err != nil || d < 0 {
+				return nil, true, fmt.Errorf("invalid pause %q", value)
+			}
+			if len(parts) == 0 {
+				parts = append(parts, Part{Voice: voice})
+			}
+			parts[len(parts)-1].Pause += d
+		}
+	}
+	addText(text[prev:])
+	return parts, true, nil
+}
@@ -0,0 +1,180 @@
+package segment
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// abbreviations are words that end in a period but rarely end a sentence,
+// compared case-insensitively without the trailing period.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "sr": true, "jr": true,
+	"st": true, "mt": true, "ft": true, "gen": true, "capt": true, "lt": true, "col": true,
+	"sgt": true, "rev": true, "hon": true, "gov": true, "sen": true, "rep": true,
+	"fig": true, "no": true, "nos": true, "vol": true, "pp": true, "ch": true, "sec": true,
+	"vs": true, "e.g": true, "i.e": true, "cf": true, "approx": true, "ca": true, "dept": true,
+	"jan": true, "feb": true, "mar": true, "apr": true, "jun": true, "jul": true, "aug": true,
+	"sep": true, "sept": true, "oct": true, "nov": true, "dec": true,
+	"u.s": true, "u.k": true, "a.m": true, "p.m": true,
+}
+
+// Split breaks normalized text into sentences. A sentence ends at '.', '!', '?'
+// or '…' (plus any closing quotes or brackets) followed by whitespace and an
+// upper-case letter, digit or opening quote. Periods after known abbreviations
+// and single-letter initials do not end a sentence.
+func Split(text string) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	var out []string
+	start := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+		if !isTerminator(r) {
+			continue
+		}
+
+		// Absorb runs like "?!" or "..." and closing punctuation.
+		end := i
+		for end < len(text) {
+			next, n := utf8.DecodeRuneInString(text[end:])
+			if !isTerminator(next) && !isCloser(next) {
+				break
+			}
+			end += n
+		}
+		if end >= len(text) {
+			break
+		}
+		next, _ := utf8.DecodeRuneInString(text[end:])
+		if !unicode.IsSpace(next) {
+			i = end
+			continue
+		}
+		rest := strings.TrimLeftFunc(text[end:], unicode.IsSpace)
+		if !startsSentence(rest) {
+			i = end
+			continue
+		}
+		if r == '.' && isAbbreviation(text[start:i-size]) {
+			i = end
+			continue
+		}
+
+		if s := strings.TrimSpace(text[start:end]); s != "" {
+			out = append(out, s)
+		}
+		start = len(text) - len(rest)
+		i = start
+	}
+	if s := strings.TrimSpace(text[start:]); s != "" {
+		out = append(out, s)
+	}
+	return out
+}
+
+// Units breaks text into pieces of at most max characters, packing whole
+// sentences together while they fit. A longer sentence is broken after a
+// comma, semicolon, colon or dash, else between words; only a word longer than
+// max is cut. Text that fits, or a max of 0, yields a single unit.
+func Units(text string, max int) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	if max <= 0 || utf8.RuneCountInString(text) <= max {
+		return []string{text}
+	}
+
+	var out []string
+	cur, n := "", 0
+	for _, sentence := range Split(text) {
+		for _, piece := range breakSentence(sentence, max) {
+			pn := utf8.RuneCountInString(piece)
+			if cur != "" && n+1+pn <= max {
+				cur, n = cur+" "+piece, n+1+pn
+				continue
+			}
+			if cur != "" {
+				out = append(out, cur)
+			}
+			cur, n = piece, pn
+		}
+	}
+	if cur != "" {
+		out = append(out, cur)
+	}
+	return out
+}
+
+// breakSentence cuts s into pieces of at most max characters, preferring
+// clause boundaries in the second half of a piece, then spaces.
+func breakSentence(s string, max int) []string {
+	var out []string
+	r := []rune(s)
+	for len(r) > max {
+		cut := -1
+		for i := max; i > max/2 && cut < 0; i-- {
+			if unicode.IsSpace(r[i]) && strings.ContainsRune(",;:-–—", r[i-1]) {
+				cut = i
+			}
+		}
+		for i := max; i > 0 && cut < 0; i-- {
+			if unicode.IsSpace(r[i]) {
+				cut = i
+			}
+		}
+		if cut < 0 {
+			cut = max
+		}
+		out = append(out, strings.TrimSpace(string(r[:cut])))
+		r = []rune(strings.TrimLeftFunc(string(r[cut:]), unicode.IsSpace))
+	}
+	if len(r) > 0 {
+		out = append(out, string(r))
+	}
+	return out
+}
+
+func isTerminator(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == '…'
+}
+
+func isCloser(r rune) bool {
+	switch r {
+	case '"', '\'', ')', ']', '}', '”', '’', '»':
+		return true
+	}
+	return false
+}
+
+func startsSentence(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	switch r {
+	case '"', '\'', '(', '[', '“', '‘', '«', '¿', '¡':
+		return true
+	}
+	return unicode.IsUpper(r) || unicode.IsDigit(r) || (unicode.IsLetter(r) && !unicode.IsLower(r))
+}
+
+// isAbbreviation reports whether the word ending s (before its final period)
+// is a known abbreviation or a single-letter initial.
+func isAbbreviation(s string) bool {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return false
+	}
+	word := strings.TrimLeft(fields[len(fields)-1], "(\"'“‘[")
+	if word == "" {
+		return false
+	}
+	if utf8.RuneCountInString(word) == 1 {
+		r, _ := utf8.DecodeRuneInString(word)
+		return unicode.IsLetter(r)
+	}
+	return abbreviations[strings.ToLower(word)]
+}
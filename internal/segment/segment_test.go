@@ -0,0 +1,118 @@
+package segment
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSplit(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"Hello world", []string{"Hello world"}},
+		{"Build passed. Deploy started! Ready?", []string{"Build passed.", "Deploy started!", "Ready?"}},
+		{"Mr. Smith met Dr. Jones at 5 p.m. Today.", []string{"Mr. Smith met Dr. Jones at 5 p.m. Today."}},
+		{"J. R. R. Tolkien wrote it. Then he rested.", []string{"J. R. R. Tolkien wrote it.", "Then he rested."}},
+		{"Pi is 3.14 roughly. Nice.", []string{"Pi is 3.14 roughly.", "Nice."}},
+		{"He said \"stop.\" Then left.", []string{"He said \"stop.\"", "Then left."}},
+		{"Wait... what? Really?!", []string{"Wait... what?", "Really?!"}},
+		{"Version 2. 3 issues remain.", []string{"Version 2.", "3 issues remain."}},
+		{"See e.g. Section 4 for details. Thanks.", []string{"See e.g. Section 4 for details.", "Thanks."}},
+		{"Temperatur fällt. Über Nacht Frost.", []string{"Temperatur fällt.", "Über Nacht Frost."}},
+	}
+	for _, tc := range cases {
+		if got := Split(tc.in); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Split(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestUnits(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		max  int
+		want []string
+	}{
+		{"fits", "One. Two.", 20, []string{"One. Two."}},
+		{"no limit", "One. Two.", 0, []string{"One. Two."}},
+		{"packs sentences", "One two. Three four. Five six. Seven.", 20, []string{"One two. Three four.", "Five six. Seven."}},
+		{"clause boundary", "Alpha beta gamma, delta epsilon zeta eta.", 25, []string{"Alpha beta gamma,", "delta epsilon zeta eta."}},
+		{"early comma ignored", "Hi, alpha beta gamma delta epsilon.", 25, []string{"Hi, alpha beta gamma", "delta epsilon."}},
+		{"long word", "abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"empty", "  ", 10, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Units(tc.text, tc.max)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("Units(%q, %d) = %q, want %q", tc.text, tc.max, got, tc.want)
+			}
+			for _, u := range got {
+				if tc.max > 0 && len([]rune(u)) > tc.max {
+					t.Fatalf("unit %q exceeds %d characters", u, tc.max)
+				}
+			}
+		})
+	}
+}
+
+func TestParseMarkup(t *testing.T) {
+	parts, ok, err := ParseMarkup("Intro. [voice=amy] Hello, Ryan. [pause=500ms] How are you? [voice=ryan]  Fine,\n thanks. [voice=amy][pause=1s]")
+	if err != nil || !ok {
+		t.Fatalf("parse: %v %v", ok, err)
+	}
+	want := []Part{
+		{Voice: "", Text: "Intro."},
+		{Voice: "amy", Text: "Hello, Ryan.", Pause: 500 * time.Millisecond},
+		{Voice: "amy", Text: "How are you?"},
+		{Voice: "ryan", Text: "Fine, thanks.", Pause: time.Second},
+	}
+	if !reflect.DeepEqual(parts, want) {
+		t.Fatalf("parts = %+v, want %+v", parts, want)
+	}
+
+	if _, ok, _ := ParseMarkup("no tags [here]"); ok {
+		t.Fatalf("plain text should not be markup")
+	}
+	if _, _, err := ParseMarkup("[pause=soon] hi"); err == nil {
+		t.Fatalf("invalid pause should fail")
+	}
+	if parts, _, _ := ParseMarkup("[pause=2s][voice=amy] hi"); len(parts) != 2 || parts[0].Text != "" || parts[0].Pause != 2*time.Second {
+		t.Fatalf("leading pause: %+v", parts)
+	}
+}
+
+func TestParseSSML(t *testing.T) {
+	parts, err := ParseSSML(`<speak>
+		<p><s>Welcome home</s><s>It is <say-as interpret-as="date" format="mdy">3/1/2024</say-as></s></p>
+		<voice name="ryan">Code <say-as interpret-as="digits">407</say-as>, <sub alias="World Wide Web Consortium">W3C</sub>
+		<break time="300ms"/><prosody rate="slow">slowly <emphasis>now</emphasis></prosody></voice>
+		<break strength="strong"/><say-as interpret-as="characters">SOS</say-as> &amp; done
+	</speak>`)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []Part{
+		{Text: "Welcome home. It is 2024-03-01.", Pause: paragraphPause},
+		{Voice: "ryan", Text: "Code 4 0 7, World Wide Web Consortium", Pause: 300 * time.Millisecond},
+		{Voice: "ryan", Text: "slowly now", Rate: 0.75, Pause: 750 * time.Millisecond},
+		{Text: "S O S & done"},
+	}
+	if !reflect.DeepEqual(parts, want) {
+		t.Fatalf("parts = %+v, want %+v", parts, want)
+	}
+
+	for _, doc := range []string{
+		`<speak>unclosed`,
+		`<speak><break time="soon"/></speak>`,
+		`<speak><prosody rate="warp">x</prosody></speak>`,
+	} {
+		if _, err := ParseSSML(doc); err == nil {
+			t.Fatalf("%s: expected error", doc)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package segment
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// breakStrengths are the pauses used for <break strength="...">.
+var breakStrengths = map[string]time.Duration{
+	"none":     0,
+	"x-weak":   100 * time.Millisecond,
+	"weak":     250 * time.Millisecond,
+	"medium":   500 * time.Millisecond,
+	"strong":   750 * time.Millisecond,
+	"x-strong": 1200 * time.Millisecond,
+}
+
+// prosodyRates are the speaking rates of <prosody rate="..."> keywords.
+var prosodyRates = map[string]float64{
+	"x-slow": 0.5, "slow": 0.75, "medium": 1, "default": 1, "fast": 1.25, "x-fast": 1.5,
+}
+
+// paragraphPause follows every </p>.
+const paragraphPause = 500 * time.Millisecond
+
+// ssmlFrame is an open element; voice and rate apply to the text inside it.
+type ssmlFrame struct {
+	name  string
+	voice string
+	rate  float64
+	// sayAs collects the text of a <say-as> element until it closes.
+	sayAs             *strings.Builder
+	interpret, format string
+	// skip drops the element's text, e.g. the original text of <sub>.
+	skip bool
+}
+
+// ParseSSML converts an SSML document into parts. It understands <voice
+// name>, <break time|strength>, <prosody rate>, <say-as interpret-as> (digits,
+// characters, date), <sub alias> and <p>/<s>; other elements are dropped and
+// their text kept. Text inside <prosody rate> gets a Rate, so voices switch
+// and rate changes both start a new part.
+func ParseSSML(doc string) ([]Part, error) {
+	d := xml.NewDecoder(strings.NewReader(doc))
+	d.Entity = xml.HTMLEntity
+
+	var parts []Part
+	var pending strings.Builder
+	stack := []ssmlFrame{{rate: 1}}
+	top := func() *ssmlFrame { return &stack[len(stack)-1] }
+
+	// flush moves pending text into a part for the current voice and rate.
+	flush := func() {
+		text := strings.Join(strings.Fields(pending.String()), " ")
+		pending.Reset()
+		if text == "" {
+			return
+		}
+		voice, rate := top().voice, partRate(top().rate)
+		if n := len(parts); n > 0 && parts[n-1].Voice == voice && parts[n-1].Rate == rate && parts[n-1].Pause == 0 && parts[n-1].Text != "" {
+			parts[n-1].Text += " " + text
+			return
+		}
+		parts = append(parts, Part{Voice: voice, Text: text, Rate: rate})
+	}
+	pause := func(p time.Duration) {
+		flush()
+		if len(parts) == 0 {
+			parts = append(parts, Part{Voice: top().voice})
+		}
+		parts[len(parts)-1].Pause += p
+	}
+	write := func(s string) {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i].skip {
+				return
+			}
+			if stack[i].sayAs != nil {
+				stack[i].sayAs.WriteString(s)
+				return
+			}
+		}
+		pending.WriteString(s)
+	}
+
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid ssml: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.CharData:
+			write(string(t))
+		case xml.StartElement:
+			f := *top()
+			f.name, f.sayAs, f.skip = t.Name.Local, nil, false
+			switch f.name {
+			case "voice":
+				if name := attr(t, "name"); name != "" {
+					flush()
+					f.voice = name
+				}
+			case "prosody":
+				if v := attr(t, "rate"); v != "" {
+					rate, err := parseRate(v)
+					if err != nil {
+						return nil, err
+					}
+					flush()
+					f.rate *= rate
+				}
+			case "break":
+				p, err := parseBreak(t)
+				if err != nil {
+					return nil, err
+				}
+				pause(p)
+			case "sub":
+				write(attr(t, "alias"))
+				f.skip = true
+			case "say-as":
+				f.sayAs = &strings.Builder{}
+				f.interpret, f.format = attr(t, "interpret-as"), attr(t, "format")
+			}
+			stack = append(stack, f)
+		case xml.EndElement:
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("invalid ssml: unexpected </%s>", t.Name.Local)
+			}
+			f := *top()
+			switch f.name {
+			case "voice", "prosody":
+				if f.voice != stack[len(stack)-2].voice || f.rate != stack[len(stack)-2].rate {
+					flush()
+				}
+			case "say-as":
+				stack = stack[:len(stack)-1]
+				write(sayAs(f.interpret, f.format, f.sayAs.String()))
+				continue
+			case "s", "p":
+				endSentence(&pending)
+				if f.name == "p" {
+					pause(paragraphPause)
+				}
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	flush()
+	return parts, nil
+}
+
+// partRate stores a rate of 1 as unset so plain text keys stay unchanged.
+func partRate(rate float64) float64 {
+	if rate == 1 {
+		return 0
+	}
+	return rate
+}
+
+func attr(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if a.Name.Local == name {
+			return strings.TrimSpace(a.Value)
+		}
+	}
+	return ""
+}
+
+// parseRate reads a prosody rate: a keyword, a multiplier ("1.2"), a
+// percentage of the normal rate ("80%") or a relative change ("+10%").
+func parseRate(v string) (float64, error) {
+	if r, ok := prosodyRates[v]; ok {
+		return r, nil
+	}
+	pct := strings.HasSuffix(v, "%")
+	n, err := strconv.ParseFloat(strings.TrimSuffix(v, "%"), 64)
+	switch {
+	case err != nil:
+	case pct && (v[0] == '+' || v[0] == '-'):
+		n = 1 + n/100
+	case pct:
+		n /= 100
+	}
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid prosody rate %q", v)
+	}
+	return n, nil
+}
+
+func parseBreak(e xml.StartElement) (time.Duration, error) {
+	if v := attr(e, "time"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("invalid break time %q", v)
+		}
+		return d, nil
+	}
+	if v := attr(e, "strength"); v != "" {
+		d, ok := breakStrengths[v]
+		if !ok {
+			return 0, fmt.Errorf("invalid break strength %q", v)
+		}
+		return d, nil
+	}
+	return breakStrengths["medium"], nil
+}
+
+// endSentence terminates the pending text with a period unless it already
+// ends a sentence.
+func endSentence(b *strings.Builder) {
+	text := strings.TrimRightFunc(b.String(), unicode.IsSpace)
+	if text == "" {
+		return
+	}
+	if r := []rune(text); !strings.ContainsRune(".!?…:;", r[len(r)-1]) {
+		b.WriteString(".")
+	}
+	b.WriteString(" ")
+}
+
+var ssmlDate = regexp.MustCompile(`^(\d{1,4})[-/.](\d{1,2})[-/.](\d{1,4})$`)
+
+// sayAs rewrites text so normalization reads it as interpret says: digits
+// and characters one by one, dates in ISO form. Other kinds are left as is.
+func sayAs(interpret, format, text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	switch interpret {
+	case "digits", "characters", "spell-out", "telephone":
+		var out []string
+		for _, r := range text {
+			if !unicode.IsSpace(r) && (interpret != "telephone" || unicode.IsDigit(r)) {
+				out = append(out, string(r))
+			}
+		}
+		return strings.Join(out, " ")
+	case "date":
+		m := ssmlDate.FindStringSubmatch(text)
+		if m == nil {
+			return text
+		}
+		var y, mo, d string
+		switch format {
+		case "mdy":
+			mo, d, y = m[1], m[2], m[3]
+		case "dmy":
+			d, mo, y = m[1], m[2], m[3]
+		case "ymd", "":
+			y, mo, d = m[1], m[2], m[3]
+		default:
+			return text
+		}
+		month, _ := strconv.Atoi(mo)
+		day, _ := strconv.Atoi(d)
+		if len(y) != 4 || month < 1 || month > 12 || day < 1 || day > 31 {
+			return text
+		}
+		return fmt.Sprintf("%s-%02d-%02d", y, month, day)
+	}
+	return text
+}
@@ -0,0 +1,337 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/audio"
+	"github.com/venkytv/tts-cached/internal/cache"
+	"github.com/venkytv/tts-cached/internal/engine"
+	"github.com/venkytv/tts-cached/internal/segment"
+)
+
+const (
+	maxDialogueSegments = 64
+	maxDialoguePause    = 30 * time.Second
+)
+
+// dialogueSegment is one entry of a /tts "segments" list.
+type dialogueSegment struct {
+	Text  string `json:"text"`
+	Voice string `json:"voice,omitempty"`
+	// Pause is silence after the segment, as a duration such as "500ms".
+	Pause string `json:"pause,omitempty"`
+}
+
+// dialogueParts converts a request's segments, its SSML, or inline
+// [voice=...] markup in its text, into parts. ok is false for an ordinary
+// single-voice request.
+func dialogueParts(req ttsRequest) (parts []segment.Part, ok bool, err error) {
+	switch {
+	case req.SSML != "":
+		if req.Text != "" || len(req.Segments) > 0 {
+			return nil, true, fmt.Errorf("ssml cannot be combined with text or segments")
+		}
+		ok = true
+		parts, err = segment.ParseSSML(req.SSML)
+	case len(req.Segments) == 0:
+		parts, ok, err = segment.ParseMarkup(req.Text)
+	default:
+		ok = true
+		for i, seg := range req.Segments {
+			p := segment.Part{Voice: seg.Voice, Text: normalizeText(seg.Text)}
+			if seg.Pause != "" {
+				if p.Pause, err = time.ParseDuration(seg.Pause); err != nil || p.Pause < 0 {
+					return nil, true, fmt.Errorf("segment %d: invalid pause %q", i+1, seg.Pause)
+				}
+			}
+			parts = append(parts, p)
+		}
+	}
+	if !ok || err != nil {
+		return parts, ok, err
+	}
+	if len(parts) > maxDialogueSegments {
+		return nil, true, fmt.Errorf("too many segments (max %d)", maxDialogueSegments)
+	}
+	hasText := false
+	for i, p := range parts {
+		if p.Pause > maxDialoguePause {
+			return nil, true, fmt.Errorf("segment %d: pause exceeds %s", i+1, maxDialoguePause)
+		}
+		hasText = hasText || p.Text != ""
+	}
+	if !hasText {
+		return nil, true, fmt.Errorf("text is required")
+	}
+	return parts, true, nil
+}
+
+// dialogueRequest parses a dialogue request, redacts each part's text, picks
+// voices for parts that name none, as selectVoice would for a single-voice
+// request, and prepares each part's text for its voice.
+func (s *Server) dialogueRequest(r *http.Request, req ttsRequest) ([]segment.Part, bool, error) {
+	parts, ok, err := dialogueParts(req)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+	texts := make([]string, len(parts))
+	for i, p := range parts {
+		if p.Text != "" {
+			parts[i].Text = s.redact(normalizeText(p.Text))
+			texts[i] = parts[i].Text
+		}
+	}
+	if err := s.checkLength(texts...); err != nil {
+		return nil, true, err
+	}
+	for i, p := range parts {
+		if p.Text == "" {
+			continue
+		}
+		if p.Voice == "" {
+			if parts[i].Voice, _, err = s.selectVoice(r, ttsRequest{Voice: req.Voice, Lang: req.Lang}, p.Text); err != nil {
+				return nil, true, err
+			}
+		}
+		if parts[i].Text, err = s.prepare(r.Context(), p.Text, parts[i].Voice); err != nil {
+			return nil, true, err
+		}
+	}
+	return parts, true, nil
+}
+
+// dialogueText joins the parts' texts for cache statistics.
+func dialogueText(parts []segment.Part) string {
+	texts := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p.Text != "" {
+			texts = append(texts, p.Text)
+		}
+	}
+	return strings.Join(texts, " ")
+}
+
+// dialogueKey returns the cache key of a composite of parts spoken by voices.
+func dialogueKey(parts []segment.Part, voices []string, params engine.Params) string {
+	return cache.BuildKey(cache.DialogueVoice, dialogueScript(parts, voices), params.Key())
+}
+
+// dialogueScript describes parts and their voices one line per part; it is
+// the text behind a dialogue's cache key.
+func dialogueScript(parts []segment.Part, voices []string) string {
+	var script strings.Builder
+	for i, p := range parts {
+		if p.Rate != 0 {
+			fmt.Fprintf(&script, "%s|%s|rate=%s|%s\n", voices[i], p.Pause, engine.FormatFloat(p.Rate), p.Text)
+			continue
+		}
+		fmt.Fprintf(&script, "%s|%s|%s\n", voices[i], p.Pause, p.Text)
+	}
+	return script.String()
+}
+
+// ensureDialogue produces one clip from parts spoken by different voices. Each
+// part goes through ensureCached and its own cache entry; the joined clip is
+// cached under a key covering every part. If a voice had to fall back, the
+// clip is keyed by the voices actually used.
+func (s *Server) ensureDialogue(ctx context.Context, parts []segment.Part, params engine.Params) (synthResult, error) {
+	voices := make([]string, len(parts))
+	partParams := make([]engine.Params, len(parts))
+	for i, p := range parts {
+		v, e, err := s.engines.Resolve(p.Voice)
+		if err != nil {
+			return synthResult{}, fmt.Errorf("segment %d: %w", i+1, err)
+		}
+		voices[i] = v.Name
+		partParams[i] = rateParams(v, e, params, p.Rate)
+	}
+
+	res := synthResult{key: dialogueKey(parts, voices, params)}
+	res.path = s.cache.PathForKey(res.key)
+	if _, err := os.Stat(res.path); err == nil {
+		s.cache.Touch(res.path)
+		res.status = "cache_hit"
+		return res, nil
+	}
+
+	leave, err := s.admit(synthJob{}, res.key)
+	if err != nil {
+		return res, err
+	}
+	defer leave()
+
+	results := make([]synthResult, len(parts))
+	errs := make([]error, len(parts))
+	s.fanOut(len(parts), func(i int) {
+		if parts[i].Text == "" {
+			return
+		}
+		// The dialogue holds the queue place for all of its segments; each
+		// segment still falls back through its own voice's chain.
+		results[i], errs[i] = s.ensureCached(ctx, synthJob{text: parts[i].Text, voice: voices[i], params: partParams[i], background: true})
+	})
+
+	counts := &sentenceCounts{}
+	paths := make([]string, len(parts))
+	gaps := make([]time.Duration, len(parts))
+	for i, r := range results {
+		if errs[i] != nil {
+			return res, fmt.Errorf("segment %d: %w", i+1, errs[i])
+		}
+		gaps[i] = parts[i].Pause
+		if parts[i].Text == "" {
+			continue
+		}
+		paths[i] = r.path
+		counts.Total++
+		if r.status == "cache_hit" || r.status == "peer_hit" {
+			counts.Cached++
+		}
+		if r.fallback != "" {
+			voices[i] = r.fallback
+			if res.fallback == "" {
+				res.fallback = r.fallback
+			}
+		}
+	}
+	if res.fallback != "" {
+		res.key = dialogueKey(parts, voices, params)
+		res.path = s.cache.PathForKey(res.key)
+	}
+
+	unlock, err := s.cache.LockKey(ctx, res.key)
+	if err != nil {
+		s.logger.Printf("ERROR: lock cache key failed: %v", err)
+		return res, err
+	}
+	defer unlock()
+
+	// Another request may have built the same clip while the segments ran.
+	if _, err := os.Stat(res.path); err == nil {
+		s.cache.Touch(res.path)
+		res.status = "cache_hit"
+		return res, nil
+	}
+	if err := joinWavs(paths, gaps, res.path); err != nil {
+		s.logger.Printf("ERROR: build dialogue wav failed: %v", err)
+		return res, err
+	}
+	s.commit(s.dialogueVoice(voices), params, res.key, dialogueScript(parts, voices), res.path)
+
+	res.segments = counts
+	if counts.Cached == 0 {
+		res.status = "cache_miss"
+	} else {
+		res.status = "partial_hit"
+	}
+	return res, nil
+}
+
+// dialogueVoice describes the voices of a dialogue as one voice for its cache
+// metadata, so that import can check every voice's model.
+func (s *Server) dialogueVoice(voices []string) engine.Voice {
+	models := make(map[string]string, len(voices))
+	for _, name := range voices {
+		if v, _, err := s.engines.Resolve(name); err == nil {
+			models[name] = v.Fingerprint
+		}
+	}
+	return engine.Voice{Name: cache.DialogueVoice, Fingerprint: cache.DialogueModel(models)}
+}
+
+// rateParams applies a part's speaking rate to params as a length_scale,
+// clamped to the voice's range. Voices whose engine has no length_scale speak
+// at their normal rate.
+func rateParams(v engine.Voice, e engine.Engine, params engine.Params, rate float64) engine.Params {
+	if rate == 0 {
+		return params
+	}
+	supported := false
+	for _, name := range e.Capabilities().Params {
+		supported = supported || name == engine.ParamLengthScale
+	}
+	if !supported {
+		return params
+	}
+	ls := 1.0
+	if params.LengthScale != nil {
+		ls = *params.LengthScale
+	}
+	ls /= rate
+	r := v.Range(engine.ParamLengthScale)
+	ls = math.Min(math.Max(ls, r.Min), r.Max)
+	params.LengthScale = &ls
+	return params
+}
+
+// joinWavs concatenates the wavs at paths into outPath, adding gaps[i] of
+// silence after part i. An empty path contributes only its gap. Parts are
+// resampled to the highest sample rate among them; channel count and sample
+// width must match.
+func joinWavs(paths []string, gaps []time.Duration, outPath string) error {
+	formats := make([]audio.Format, len(paths))
+	data := make([][]byte, len(paths))
+	var format audio.Format
+	for i, path := range paths {
+		if path == "" {
+			continue
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		pf, pcm, err := audio.ReadWAV(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("read %s: %w", filepath.Base(path), err)
+		}
+		if format.SampleRate == 0 {
+			format = pf
+		} else if pf.Channels != format.Channels || pf.BitsPerSample != format.BitsPerSample {
+			return fmt.Errorf("part formats differ: %+v vs %+v", pf, format)
+		}
+		if pf.SampleRate > format.SampleRate {
+			format.SampleRate = pf.SampleRate
+		}
+		formats[i], data[i] = pf, pcm
+	}
+	if format.SampleRate == 0 {
+		return fmt.Errorf("no audio to join")
+	}
+
+	var pcm bytes.Buffer
+	for i := range paths {
+		if data[i] != nil {
+			converted, err := audio.Resample(formats[i], data[i], format.SampleRate)
+			if err != nil {
+				return err
+			}
+			pcm.Write(converted)
+		}
+		if gaps[i] > 0 {
+			pcm.Write(audio.Silence(format, gaps[i]))
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(outPath), filepath.Base(outPath)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := audio.WriteWAV(tmp, format, pcm.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), outPath)
+}
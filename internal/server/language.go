@@ -0,0 +1,128 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/venkytv/tts-cached/internal/engine"
+	"github.com/venkytv/tts-cached/internal/langid"
+)
+
+// selectVoice picks the voice for a request. An explicit voice wins; then the
+// request's lang field, then (with LANG_DETECT) Accept-Language and the
+// language detected in the text. An empty result selects the default voice.
+// The second result reports how the voice was chosen, for logging.
+func (s *Server) selectVoice(r *http.Request, req ttsRequest, text string) (string, string, error) {
+	if req.Voice != "" {
+		return req.Voice, "", nil
+	}
+	if req.Lang != "" {
+		v, ok := s.voiceForLanguage(req.Lang)
+		if !ok {
+			return "", "", fmt.Errorf("%w %q", errNoLanguageVoice, req.Lang)
+		}
+		return v, "lang=" + req.Lang, nil
+	}
+	if !s.cfg.LangDetect {
+		return "", "", nil
+	}
+	for _, tag := range acceptLanguages(r.Header.Get("Accept-Language")) {
+		if v, ok := s.voiceForLanguage(tag); ok {
+			return v, "accept-language=" + tag, nil
+		}
+	}
+	if lang, ok := langid.Detect(text, s.languages()); ok {
+		if v, ok := s.voiceForLanguage(lang); ok {
+			return v, "detected=" + lang, nil
+		}
+	}
+	return "", "", nil
+}
+
+// errNoLanguageVoice rejects a lang field no configured voice speaks.
+var errNoLanguageVoice = errors.New("no voice configured for language")
+
+// voiceLanguage returns v's language, or its engine's when the engine reports
+// exactly one.
+func (s *Server) voiceLanguage(v engine.Voice) string {
+	if v.Language != "" {
+		return v.Language
+	}
+	if eng, ok := s.engines.Engine(v.Engine); ok {
+		if langs := eng.Capabilities().Languages; len(langs) == 1 {
+			return langs[0]
+		}
+	}
+	return ""
+}
+
+// voiceForLanguage returns a voice speaking lang (compared by primary subtag),
+// preferring the default voice, then the first by name.
+func (s *Server) voiceForLanguage(lang string) (string, bool) {
+	base := langid.Base(lang)
+	if base == "" {
+		return "", false
+	}
+	found := ""
+	for _, v := range s.engines.Voices() {
+		if langid.Base(s.voiceLanguage(v)) != base {
+			continue
+		}
+		if v.Name == s.engines.DefaultVoice() {
+			return v.Name, true
+		}
+		if found == "" {
+			found = v.Name
+		}
+	}
+	return found, found != ""
+}
+
+// languages lists the configured voices' languages, the detector's candidates.
+func (s *Server) languages() []string {
+	var out []string
+	for _, v := range s.engines.Voices() {
+		if lang := s.voiceLanguage(v); lang != "" {
+			out = append(out, lang)
+		}
+	}
+	return out
+}
+
+// acceptLanguages returns the tags of an Accept-Language header ordered by
+// quality, dropping wildcards and q=0.
+func acceptLanguages(header string) []string {
+	type entry struct {
+		tag string
+		q   float64
+	}
+	var entries []entry
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(f), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			entries = append(entries, entry{tag, q})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].q > entries[j].q })
+	tags := make([]string, len(entries))
+	for i, e := range entries {
+		tags[i] = e.tag
+	}
+	return tags
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"os"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/lexicon"
+)
+
+const lexiconPollInterval = 2 * time.Second
+
+// WatchLexicon loads the lexicon at path and reloads it whenever the file
+// changes. A lexicon that fails to reload is logged and the previous one kept.
+func (s *Server) WatchLexicon(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := s.loadLexicon(path); err != nil {
+		return err
+	}
+	go s.pollLexicon(path, info)
+	return nil
+}
+
+func (s *Server) loadLexicon(path string) error {
+	lex, err := lexicon.Load(path)
+	if err != nil {
+		return err
+	}
+	s.lexicon.Store(lex)
+	s.logger.Printf("INFO: loaded lexicon %s (%d entries)", path, lex.Len())
+	return nil
+}
+
+// pollLexicon reloads the lexicon when its size or modification time changes
+// until the server is closed.
+func (s *Server) pollLexicon(path string, last os.FileInfo) {
+	ticker := time.NewTicker(lexiconPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.bgCtx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil {
+				s.logger.Printf("ERROR: stat lexicon %s failed: %v", path, err)
+				continue
+			}
+			if info.ModTime().Equal(last.ModTime()) && info.Size() == last.Size() {
+				continue
+			}
+			last = info
+			if err := s.loadLexicon(path); err != nil {
+				s.logger.Printf("ERROR: reload lexicon %s failed, keeping previous: %v", path, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/venkytv/tts-cached/internal/segment"
+)
+
+// limitError is the 413 body for a request over MAX_BODY_BYTES or
+// MAX_TEXT_CHARS.
+type limitError struct {
+	Code    string `json:"error"`
+	Message string `json:"message"`
+	Limit   int64  `json:"limit"`
+	// Size is the request's size, when known.
+	Size int64 `json:"size,omitempty"`
+}
+
+func (e *limitError) Error() string { return e.Message }
+
+// decodeRequest reads a /tts-style JSON body of at most MaxBodyBytes. It
+// writes the error response and returns false when the body cannot be used.
+func (s *Server) decodeRequest(w http.ResponseWriter, r *http.Request, req *ttsRequest) bool {
+	if max := s.cfg.MaxBodyBytes; max > 0 {
+		if r.ContentLength > max {
+			s.writeLimitError(w, bodyTooLarge(max, r.ContentLength))
+			return false
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, max)
+	}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		var mbe *http.MaxBytesError
+		if errors.As(err, &mbe) {
+			s.writeLimitError(w, bodyTooLarge(mbe.Limit, 0))
+			return false
+		}
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func bodyTooLarge(limit, size int64) *limitError {
+	return &limitError{
+		Code:    "body_too_large",
+		Message: fmt.Sprintf("request body exceeds %d bytes", limit),
+		Limit:   limit,
+		Size:    size,
+	}
+}
+
+// checkLength rejects texts longer than MaxTextChars characters.
+func (s *Server) checkLength(texts ...string) error {
+	if s.cfg.MaxTextChars <= 0 {
+		return nil
+	}
+	n := 0
+	for _, text := range texts {
+		n += utf8.RuneCountInString(text)
+	}
+	if n <= s.cfg.MaxTextChars {
+		return nil
+	}
+	return &limitError{
+		Code:    "text_too_long",
+		Message: fmt.Sprintf("text has %d characters; the limit is %d", n, s.cfg.MaxTextChars),
+		Limit:   int64(s.cfg.MaxTextChars),
+		Size:    int64(n),
+	}
+}
+
+func (s *Server) writeLimitError(w http.ResponseWriter, e *limitError) {
+	s.logger.Printf("INFO: rejecting request: %s", e.Message)
+	s.writeJSON(w, http.StatusRequestEntityTooLarge, e)
+}
+
+// units splits text into the pieces synthesized separately: its sentences
+// with SENTENCE_CACHE, otherwise runs of whole sentences. No piece is longer
+// than MaxUnitChars, so each stays within the synthesis timeout.
+func (s *Server) units(text string) []string {
+	if !s.cfg.SentenceCache {
+		return segment.Units(text, s.cfg.MaxUnitChars)
+	}
+	var out []string
+	for _, sentence := range segment.Split(text) {
+		out = append(out, segment.Units(sentence, s.cfg.MaxUnitChars)...)
+	}
+	return out
+}
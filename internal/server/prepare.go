@@ -0,0 +1,205 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/venkytv/tts-cached/internal/config"
+	"github.com/venkytv/tts-cached/internal/langid"
+	"github.com/venkytv/tts-cached/internal/normalize"
+	"github.com/venkytv/tts-cached/internal/redact"
+	"github.com/venkytv/tts-cached/internal/textformat"
+	"github.com/venkytv/tts-cached/internal/unicodetext"
+)
+
+// errFilterRejected is returned when the text filter fails and
+// FILTER_FAILURE is reject.
+var errFilterRejected = errors.New("text filter failed")
+
+// SetRedactor makes the server redact request text as it arrives, before it
+// is filtered, logged, counted or cached. Call it before serving requests.
+func (s *Server) SetRedactor(r *redact.Redactor) {
+	s.redactor = r
+}
+
+// extractText converts the request's text and segment texts from its format
+// to plain text.
+func extractText(req *ttsRequest) error {
+	var err error
+	if req.Text, err = textformat.Convert(req.Text, req.Format); err != nil {
+		return err
+	}
+	for i := range req.Segments {
+		if req.Segments[i].Text, err = textformat.Convert(req.Segments[i].Text, req.Format); err != nil {
+			return fmt.Errorf("segment %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// redact applies the redactor to incoming text, logging how many matches each
+// detector replaced but never the text itself.
+func (s *Server) redact(text string) string {
+	out, counts := s.redactor.Redact(text)
+	if len(counts) > 0 {
+		names := make([]string, 0, len(counts))
+		for name := range counts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for i, name := range names {
+			names[i] = fmt.Sprintf("%s=%d", name, counts[name])
+		}
+		s.logger.Printf("INFO: redacted %s", strings.Join(names, " "))
+	}
+	return out
+}
+
+// prepare turns whitespace-collapsed request text into what voice will speak.
+// The result is the text cache keys are built from.
+func (s *Server) prepare(ctx context.Context, text, voice string) (string, error) {
+	out, _, err := s.trace(ctx, text, voice)
+	return out, err
+}
+
+// trace runs the filter command, the lexicon, emoji and symbol handling and
+// then normalization for the voice's language, recording each step that
+// changed the text.
+func (s *Server) trace(ctx context.Context, text, voice string) (string, []normalize.Step, error) {
+	emoji := s.cfg.Emoji
+	if v, _, err := s.engines.Resolve(voice); err == nil {
+		voice = v.Name
+		if v.Emoji != "" {
+			emoji = v.Emoji
+		}
+	}
+	var steps []normalize.Step
+	if s.filter != nil {
+		out, err := s.filter.Apply(ctx, text)
+		switch {
+		case err == nil:
+			if out = normalizeText(out); out != text {
+				text = out
+				steps = append(steps, normalize.Step{Rule: "filter", Text: text})
+			}
+		case s.cfg.FilterFailure == config.FilterReject:
+			s.logger.Printf("ERROR: %v; rejecting request", err)
+			return "", nil, errFilterRejected
+		default:
+			s.logger.Printf("ERROR: %v; using unfiltered text", err)
+		}
+	}
+	if out := s.lexicon.Load().Apply(text, voice); out != text {
+		text = normalizeText(out)
+		steps = append(steps, normalize.Step{Rule: "lexicon", Text: text})
+	}
+	lang := s.languageOf(voice)
+	if out := unicodetext.Symbols(text, emoji, lang); out != text {
+		text = out
+		steps = append(steps, normalize.Step{Rule: "symbols", Text: text})
+	}
+	out, more := s.norm.Trace(text, lang)
+	return out, append(steps, more...), nil
+}
+
+// writeRequestError reports a request that could not be prepared.
+func (s *Server) writeRequestError(w http.ResponseWriter, err error) {
+	var le *limitError
+	if errors.As(err, &le) {
+		s.writeLimitError(w, le)
+		return
+	}
+	if errors.Is(err, errFilterRejected) {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusBadRequest)
+}
+
+// languageOf returns the primary language subtag of the named voice (the
+// default voice when name is empty), or "" if it has none.
+func (s *Server) languageOf(name string) string {
+	v, _, err := s.engines.Resolve(name)
+	if err != nil {
+		return ""
+	}
+	return langid.Base(s.voiceLanguage(v))
+}
+
+type normalizeResponse struct {
+	Voice    string           `json:"voice"`
+	Language string           `json:"language,omitempty"`
+	Input    string           `json:"input"`
+	Text     string           `json:"text"`
+	Steps    []normalize.Step `json:"steps"`
+}
+
+// handleNormalize shows what would be spoken for a text without synthesizing
+// it. It accepts the /tts request body (POST) or text, voice and lang query
+// parameters (GET).
+func (s *Server) handleNormalize(w http.ResponseWriter, r *http.Request) {
+	var req ttsRequest
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req = ttsRequest{Text: q.Get("text"), Voice: q.Get("voice"), Lang: q.Get("lang"), Format: q.Get("format")}
+	case http.MethodPost:
+		if !s.decodeRequest(w, r, &req) {
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	raw := normalizeText(req.Text)
+	if err := extractText(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	input := normalizeText(req.Text)
+	if input == "" {
+		http.Error(w, "text is required", http.StatusBadRequest)
+		return
+	}
+	if err := s.checkLength(input); err != nil {
+		s.writeRequestError(w, err)
+		return
+	}
+	// Steps before redaction are shown redacted too; secrets are never echoed.
+	var intake []normalize.Step
+	if input != raw {
+		shown, _ := s.redactor.Redact(input)
+		intake = append(intake, normalize.Step{Rule: "format", Text: shown})
+	}
+	if out := s.redact(input); out != input {
+		intake = append(intake, normalize.Step{Rule: "redact", Text: out})
+		input = out
+	}
+	voice, _, err := s.selectVoice(r, req, input)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	v, _, err := s.engines.Resolve(voice)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := normalizeResponse{Voice: v.Name, Language: s.languageOf(v.Name), Input: input}
+	resp.Text, resp.Steps, err = s.trace(r.Context(), input, v.Name)
+	if err != nil {
+		s.writeRequestError(w, err)
+		return
+	}
+	resp.Steps = append(intake, resp.Steps...)
+	if resp.Steps == nil {
+		resp.Steps = []normalize.Step{}
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/audio"
+	"github.com/venkytv/tts-cached/internal/engine"
+)
+
+// sentenceCounts reports how much of a multi-sentence text was reused.
+type sentenceCounts struct {
+	Total  int `json:"total"`
+	Cached int `json:"cached"`
+}
+
+// admit lets a request made of several syntheses in once, holding a place in
+// the SYNTH_QUEUE bound while it runs; its syntheses then wait for slots as
+// background jobs. Background requests are not admitted, they only wait.
+func (s *Server) admit(job synthJob, key string) (func(), error) {
+	if job.background {
+		return func() {}, nil
+	}
+	leave, err := s.sched.Admit()
+	if err != nil {
+		s.logger.Printf("INFO: synthesis queue full, rejecting key=%s", key)
+	}
+	return leave, err
+}
+
+// fanOut calls fn for 0..n-1, running at most SynthConcurrency calls at once
+// so one request cannot flood the scheduler with waiters.
+func (s *Server) fanOut(n int, fn func(i int)) {
+	limit := s.cfg.SynthConcurrency
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// ensureComposite builds the wav for a text split into sentences or units from
+// their own cache entries, synthesizing only the ones that are missing. The
+// composite is cached under the full text's key. A job's sink receives the
+// first unit while it is synthesized and the rest once the composite is built,
+// after its queue place and key lock are released.
+func (s *Server) ensureComposite(ctx context.Context, job synthJob, voice engine.Voice, res synthResult, sentences []string) (synthResult, error) {
+	held := &heldSink{sink: job.sink}
+	res, err := s.buildComposite(ctx, job, voice, res, sentences, held)
+	if err != nil || !res.streamed {
+		held.close()
+		return res, err
+	}
+	pcm, err := held.rest(res.path)
+	if err != nil {
+		s.logger.Printf("ERROR: stream rest of key=%s failed: %v", res.key, err)
+		held.close()
+		return res, nil
+	}
+	if job.detach {
+		go func() {
+			s.writeRest(held, res.key, pcm)
+			held.close()
+		}()
+		return res, nil
+	}
+	s.writeRest(held, res.key, pcm)
+	held.close()
+	return res, nil
+}
+
+// buildComposite joins the units of ensureComposite, streaming the first one
+// to held.
+func (s *Server) buildComposite(ctx context.Context, job synthJob, voice engine.Voice, res synthResult, sentences []string, held *heldSink) (synthResult, error) {
+	leave, err := s.admit(job, res.key)
+	if err != nil {
+		return res, err
+	}
+	defer leave()
+
+	parts := make([]synthResult, len(sentences))
+	errs := make([]error, len(sentences))
+	s.fanOut(len(sentences), func(i int) {
+		// The request was admitted as a whole, so its sentences wait for slots
+		// instead of being rejected one by one. Fallback applies to the whole
+		// text, never to single sentences.
+		part := synthJob{text: sentences[i], voice: voice.Name, params: job.params, background: true, single: true, exact: true}
+		if i == 0 && job.sink != nil {
+			part.sink = held.open
+		}
+		parts[i], errs[i] = s.ensureCached(ctx, part)
+	})
+	res.streamed = parts[0].streamed
+
+	counts := &sentenceCounts{Total: len(sentences)}
+	for i, err := range errs {
+		if err != nil {
+			return res, fmt.Errorf("sentence %d: %w", i+1, err)
+		}
+		if parts[i].status != "cache_miss" {
+			counts.Cached++
+		}
+	}
+
+	unlock, err := s.cache.LockKey(ctx, res.key)
+	if err != nil {
+		s.logger.Printf("ERROR: lock cache key failed: %v", err)
+		return res, err
+	}
+	defer unlock()
+
+	if _, err := os.Stat(res.path); err == nil {
+		// Another request built the composite while this one held its sentences.
+		res.status = "cache_hit"
+		return res, nil
+	}
+
+	gap := s.cfg.SentenceGap
+	if job.params.SentenceSilence != nil {
+		// The engine already ended each sentence with the requested silence.
+		gap = 0
+	}
+	paths := make([]string, len(parts))
+	gaps := make([]time.Duration, len(parts))
+	for i, p := range parts {
+		paths[i] = p.path
+		if i < len(parts)-1 {
+			gaps[i] = gap
+		}
+	}
+	if err := joinWavs(paths, gaps, res.path); err != nil {
+		s.logger.Printf("ERROR: build composite wav failed: %v", err)
+		return res, err
+	}
+	s.commit(voice, job.params, res.key, job.text, res.path)
+
+	res.sentences = counts
+	switch counts.Cached {
+	case 0:
+		res.status = "cache_miss"
+	default:
+		res.status = "partial_hit"
+	}
+	return res, nil
+}
+
+// writeRest sends the rest of a composite to the sink its first unit streamed
+// to.
+func (s *Server) writeRest(held *heldSink, key string, pcm []byte) {
+	if _, err := held.out.Write(pcm); err != nil {
+		s.logger.Printf("ERROR: stream rest of key=%s failed: %v", key, err)
+	}
+}
+
+// heldSink passes a composite's first unit to a job's sink and keeps the sink
+// open, so the rest of the composite can follow once it is joined.
+type heldSink struct {
+	sink pcmSink
+	out  io.WriteCloser
+	n    int
+}
+
+// open is the first unit's sink.
+func (h *heldSink) open(sampleRate int) (io.WriteCloser, error) {
+	out, err := h.sink(sampleRate)
+	if err != nil {
+		return nil, err
+	}
+	h.out = out
+	return h, nil
+}
+
+func (h *heldSink) Write(p []byte) (int, error) {
+	n, err := h.out.Write(p)
+	h.n += n
+	return n, err
+}
+
+// Close leaves the sink open for the rest of the composite.
+func (h *heldSink) Close() error { return nil }
+
+// rest reads the PCM of the composite wav at path that follows what the
+// first unit already wrote.
+func (h *heldSink) rest(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	_, pcm, err := audio.ReadWAV(f)
+	if err != nil || h.n >= len(pcm) {
+		return nil, err
+	}
+	return pcm[h.n:], nil
+}
+
+// close releases the sink. Closing may block until playback drains, so it
+// does not hold the request.
+func (h *heldSink) close() {
+	if h.out != nil {
+		go h.out.Close()
+	}
+}
@@ -4,22 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
-	"time"
+	"sync"
+	"sync/atomic"
 
+	"github.com/venkytv/tts-cached/internal/breaker"
 	"github.com/venkytv/tts-cached/internal/cache"
 	"github.com/venkytv/tts-cached/internal/config"
+	"github.com/venkytv/tts-cached/internal/engine"
+	"github.com/venkytv/tts-cached/internal/lexicon"
+	"github.com/venkytv/tts-cached/internal/normalize"
+	"github.com/venkytv/tts-cached/internal/peer"
+	"github.com/venkytv/tts-cached/internal/redact"
+	"github.com/venkytv/tts-cached/internal/scheduler"
+	"github.com/venkytv/tts-cached/internal/stats"
+	"github.com/venkytv/tts-cached/internal/textfilter"
+	"github.com/venkytv/tts-cached/internal/unicodetext"
+	"github.com/venkytv/tts-cached/internal/warmup"
 )
 
-// Piper synthesizes text to an output wav file path.
-type Piper interface {
-	Synthesize(ctx context.Context, text, outPath string) error
-}
-
 // Player handles wav playback.
 type Player interface {
 	PlayWav(path string)
@@ -27,42 +36,105 @@ type Player interface {
 
 // Server bundles HTTP handlers for the TTS cache service.
 type Server struct {
-	cfg    config.Config
-	cache  cache.Manager
-	piper  Piper
-	player Player
-	logger *log.Logger
+	cfg      config.Config
+	cache    cache.Manager
+	engines  *engine.Registry
+	player   Player
+	peers    *peer.Client
+	sched    *scheduler.Scheduler
+	stats    *stats.Recorder
+	norm     normalize.Pipeline
+	lexicon  atomic.Pointer[lexicon.Lexicon]
+	filter   *textfilter.Filter
+	redactor *redact.Redactor
+	logger   *log.Logger
+
+	breakersMu sync.Mutex
+	breakers   map[string]*breaker.Breaker
+
+	warmup   warmup.Progress
+	bgCtx    context.Context
+	bgCancel context.CancelFunc
 }
 
-// New constructs a server with dependencies.
-func New(cfg config.Config, cacheMgr cache.Manager, piper Piper, player Player, logger *log.Logger) *Server {
+// New constructs a server with dependencies. engines resolves request voices
+// to synthesis backends.
+func New(cfg config.Config, cacheMgr cache.Manager, engines *engine.Registry, player Player, logger *log.Logger) *Server {
 	if logger == nil {
 		logger = log.Default()
 	}
-	return &Server{
-		cfg:    cfg,
-		cache:  cacheMgr,
-		piper:  piper,
-		player: player,
-		logger: logger,
+	rec, err := stats.Load(statsPath(cfg))
+	if err != nil {
+		logger.Printf("ERROR: load cache stats failed: %v", err)
 	}
+	bgCtx, bgCancel := context.WithCancel(context.Background())
+	s := &Server{
+		cfg:      cfg,
+		cache:    cacheMgr.WithEvictHook(rec.RecordEviction),
+		engines:  engines,
+		player:   player,
+		peers:    peer.NewClient(cfg.Peers, cfg.PeerTimeout, cfg.PeerMaxBytes, logger),
+		breakers: make(map[string]*breaker.Breaker),
+		sched:    scheduler.New(cfg.SynthConcurrency, cfg.SynthQueue),
+		stats:    rec,
+		norm:     normalize.New(),
+		logger:   logger,
+		bgCtx:    bgCtx,
+		bgCancel: bgCancel,
+	}
+	if cfg.FilterCmd != "" {
+		s.filter = textfilter.New(cfg.FilterCmd, cfg.FilterArgs, cfg.FilterTimeout, logger)
+	}
+	go s.persistStats()
+	return s
+}
+
+// Close stops background work such as warmups started via StartWarmup and
+// persists cache statistics.
+func (s *Server) Close() {
+	s.bgCancel()
+	s.saveStats()
 }
 
 // Handler returns an http.Handler with registered routes.
 func (s *Server) Handler() http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/tts", s.handleTTS)
+	mux.HandleFunc("/tts/stream", s.handleTTSStream)
+	mux.HandleFunc("/voices", s.handleVoices)
+	mux.HandleFunc("/normalize", s.handleNormalize)
+	mux.HandleFunc("/warmup", s.handleWarmup)
+	mux.HandleFunc("/cache/stats", s.handleCacheStats)
+	mux.HandleFunc("/cache/", s.handleCacheFile)
+	mux.HandleFunc("/status", s.handleStatus)
 	mux.HandleFunc("/healthz", s.handleHealth)
 	return mux
 }
 
 type ttsRequest struct {
-	Text string `json:"text"`
+	Text  string `json:"text"`
+	Voice string `json:"voice,omitempty"`
+	// Lang selects the voice for a language when Voice is empty.
+	Lang string `json:"lang,omitempty"`
+	// Segments, when set, replace Text with an ordered multi-voice script.
+	Segments []dialogueSegment `json:"segments,omitempty"`
+	// SSML is an alternative to Text and Segments; see segment.ParseSSML.
+	SSML string `json:"ssml,omitempty"`
+	// Format of Text and segment texts: plain (the default), markdown or html.
+	Format string `json:"format,omitempty"`
+	// Optional synthesis settings (speaker, length_scale, ...).
+	engine.Params
 }
 
 type ttsResponse struct {
 	Status string `json:"status"`
 	File   string `json:"file"`
+	// Voice names the voice chosen by language when the request named none.
+	Voice string `json:"voice,omitempty"`
+	// Fallback names the voice that produced the audio when the requested one failed.
+	Fallback  string          `json:"fallback,omitempty"`
+	Sentences *sentenceCounts `json:"sentences,omitempty"`
+	Segments  *sentenceCounts `json:"segments,omitempty"`
 }
 
 func (s *Server) handleTTS(w http.ResponseWriter, r *http.Request) {
@@ -72,49 +144,347 @@ func (s *Server) handleTTS(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req ttsRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid json", http.StatusBadRequest)
+	if !s.decodeRequest(w, r, &req) {
+		return
+	}
+	if err := extractText(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if parts, ok, err := s.dialogueRequest(r, req); ok || err != nil {
+		if err != nil {
+			s.writeRequestError(w, err)
+			return
+		}
+		res, err := s.ensureDialogue(r.Context(), parts, req.Params)
+		if err != nil {
+			s.writeSynthError(w, err)
+			return
+		}
+		s.recordPlay(res, dialogueText(parts))
+		go s.player.PlayWav(res.path)
+		s.logger.Printf("INFO: /tts dialogue %s key=%s segments=%d", res.status, res.key, len(parts))
+		s.writeJSON(w, http.StatusOK, ttsResponse{Status: res.status, File: filepath.Base(res.path), Fallback: res.fallback, Segments: res.segments})
 		return
 	}
 
-	normalized := strings.Join(strings.Fields(req.Text), " ")
+	normalized := s.redact(normalizeText(req.Text))
 	if normalized == "" {
 		http.Error(w, "text is required", http.StatusBadRequest)
 		return
 	}
+	if err := s.checkLength(normalized); err != nil {
+		s.writeRequestError(w, err)
+		return
+	}
 
-	key := cache.BuildKey(s.cfg.VoiceID, normalized)
-	filename := key + ".wav"
-	wavPath := s.cache.PathForKey(key)
+	voice, reason, err := s.selectVoice(r, req, normalized)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if reason != "" {
+		s.logger.Printf("INFO: /tts selected voice %s (%s)", voice, reason)
+	}
 
-	if _, err := os.Stat(wavPath); err == nil {
-		s.cache.Touch(wavPath)
-		go s.player.PlayWav(wavPath)
-		s.logger.Printf("INFO: /tts cache_hit key=%s file=%s", key, filename)
-		s.writeJSON(w, http.StatusOK, ttsResponse{Status: "cache_hit", File: filename})
+	text, err := s.prepare(r.Context(), normalized, voice)
+	if err != nil {
+		s.writeRequestError(w, err)
 		return
-	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
-		s.logger.Printf("ERROR: stat cache file failed: %v", err)
-		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+
+	res, err := s.ensureCached(r.Context(), synthJob{text: text, voice: voice, params: req.Params, sink: s.playbackSink(), detach: true})
+	if err != nil {
+		s.writeSynthError(w, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	s.recordPlay(res, normalized)
+
+	filename := filepath.Base(res.path)
+	if !res.streamed {
+		go s.player.PlayWav(res.path)
+	}
+	s.logger.Printf("INFO: /tts %s key=%s file=%s", res.status, res.key, filename)
+	resp := ttsResponse{Status: res.status, File: filename, Fallback: res.fallback, Sentences: res.sentences}
+	if reason != "" {
+		resp.Voice = voice
+	}
+	s.writeJSON(w, http.StatusOK, resp)
+}
+
+// synthJob describes a cache entry to produce.
+type synthJob struct {
+	text string
+	// voice names a configured voice; empty selects the default voice.
+	voice string
+	// params are per-request synthesis settings, validated against the voice.
+	params engine.Params
+	// background jobs (warmup, and the parts of an admitted request) wait for
+	// a synthesis slot instead of being rejected, outside the queue bound.
+	background bool
+	// sink, when set, receives PCM while the engine runs if it can stream.
+	sink pcmSink
+	// detach lets the rest of a split text reach sink after ensureCached
+	// returns, for sinks not tied to the request such as playback.
+	detach bool
+	// single disables splitting into sentences or units (the job is already one).
+	single bool
+	// exact disables the voice's fallback chain.
+	exact bool
+}
+
+// synthResult describes where a cached wav came from.
+type synthResult struct {
+	key    string
+	path   string
+	status string
+	// streamed is set when the audio was already delivered to the job's sink.
+	streamed bool
+	// sentences is set when the wav was assembled from per-sentence entries.
+	sentences *sentenceCounts
+	// segments is set for dialogue composites.
+	segments *sentenceCounts
+	// fallback names the voice used when the requested voice's engine failed.
+	fallback string
+}
+
+// ensureCached makes sure the wav for text exists in the cache, walking the
+// voice's fallback chain when its engine fails. Fallback output is cached
+// under the fallback voice's key. Errors are logged here.
+func (s *Server) ensureCached(ctx context.Context, job synthJob) (synthResult, error) {
+	chain, err := s.engines.Chain(job.voice)
+	if err != nil {
+		return synthResult{}, err
+	}
+	if job.exact {
+		chain = chain[:1]
+	}
+	if err := chain[0].Voice.Validate(job.params, chain[0].Engine.Capabilities()); err != nil {
+		return synthResult{}, err
+	}
+
+	var res synthResult
+	for i, link := range chain {
+		vjob := job
+		caps := link.Engine.Capabilities()
+		// A speaker's name and numeric id share one cache entry.
+		vjob.params = job.params.Canonical(caps)
+		if i > 0 {
+			s.logger.Printf("INFO: falling back from voice %s to %s: %v", chain[i-1].Voice.Name, link.Voice.Name, err)
+			// Fallback voices drop settings they cannot honour rather than fail.
+			if link.Voice.Validate(job.params, caps) != nil {
+				vjob.params = engine.Params{}
+			}
+		}
+		res, err = s.ensureVoice(ctx, vjob, link.Voice, link.Engine)
+		if err == nil {
+			if i > 0 {
+				res.fallback = link.Voice.Name
+			}
+			return res, nil
+		}
+		// Give up when the caller is gone, the queue pushes back, or audio
+		// from this attempt already reached the listener.
+		if ctx.Err() != nil || errors.Is(err, scheduler.ErrQueueFull) || res.streamed {
+			return res, err
+		}
+	}
+	return res, err
+}
+
+// ensureVoice makes sure the wav for text in one voice exists in the cache,
+// checking the local cache, then peers, then running the voice's engine.
+func (s *Server) ensureVoice(ctx context.Context, job synthJob, voice engine.Voice, eng engine.Engine) (synthResult, error) {
+	text := job.text
+	params := job.params
+	if params.Speaker == eng.Capabilities().CanonicalSpeaker(voice.Speaker) {
+		// The voice's own speaker is implied by its name.
+		params.Speaker = ""
+	}
+	job.params = params
+	key := cache.BuildKey(voice.Name, text, params.Key())
+	res := synthResult{key: key, path: s.cache.PathForKey(key)}
+
+	if _, err := os.Stat(res.path); err == nil {
+		s.cache.Touch(res.path)
+		res.status = "cache_hit"
+		return res, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		s.logger.Printf("ERROR: stat cache file failed: %v", err)
+		return res, err
+	}
+
+	if !job.single {
+		if units := s.units(text); len(units) > 1 {
+			return s.ensureComposite(ctx, job, voice, res, units)
+		}
+	}
+
+	// Hold the per-key lock while producing the entry so concurrent requests (or
+	// another daemon sharing the cache directory) synthesize it only once.
+	unlock, err := s.cache.LockKey(ctx, key)
+	if err != nil {
+		s.logger.Printf("ERROR: lock cache key failed: %v", err)
+		return res, err
+	}
+	defer unlock()
+
+	if _, err := os.Stat(res.path); err == nil {
+		res.status = "cache_hit"
+		return res, nil
+	}
+
+	if s.peers.Enabled() {
+		if from, err := s.peers.Fetch(ctx, key, res.path); err == nil {
+			s.logger.Printf("INFO: fetched key=%s from peer=%s", key, from)
+			s.commit(voice, params, key, text, res.path)
+			res.status = "peer_hit"
+			return res, nil
+		}
+	}
+
+	br := s.breakerFor(voice.Engine)
+	if !br.Allow() {
+		s.logger.Printf("INFO: engine %s unavailable (circuit open), skipping key=%s", voice.Engine, key)
+		return res, fmt.Errorf("engine %s: %w", voice.Engine, breaker.ErrOpen)
+	}
+
+	acquire := s.sched.Acquire
+	if job.background {
+		acquire = s.sched.Wait
+	}
+	release, err := acquire(ctx)
+	if err != nil {
+		br.Release()
+		if errors.Is(err, scheduler.ErrQueueFull) {
+			s.logger.Printf("INFO: synthesis queue full, rejecting key=%s", key)
+		}
+		return res, err
+	}
+	defer release()
+
+	synthCtx, cancel := ctx, context.CancelFunc(func() {})
+	if s.cfg.SynthTimeout > 0 {
+		synthCtx, cancel = context.WithTimeout(ctx, s.cfg.SynthTimeout)
+	}
 	defer cancel()
 
-	if err := s.piper.Synthesize(ctx, normalized, wavPath); err != nil {
-		s.logger.Printf("ERROR: piper synth failed: %v", err)
-		http.Error(w, "internal error", http.StatusInternalServerError)
+	req := engine.Request{Text: text, Voice: voice.EngineVoice, Params: params}
+	if req.Params.Speaker == "" {
+		req.Params.Speaker = voice.Speaker
+	}
+	caps := eng.Capabilities()
+	if st, ok := eng.(engine.Streamer); ok && caps.Streaming && job.sink != nil {
+		res.streamed, err = s.synthesizeStreaming(synthCtx, st, req, caps.SampleRate, res.path, job.sink)
+	} else if err = eng.Synthesize(synthCtx, req, res.path); err != nil {
+		s.logger.Printf("ERROR: %s synth failed: %v", voice.Engine, err)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			br.Release()
+		} else {
+			br.Failure(err)
+		}
+		return res, err
+	}
+	br.Success()
+
+	s.commit(voice, params, key, text, res.path)
+	res.status = "cache_miss"
+	return res, nil
+}
+
+// breakerFor returns the circuit breaker guarding the named engine.
+func (s *Server) breakerFor(name string) *breaker.Breaker {
+	s.breakersMu.Lock()
+	defer s.breakersMu.Unlock()
+	b, ok := s.breakers[name]
+	if !ok {
+		b = breaker.New(s.cfg.BreakerThreshold, s.cfg.BreakerProbeInterval)
+		s.breakers[name] = b
+	}
+	return b
+}
+
+// writeSynthError maps ensureCached failures onto HTTP responses.
+func (s *Server) writeSynthError(w http.ResponseWriter, err error) {
+	if errors.Is(err, engine.ErrUnknownVoice) || errors.Is(err, engine.ErrInvalidParams) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, breaker.ErrOpen) {
+		http.Error(w, "synthesis engine unavailable", http.StatusServiceUnavailable)
 		return
 	}
+	if errors.Is(err, scheduler.ErrQueueFull) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(s.sched.RetryAfter().Seconds())))
+		http.Error(w, "synthesis queue full", http.StatusServiceUnavailable)
+		return
+	}
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
 
+// commit records metadata for a newly stored wav and enforces the cache limit.
+func (s *Server) commit(voice engine.Voice, params engine.Params, key, text, wavPath string) {
+	s.writeMeta(voice, params, key, text, wavPath)
 	if err := s.cache.EnforceLimit(); err != nil {
 		s.logger.Printf("ERROR: enforce cache limit failed: %v", err)
 	}
+}
+
+// normalizeText canonicalizes Unicode and collapses whitespace so equivalent
+// texts share a cache key.
+func normalizeText(text string) string {
+	return strings.Join(strings.Fields(unicodetext.Clean(text)), " ")
+}
 
-	go s.player.PlayWav(wavPath)
-	s.logger.Printf("INFO: /tts cache_miss key=%s file=%s", key, filepath.Base(wavPath))
-	s.writeJSON(w, http.StatusOK, ttsResponse{Status: "cache_miss", File: filename})
+// writeMeta records the sidecar used by cache export/import; best-effort.
+func (s *Server) writeMeta(voice engine.Voice, params engine.Params, key, text, wavPath string) {
+	meta := cache.Meta{Key: key, Text: text, Voice: voice.Name, Model: voice.Fingerprint, Params: params.Key()}
+	if info, err := os.Stat(wavPath); err == nil {
+		meta.Size = info.Size()
+	}
+	if err := s.cache.WriteMeta(meta); err != nil {
+		s.logger.Printf("ERROR: write cache metadata failed: %v", err)
+	}
+}
+
+// handleCacheFile serves a locally cached wav by key so peers can reuse it.
+func (s *Server) handleCacheFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/cache/")
+	key := strings.TrimSuffix(name, ".wav")
+	if key == name || !cache.ValidKey(key) {
+		http.NotFound(w, r)
+		return
+	}
+
+	f, err := os.Open(s.cache.PathForKey(key))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			s.logger.Printf("ERROR: open cache file failed: %v", err)
+		}
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		s.logger.Printf("ERROR: stat cache file failed: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.Printf("INFO: /cache serving key=%s to %s", key, r.RemoteAddr)
+	w.Header().Set("Content-Type", "audio/wav")
+	http.ServeContent(w, r, name, info.ModTime(), f)
 }
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
@@ -129,6 +499,27 @@ func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write([]byte("OK\n"))
 }
 
+type statusResponse struct {
+	Synthesis scheduler.Stats          `json:"synthesis"`
+	Engines   map[string]breaker.Stats `json:"engines"`
+	Warmup    warmup.Snapshot          `json:"warmup"`
+}
+
+// handleStatus reports synthesis load, engine circuit breakers and warmup progress.
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	engines := make(map[string]breaker.Stats)
+	s.breakersMu.Lock()
+	for name, b := range s.breakers {
+		engines[name] = b.Stats()
+	}
+	s.breakersMu.Unlock()
+	s.writeJSON(w, http.StatusOK, statusResponse{Synthesis: s.sched.Stats(), Engines: engines, Warmup: s.warmup.Snapshot()})
+}
+
 func (s *Server) writeJSON(w http.ResponseWriter, status int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
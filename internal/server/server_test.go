@@ -3,18 +3,31 @@ package server
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"io"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/venkytv/tts-cached/internal/audio"
+	"github.com/venkytv/tts-cached/internal/breaker"
 	"github.com/venkytv/tts-cached/internal/cache"
 	"github.com/venkytv/tts-cached/internal/config"
+	"github.com/venkytv/tts-cached/internal/engine"
+	"github.com/venkytv/tts-cached/internal/redact"
+	"github.com/venkytv/tts-cached/internal/unicodetext"
+	"github.com/venkytv/tts-cached/internal/warmup"
 )
 
 func TestHandleTTSSynthAndCache(t *testing.T) {
@@ -26,8 +39,7 @@ func TestHandleTTSSynthAndCache(t *testing.T) {
 		VoiceID:  "default",
 		CacheDir: dir,
 	}
-	mgr := cache.NewManager(dir, 1024*1024, logDiscard)
-	srv := New(cfg, mgr, fp, player, logDiscard)
+	srv := newTestServer(t, cfg, single(fp), player)
 
 	body := bytes.NewBufferString(`{"text":"  hello   world "}`)
 	req := httptest.NewRequest(http.MethodPost, "/tts", body)
@@ -75,8 +87,7 @@ func TestHandleTTSCacheHit(t *testing.T) {
 		VoiceID:  "default",
 		CacheDir: dir,
 	}
-	mgr := cache.NewManager(dir, 1024*1024, logDiscard)
-	srv := New(cfg, mgr, fp, player, logDiscard)
+	srv := newTestServer(t, cfg, single(fp), player)
 
 	key := cache.BuildKey("default", "hello world")
 	wav := filepath.Join(dir, key+".wav")
@@ -120,15 +131,853 @@ func TestHandleTTSCacheHit(t *testing.T) {
 	}
 }
 
+func TestHandleTTSPeerHit(t *testing.T) {
+	key := cache.BuildKey("default", "hello world")
+	wavData := []byte("RIFF\x00\x00\x00\x00WAVEdata")
+
+	peerDir := t.TempDir()
+	peerMgr := cache.NewManager(peerDir, 1024*1024, logDiscard)
+	if err := os.WriteFile(peerMgr.PathForKey(key), wavData, 0o644); err != nil {
+		t.Fatalf("write peer wav: %v", err)
+	}
+	peerSrv := newTestServer(t, config.Config{VoiceID: "default", CacheDir: peerDir}, single(&fakePiper{}), &fakePlayer{ch: make(chan string, 1)})
+	peerHTTP := httptest.NewServer(peerSrv.Handler())
+	defer peerHTTP.Close()
+
+	dir := t.TempDir()
+	fp := &fakePiper{}
+	player := &fakePlayer{ch: make(chan string, 1)}
+	cfg := config.Config{
+		VoiceID:      "default",
+		CacheDir:     dir,
+		Peers:        []string{peerHTTP.URL},
+		PeerTimeout:  time.Second,
+		PeerMaxBytes: 1024,
+	}
+	srv := newTestServer(t, cfg, single(fp), player)
+
+	req := httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(`{"text":"hello world"}`))
+	rec := httptest.NewRecorder()
+	srv.handleTTS(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp ttsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.Status != "peer_hit" {
+		t.Fatalf("expected peer_hit, got %s", resp.Status)
+	}
+	if fp.calls != 0 {
+		t.Fatalf("piper should not be called on peer hit")
+	}
+	got, err := os.ReadFile(filepath.Join(dir, key+".wav"))
+	if err != nil || !bytes.Equal(got, wavData) {
+		t.Fatalf("peer wav not stored locally: %v", err)
+	}
+	<-player.ch
+}
+
+func TestHandleCacheFileRejectsBadKeys(t *testing.T) {
+	dir := t.TempDir()
+	srv := newTestServer(t, config.Config{VoiceID: "default", CacheDir: dir, CacheMaxBytes: 1024}, single(&fakePiper{}), &fakePlayer{})
+
+	for _, path := range []string{"/cache/" + strings.Repeat("z", 64) + ".wav", "/cache/abc.wav", "/cache/" + cache.BuildKey("default", "x")} {
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("%s: expected 404, got %d", path, rec.Code)
+		}
+	}
+}
+
+func TestHandleTTSSelectsVoiceEngine(t *testing.T) {
+	dir := t.TempDir()
+	piper, espeak := &fakePiper{}, &fakePiper{}
+	reg := single(piper)
+	reg.AddEngine("espeak", espeak)
+	if err := reg.AddVoice(engine.Voice{Name: "robot", Engine: "espeak", EngineVoice: "en-us"}); err != nil {
+		t.Fatalf("add voice: %v", err)
+	}
+	srv := newTestServer(t, config.Config{VoiceID: "default", CacheDir: dir}, reg, &fakePlayer{ch: make(chan string, 2)})
+
+	for _, body := range []string{`{"text":"hi"}`, `{"text":"hi","voice":"robot"}`} {
+		rec := httptest.NewRecorder()
+		srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(body)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", body, rec.Code)
+		}
+	}
+	if piper.count() != 1 || espeak.count() != 1 {
+		t.Fatalf("expected one synthesis per engine, got %d/%d", piper.count(), espeak.count())
+	}
+	if _, err := os.Stat(filepath.Join(dir, cache.BuildKey("robot", "hi")+".wav")); err != nil {
+		t.Fatalf("robot entry missing: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(`{"text":"hi","voice":"nope"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown voice: expected 400, got %d", rec.Code)
+	}
+}
+
+func TestFallbackChainAndCircuitBreaker(t *testing.T) {
+	dir := t.TempDir()
+	broken, espeak := &failingEngine{}, &fakePiper{}
+	reg := engine.NewRegistry("default")
+	reg.AddEngine("piper", broken)
+	reg.AddEngine("espeak", espeak)
+	for _, v := range []engine.Voice{
+		{Name: "default", Engine: "piper", Fallback: []string{"robot"}},
+		{Name: "robot", Engine: "espeak"},
+	} {
+		if err := reg.AddVoice(v); err != nil {
+			t.Fatalf("add voice: %v", err)
+		}
+	}
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, BreakerThreshold: 2, BreakerProbeInterval: time.Hour}
+	srv := newTestServer(t, cfg, reg, &fakePlayer{ch: make(chan string, 4)})
+
+	for i, text := range []string{"one", "two", "three"} {
+		rec := httptest.NewRecorder()
+		srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(`{"text":"`+text+`"}`)))
+		var resp ttsResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+			t.Fatalf("%s: unexpected response %d %s", text, rec.Code, rec.Body.String())
+		}
+		if resp.Fallback != "robot" || resp.File != cache.BuildKey("robot", text)+".wav" {
+			t.Fatalf("%d: expected fallback to robot, got %+v", i, resp)
+		}
+		if _, err := os.Stat(filepath.Join(dir, cache.BuildKey("default", text)+".wav")); !os.IsNotExist(err) {
+			t.Fatalf("fallback audio cached under the primary key")
+		}
+	}
+	if n := broken.count(); n != 2 {
+		t.Fatalf("open breaker should stop calls to the broken engine, got %d calls", n)
+	}
+
+	statusRec := httptest.NewRecorder()
+	srv.handleStatus(statusRec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	var status statusResponse
+	if err := json.Unmarshal(statusRec.Body.Bytes(), &status); err != nil {
+		t.Fatalf("unmarshal status: %v", err)
+	}
+	if st := status.Engines["piper"]; st.State != breaker.StateOpen || st.Trips != 1 {
+		t.Fatalf("unexpected piper breaker %+v", st)
+	}
+
+	// Without a fallback the open breaker surfaces as 503.
+	_, err := srv.ensureCached(context.Background(), synthJob{text: "four", exact: true})
+	if !errors.Is(err, breaker.ErrOpen) {
+		t.Fatalf("expected breaker error without fallback, got %v", err)
+	}
+	rec := httptest.NewRecorder()
+	srv.writeSynthError(rec, err)
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+}
+
+type failingEngine struct {
+	fakePiper
+}
+
+func (f *failingEngine) Synthesize(context.Context, engine.Request, string) error {
+	f.mu.Lock()
+	f.calls++
+	f.mu.Unlock()
+	return errors.New("model load failed")
+}
+
+func TestHandleTTSSynthesisParams(t *testing.T) {
+	dir := t.TempDir()
+	pe := &paramEngine{}
+	reg := engine.NewRegistry("default")
+	reg.AddEngine("piper", pe)
+	voice := engine.Voice{Name: "default", Engine: "piper", Speakers: []string{"0", "1"}, Ranges: map[string]engine.Range{engine.ParamLengthScale: {Min: 0.5, Max: 2}}}
+	if err := reg.AddVoice(voice); err != nil {
+		t.Fatalf("add voice: %v", err)
+	}
+	srv := newTestServer(t, config.Config{VoiceID: "default", CacheDir: dir}, reg, &fakePlayer{ch: make(chan string, 4)})
+
+	resp := postTTSOK(t, srv, `{"text":"hi","length_scale":1.5,"speaker":"1"}`)
+	want := cache.BuildKey("default", "hi", "length_scale=1.5;speaker=1") + ".wav"
+	if resp.Status != "cache_miss" || resp.File != want {
+		t.Fatalf("unexpected response %+v", resp)
+	}
+	if got := pe.last(); got.Speaker != "1" || got.LengthScale == nil || *got.LengthScale != 1.5 {
+		t.Fatalf("engine got params %+v", got)
+	}
+	if resp = postTTSOK(t, srv, `{"text":"hi","speaker":"1","length_scale":1.5}`); resp.Status != "cache_hit" {
+		t.Fatalf("expected cache_hit for same params, got %+v", resp)
+	}
+	if resp = postTTSOK(t, srv, `{"text":"hi"}`); resp.Status != "cache_miss" || resp.File != cache.BuildKey("default", "hi")+".wav" {
+		t.Fatalf("expected separate entry without params, got %+v", resp)
+	}
+
+	for _, body := range []string{
+		`{"text":"hi","length_scale":3}`,
+		`{"text":"hi","speaker":"7"}`,
+		`{"text":"hi","noise_w":0.5}`,
+	} {
+		if rec := postTTS(t, srv, body); rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", body, rec.Code)
+		}
+	}
+	if pe.count() != 2 {
+		t.Fatalf("expected 2 syntheses, got %d", pe.count())
+	}
+}
+
+// paramEngine supports speaker and length_scale and records the last request's params.
+type paramEngine struct {
+	fakePiper
+	params engine.Params
+}
+
+func (p *paramEngine) Capabilities() engine.Capabilities {
+	return engine.Capabilities{SampleRate: 22050, Params: []string{engine.ParamSpeaker, engine.ParamLengthScale}}
+}
+
+func (p *paramEngine) Synthesize(ctx context.Context, req engine.Request, outPath string) error {
+	p.mu.Lock()
+	p.params = req.Params
+	p.mu.Unlock()
+	return p.fakePiper.Synthesize(ctx, req, outPath)
+}
+
+func (p *paramEngine) last() engine.Params {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.params
+}
+
+// namedSpeakerEngine lists speakers by name and accepts their numeric ids.
+type namedSpeakerEngine struct{ paramEngine }
+
+func (p *namedSpeakerEngine) Capabilities() engine.Capabilities {
+	caps := p.paramEngine.Capabilities()
+	caps.Speakers = []string{"alice", "bob"}
+	caps.SpeakerAliases = map[string]string{"0": "alice", "1": "bob"}
+	return caps
+}
+
+func TestSpeakerIDsShareCacheEntryWithNames(t *testing.T) {
+	dir := t.TempDir()
+	pe := &namedSpeakerEngine{}
+	srv := newTestServer(t, config.Config{VoiceID: "default", CacheDir: dir}, single(pe), &fakePlayer{ch: make(chan string, 4)})
+
+	first := postTTSOK(t, srv, `{"text":"hi","speaker":"1"}`)
+	if first.Status != "cache_miss" || first.File != cache.BuildKey("default", "hi", "speaker=bob")+".wav" {
+		t.Fatalf("numeric speaker id: %+v", first)
+	}
+	if got := pe.last(); got.Speaker != "bob" {
+		t.Fatalf("engine got speaker %q, want bob", got.Speaker)
+	}
+	if second := postTTSOK(t, srv, `{"text":"hi","speaker":"bob"}`); second.Status != "cache_hit" || second.File != first.File {
+		t.Fatalf("speaker name: %+v", second)
+	}
+	if rec := postTTS(t, srv, `{"text":"hi","speaker":"2"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("unknown speaker id: expected 400, got %d", rec.Code)
+	}
+}
+
+func TestHandleVoicesListsCapabilities(t *testing.T) {
+	dir := t.TempDir()
+	reg := single(&paramEngine{})
+	reg.AddEngine("espeak", &fakePiper{})
+	if err := reg.AddVoice(engine.Voice{Name: "robot", Engine: "espeak", Language: "en"}); err != nil {
+		t.Fatalf("add voice: %v", err)
+	}
+	srv := newTestServer(t, config.Config{VoiceID: "default", CacheDir: dir}, reg, &fakePlayer{})
+
+	rec := httptest.NewRecorder()
+	srv.handleVoices(rec, httptest.NewRequest(http.MethodGet, "/voices", nil))
+	var voices []voiceInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &voices); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+	if len(voices) != 2 || voices[0].Name != "default" || !voices[0].Default || voices[1].Name != "robot" || voices[1].Language != "en" {
+		t.Fatalf("unexpected voices %+v", voices)
+	}
+	if voices[0].SampleRate != 22050 || len(voices[0].Params) != 2 {
+		t.Fatalf("engine capabilities missing: %+v", voices[0])
+	}
+}
+
+func TestHandleTTSSelectsVoiceByLanguage(t *testing.T) {
+	dir := t.TempDir()
+	reg := engine.NewRegistry("amy")
+	reg.AddEngine("test", &fakePiper{})
+	for _, v := range []engine.Voice{
+		{Name: "amy", Engine: "test", Language: "en_US"},
+		{Name: "thorsten", Engine: "test", Language: "de_DE"},
+		{Name: "priyamvada", Engine: "test", Language: "hi"},
+	} {
+		if err := reg.AddVoice(v); err != nil {
+			t.Fatalf("add voice: %v", err)
+		}
+	}
+	cfg := config.Config{VoiceID: "amy", CacheDir: dir, LangDetect: true}
+	srv := newTestServer(t, cfg, reg, &fakePlayer{ch: make(chan string, 8)})
+
+	cases := []struct {
+		body, accept, want string
+	}{
+		{`{"text":"The front door is open"}`, "", "amy"},
+		{`{"text":"Die Haustür ist offen, bitte schließen"}`, "", "thorsten"},
+		{`{"text":"मुख्य दरवाज़ा खुला है"}`, "", "priyamvada"},
+		{`{"text":"The front door is open"}`, "fr;q=0.9, de-DE;q=0.8", "thorsten"},
+		{`{"text":"Die Haustür ist offen","lang":"en-GB"}`, "de", "amy"},
+		{`{"text":"Die Haustür ist offen","voice":"priyamvada","lang":"en"}`, "", ""},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(c.body))
+		if c.accept != "" {
+			req.Header.Set("Accept-Language", c.accept)
+		}
+		rec := httptest.NewRecorder()
+		srv.handleTTS(rec, req)
+		var resp ttsResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+			t.Fatalf("%s: unexpected response %d %s", c.body, rec.Code, rec.Body.String())
+		}
+		if resp.Voice != c.want {
+			t.Fatalf("%s (Accept-Language %q): voice %q, want %q", c.body, c.accept, resp.Voice, c.want)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(`{"text":"hola","lang":"es"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("lang without voice: expected 400, got %d", rec.Code)
+	}
+}
+
+func TestNormalizedTextSharesCacheEntry(t *testing.T) {
+	dir := t.TempDir()
+	piper := &fakePiper{}
+	reg := engine.NewRegistry("amy")
+	reg.AddEngine("test", piper)
+	if err := reg.AddVoice(engine.Voice{Name: "amy", Engine: "test", Language: "en_US"}); err != nil {
+		t.Fatalf("add voice: %v", err)
+	}
+	srv := newTestServer(t, config.Config{VoiceID: "amy", CacheDir: dir}, reg, &fakePlayer{ch: make(chan string, 8)})
+
+	var files []string
+	for _, body := range []string{`{"text":"Pay $5 at 3:30"}`, `{"text":"Pay five dollars at three thirty"}`} {
+		rec := httptest.NewRecorder()
+		srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(body)))
+		var resp ttsResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+			t.Fatalf("%s: unexpected response %d %s", body, rec.Code, rec.Body.String())
+		}
+		files = append(files, resp.File)
+	}
+	if files[0] != files[1] || piper.count() != 1 {
+		t.Fatalf("expected one cache entry, got %v after %d syntheses", files, piper.count())
+	}
+
+	rec := httptest.NewRecorder()
+	srv.handleNormalize(rec, httptest.NewRequest(http.MethodGet, "/normalize?text=Pay+%245+at+3:30", nil))
+	var resp normalizeResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+	if resp.Voice != "amy" || resp.Language != "en" || resp.Text != "Pay five dollars at three thirty" || len(resp.Steps) != 2 {
+		t.Fatalf("unexpected normalization %+v", resp)
+	}
+}
+
+func TestLexiconRewritesOnlyAffectedTexts(t *testing.T) {
+	dir := t.TempDir()
+	reg := engine.NewRegistry("amy")
+	reg.AddEngine("test", &fakePiper{})
+	for _, name := range []string{"amy", "ryan"} {
+		if err := reg.AddVoice(engine.Voice{Name: name, Engine: "test"}); err != nil {
+			t.Fatalf("add voice: %v", err)
+		}
+	}
+	srv := newTestServer(t, config.Config{VoiceID: "amy", CacheDir: dir}, reg, &fakePlayer{ch: make(chan string, 8)})
+	defer srv.Close()
+
+	lexPath := filepath.Join(t.TempDir(), "lexicon.json")
+	write := func(body string) {
+		if err := os.WriteFile(lexPath, []byte(body), 0o644); err != nil {
+			t.Fatalf("write lexicon: %v", err)
+		}
+	}
+	write(`{"words":[{"word":"Siobhan","say":"shi vawn","voices":["amy"]}]}`)
+	if err := srv.WatchLexicon(lexPath); err != nil {
+		t.Fatalf("watch lexicon: %v", err)
+	}
+
+	cases := []struct{ text, voice, spoken string }{
+		{"Call Siobhan", "amy", "Call shi vawn"},
+		{"Call Siobhan", "ryan", "Call Siobhan"},
+		{"Call home", "amy", "Call home"},
+	}
+	for _, c := range cases {
+		if got, _ := srv.prepare(context.Background(), c.text, c.voice); got != c.spoken {
+			t.Fatalf("prepare(%q, %q) = %q, want %q", c.text, c.voice, got, c.spoken)
+		}
+	}
+
+	write(`{"rules":[{"pattern":"\\bsio\\w+","replace":"shiv"}]}`)
+	if err := srv.loadLexicon(lexPath); err != nil {
+		t.Fatalf("reload lexicon: %v", err)
+	}
+	if got, _ := srv.prepare(context.Background(), "Call Siobhan", "ryan"); got != "Call shiv" {
+		t.Fatalf("reloaded lexicon not applied: %q", got)
+	}
+
+	write(`{"rules":[{"pattern":"(","replace":"x"}]}`)
+	if err := srv.loadLexicon(lexPath); err == nil {
+		t.Fatal("expected invalid lexicon to fail")
+	}
+	if got, _ := srv.prepare(context.Background(), "Call Siobhan", "amy"); got != "Call shiv" {
+		t.Fatalf("previous lexicon not kept: %q", got)
+	}
+}
+
+func TestFilterCommandRewritesTextAndAppliesFailurePolicy(t *testing.T) {
+	t.Setenv("SERVER_FILTER_HELPER", "1")
+	dir := t.TempDir()
+	newServer := func(mode, failure string) *Server {
+		cfg := config.Config{
+			VoiceID:       "default",
+			CacheDir:      dir,
+			FilterCmd:     os.Args[0],
+			FilterArgs:    []string{"-test.run=^TestFilterHelperProcess$", "--", mode},
+			FilterTimeout: 5 * time.Second,
+			FilterFailure: failure,
+		}
+		return newTestServer(t, cfg, single(&fakePiper{}), &fakePlayer{ch: make(chan string, 8)})
+	}
+	tts := func(srv *Server, text string) (*httptest.ResponseRecorder, ttsResponse) {
+		rec := httptest.NewRecorder()
+		srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(`{"text":"`+text+`"}`)))
+		var resp ttsResponse
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		return rec, resp
+	}
+
+	rec, filtered := tts(newServer("expand", config.FilterReject), "ping  ops")
+	if rec.Code != http.StatusOK || filtered.File != cache.BuildKey("default", "ping the operations team")+".wav" {
+		t.Fatalf("filtered text not used for the cache key: %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec, _ := tts(newServer("fail", config.FilterReject), "ping ops"); rec.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("reject policy: expected 422, got %d", rec.Code)
+	}
+	rec, skipped := tts(newServer("fail", config.FilterSkip), "ping ops")
+	if rec.Code != http.StatusOK || skipped.File != cache.BuildKey("default", "ping ops")+".wav" {
+		t.Fatalf("skip policy: expected unfiltered text, got %d %s", rec.Code, rec.Body.String())
+	}
+}
+
+// TestFilterHelperProcess acts as the filter command for the test above.
+func TestFilterHelperProcess(t *testing.T) {
+	if os.Getenv("SERVER_FILTER_HELPER") == "" {
+		return
+	}
+	data, _ := io.ReadAll(os.Stdin)
+	if os.Args[len(os.Args)-1] == "fail" {
+		os.Exit(1)
+	}
+	os.Stdout.WriteString(strings.ReplaceAll(string(data), "ops", "\n the operations   team\n"))
+	os.Exit(0)
+}
+
+func TestHandleTTSDialogueResamplesAndCaches(t *testing.T) {
+	dir := t.TempDir()
+	amy, ryan := &wavPiper{}, &wavPiper{rate: 11025}
+	reg := engine.NewRegistry("amy")
+	reg.AddEngine("amy", amy)
+	reg.AddEngine("ryan", ryan)
+	for _, v := range []engine.Voice{{Name: "amy", Engine: "amy"}, {Name: "ryan", Engine: "ryan"}} {
+		if err := reg.AddVoice(v); err != nil {
+			t.Fatalf("add voice: %v", err)
+		}
+	}
+	player := &fakePlayer{ch: make(chan string, 4)}
+	srv := newTestServer(t, config.Config{VoiceID: "amy", CacheDir: dir}, reg, player)
+
+	first := postTTSOK(t, srv, `{"segments":[{"text":"Hi Ryan","voice":"amy","pause":"100ms"},{"text":"Hello!","voice":"ryan"}]}`)
+	if first.Status != "cache_miss" || first.Segments == nil || first.Segments.Total != 2 {
+		t.Fatalf("unexpected first response %+v", first)
+	}
+	if played := <-player.ch; filepath.Base(played) != first.File {
+		t.Fatalf("played %s, want %s", played, first.File)
+	}
+
+	f, err := os.Open(filepath.Join(dir, first.File))
+	if err != nil {
+		t.Fatalf("open dialogue: %v", err)
+	}
+	format, pcm, err := audio.ReadWAV(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("read dialogue: %v", err)
+	}
+	// amy's part is copied as is; ryan's 3 frames at 11025 Hz become 6 at 22050 Hz.
+	want := len("Hi Ryan") + len(audio.Silence(format, 100*time.Millisecond)) + 2*len("Hello!")
+	if format.SampleRate != 22050 || len(pcm) != want {
+		t.Fatalf("dialogue format %+v pcm %d bytes, want 22050 Hz and %d bytes", format, len(pcm), want)
+	}
+
+	markup := postTTSOK(t, srv, `{"text":"[voice=amy] Hi Ryan [pause=100ms] [voice=ryan] Hello!"}`)
+	if markup.Status != "cache_hit" || markup.File != first.File {
+		t.Fatalf("markup form should hit the same composite, got %+v", markup)
+	}
+	if amy.count() != 1 || ryan.count() != 1 {
+		t.Fatalf("expected one synthesis per voice, got %d/%d", amy.count(), ryan.count())
+	}
+
+	rec := httptest.NewRecorder()
+	srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(`{"segments":[{"text":"hi","pause":"-1s"}]}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid pause: expected 400, got %d", rec.Code)
+	}
+}
+
+func TestHandleTTSSSMLMapsToSegments(t *testing.T) {
+	dir := t.TempDir()
+	amy, ryan := &rateWavPiper{}, &rateWavPiper{}
+	reg := engine.NewRegistry("amy")
+	reg.AddEngine("amy", amy)
+	reg.AddEngine("ryan", ryan)
+	for _, v := range []engine.Voice{{Name: "amy", Engine: "amy", Language: "en"}, {Name: "ryan", Engine: "ryan"}} {
+		if err := reg.AddVoice(v); err != nil {
+			t.Fatalf("add voice: %v", err)
+		}
+	}
+	srv := newTestServer(t, config.Config{VoiceID: "amy", CacheDir: dir}, reg, &fakePlayer{ch: make(chan string, 4)})
+
+	body, _ := json.Marshal(map[string]any{
+		"ssml":         `<speak>Room <say-as interpret-as="digits">12</say-as><break time="200ms"/><prosody rate="50%">slowly</prosody><voice name="ryan"><prosody rate="fast">ok</prosody></voice><blink>!</blink></speak>`,
+		"length_scale": 1.2,
+	})
+	rec := httptest.NewRecorder()
+	srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewReader(body)))
+	var resp ttsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+	if resp.Segments == nil || resp.Segments.Total != 4 {
+		t.Fatalf("expected 4 segments, got %+v", resp)
+	}
+	// Prosody rates scale the request's length_scale per segment.
+	if got := amy.spoken(); !reflect.DeepEqual(got, []string{"!@1.2", "Room one two@1.2", "slowly@2.4"}) {
+		t.Fatalf("amy spoke %v", got)
+	}
+	if got := ryan.spoken(); !reflect.DeepEqual(got, []string{"ok@0.96"}) {
+		t.Fatalf("ryan spoke %v", got)
+	}
+
+	for _, body := range []string{
+		`{"ssml":"<speak>unclosed"}`,
+		`{"ssml":"<speak>hi</speak>","text":"hi"}`,
+	} {
+		rec := httptest.NewRecorder()
+		srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(body)))
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", body, rec.Code)
+		}
+	}
+}
+
+// rateWavPiper supports length_scale and records each text with its scale.
+type rateWavPiper struct {
+	wavPiper
+	texts []string
+}
+
+func (f *rateWavPiper) Capabilities() engine.Capabilities {
+	return engine.Capabilities{SampleRate: 22050, Params: []string{engine.ParamLengthScale}}
+}
+
+func (f *rateWavPiper) Synthesize(ctx context.Context, req engine.Request, outPath string) error {
+	f.mu.Lock()
+	text := req.Text
+	if req.Params.LengthScale != nil {
+		text += "@" + engine.FormatFloat(*req.Params.LengthScale)
+	}
+	f.texts = append(f.texts, text)
+	f.mu.Unlock()
+	return f.wavPiper.Synthesize(ctx, req, outPath)
+}
+
+func (f *rateWavPiper) spoken() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	texts := append([]string(nil), f.texts...)
+	sort.Strings(texts)
+	return texts
+}
+
+func TestRedactionKeepsSecretsOffDisk(t *testing.T) {
+	dir := t.TempDir()
+	srv := newTestServer(t, config.Config{VoiceID: "default", CacheDir: dir}, single(&wavPiper{}), &fakePlayer{ch: make(chan string, 8)})
+	redactor, err := redact.New([]string{redact.All}, nil, "redacted")
+	if err != nil {
+		t.Fatalf("new redactor: %v", err)
+	}
+	srv.SetRedactor(redactor)
+
+	for _, body := range []string{
+		`{"text":"Deploy failed for alice@example.com with key sk-live1234567890abcdefXYZ"}`,
+		`{"segments":[{"text":"Ping alice@example.com"}]}`,
+	} {
+		rec := httptest.NewRecorder()
+		srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(body)))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: unexpected response %d %s", body, rec.Code, rec.Body.String())
+		}
+	}
+	srv.Close()
+
+	if _, err := os.Stat(filepath.Join(dir, cache.BuildKey("default", "Deploy failed for redacted with key redacted")+".wav")); err != nil {
+		t.Fatalf("redacted text not cached: %v", err)
+	}
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if bytes.Contains(data, []byte("alice@")) || bytes.Contains(data, []byte("sk-live")) {
+			t.Errorf("%s contains a secret", filepath.Base(path))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walk cache: %v", err)
+	}
+}
+
+func TestUnicodeAndEmojiHandling(t *testing.T) {
+	dir := t.TempDir()
+	reg := engine.NewRegistry("amy")
+	reg.AddEngine("test", &fakePiper{})
+	for _, v := range []engine.Voice{{Name: "amy", Engine: "test"}, {Name: "ryan", Engine: "test", Emoji: unicodetext.EmojiStrip}} {
+		if err := reg.AddVoice(v); err != nil {
+			t.Fatalf("add voice: %v", err)
+		}
+	}
+	cfg := config.Config{VoiceID: "amy", CacheDir: dir, Emoji: unicodetext.EmojiVerbalize}
+	srv := newTestServer(t, cfg, reg, &fakePlayer{ch: make(chan string, 8)})
+
+	composed := postTTSOK(t, srv, `{"text":"Caf\u00e9 \u201copen\u201d \ud83d\udc4d"}`)
+	decomposed := postTTSOK(t, srv, `{"text":"Cafe\u0301\u200b \"open\" \ud83d\udc4d\ufe0f"}`)
+	if composed.File != decomposed.File || composed.File != cache.BuildKey("amy", `Café "open" thumbs up`)+".wav" {
+		t.Fatalf("equivalent texts got %s and %s", composed.File, decomposed.File)
+	}
+	if stripped := postTTSOK(t, srv, `{"text":"Caf\u00e9 \u201copen\u201d \ud83d\udc4d","voice":"ryan"}`); stripped.File != cache.BuildKey("ryan", `Café "open"`)+".wav" {
+		t.Fatalf("per-voice strip not applied: %s", stripped.File)
+	}
+}
+
+func TestFormatExtractsSpeakableText(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.Config{VoiceID: "default", CacheDir: dir}
+	srv := newTestServer(t, cfg, single(&fakePiper{}), &fakePlayer{ch: make(chan string, 8)})
+
+	want := cache.BuildKey("default", "Release notes. Read the docs. Code block omitted. Thanks.") + ".wav"
+	for _, body := range []string{
+		`{"text":"## Release notes\n\n* Read the [docs](https://example.com/docs)\n\n` + "```sh\\nmake\\n```" + `\n\n**Thanks.**","format":"markdown"}`,
+		`{"text":"<h2>Release notes</h2><ul><li>Read the <a href='https://example.com/docs'>docs</a></li></ul><pre><code>make</code></pre><p><b>Thanks.</b></p>","format":"html"}`,
+	} {
+		rec := postTTS(t, srv, body)
+		var resp ttsResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+			t.Fatalf("%s: unexpected response %d %s", body, rec.Code, rec.Body.String())
+		}
+		if resp.File != want {
+			t.Fatalf("%s: got %s, want %s", body, resp.File, want)
+		}
+	}
+
+	if rec := postTTS(t, srv, `{"text":"**as is**","format":"plain"}`); !strings.Contains(rec.Body.String(), cache.BuildKey("default", "**as is**")) {
+		t.Fatalf("plain text was converted: %s", rec.Body.String())
+	}
+	if rec := postTTS(t, srv, `{"text":"hello","format":"rtf"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown format, got %d", rec.Code)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.handleNormalize(rec, httptest.NewRequest(http.MethodGet, "/normalize?format=markdown&text="+url.QueryEscape("# Hi *there*"), nil))
+	var norm normalizeResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &norm); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("normalize: unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+	if norm.Input != "Hi there." || len(norm.Steps) == 0 || norm.Steps[0].Rule != "format" {
+		t.Fatalf("unexpected normalize response %+v", norm)
+	}
+}
+
+func TestRunWarmupSynthesizesAndPins(t *testing.T) {
+	dir := t.TempDir()
+	fp := &fakePiper{}
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, WarmupConcurrency: 1}
+	srv := newTestServer(t, cfg, single(fp), &fakePlayer{})
+
+	snap, err := srv.RunWarmup(context.Background(), []warmup.Entry{
+		{Text: "front  door"},
+		{Text: "smoke alarm", Pin: true},
+		{Text: "hello", Voice: "other"},
+	})
+	if err != nil {
+		t.Fatalf("warmup: %v", err)
+	}
+	if snap.Synthesized != 2 || snap.Failed != 1 {
+		t.Fatalf("unexpected snapshot %+v", snap)
+	}
+	if fp.calls != 2 {
+		t.Fatalf("expected 2 syntheses, got %d", fp.calls)
+	}
+	if _, err := os.Stat(filepath.Join(dir, cache.BuildKey("default", "front door")+".wav")); err != nil {
+		t.Fatalf("warmed entry missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, cache.BuildKey("default", "smoke alarm")+".pin")); err != nil {
+		t.Fatalf("pin marker missing: %v", err)
+	}
+}
+
+func TestWarmupPinsBeforeEvictionAndPrunesStalePins(t *testing.T) {
+	dir := t.TempDir()
+	// A one-byte cache evicts every unpinned entry as soon as it is committed.
+	srv := newTestServer(t, config.Config{VoiceID: "default", CacheDir: dir, CacheMaxBytes: 1, WarmupConcurrency: 1}, single(&fakePiper{}), &fakePlayer{})
+	mgr := srv.cache
+
+	stale := cache.BuildKey("default", "old phrase")
+	if err := mgr.Pin(stale); err != nil {
+		t.Fatalf("pin: %v", err)
+	}
+	entries := []warmup.Entry{{Text: "smoke alarm", Pin: true}, {Text: "front door"}}
+	if n, err := srv.PrunePins(context.Background(), entries); err != nil || n != 1 {
+		t.Fatalf("expected 1 stale pin removed, got %d, %v", n, err)
+	}
+	if snap, err := srv.RunWarmup(context.Background(), entries); err != nil || snap.Failed != 0 {
+		t.Fatalf("warmup: %+v %v", snap, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, cache.BuildKey("default", "smoke alarm")+".wav")); err != nil {
+		t.Fatalf("pinned entry evicted while being committed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, cache.BuildKey("default", "front door")+".wav")); !os.IsNotExist(err) {
+		t.Fatalf("unpinned entry should have been evicted: %v", err)
+	}
+	pinned, err := mgr.Pinned()
+	if err != nil || !reflect.DeepEqual(pinned, []string{cache.BuildKey("default", "smoke alarm")}) {
+		t.Fatalf("unexpected pins %v, %v", pinned, err)
+	}
+}
+
+func TestConcurrentMissesSynthesizeOnce(t *testing.T) {
+	dir := t.TempDir()
+	fp := &fakePiper{delay: 20 * time.Millisecond}
+	player := &fakePlayer{ch: make(chan string, 8)}
+	srv := newTestServer(t, config.Config{VoiceID: "default", CacheDir: dir}, single(fp), player)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(`{"text":"same text"}`)))
+			if rec.Code != http.StatusOK {
+				t.Errorf("expected 200, got %d", rec.Code)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := fp.count(); n != 1 {
+		t.Fatalf("expected a single synthesis, got %d", n)
+	}
+}
+
+func TestHandleTTSQueueFullReturns503(t *testing.T) {
+	dir := t.TempDir()
+	gate := make(chan struct{})
+	fp := &fakePiper{gate: gate}
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, SynthConcurrency: 1, SynthQueue: 0}
+	srv := newTestServer(t, cfg, single(fp), &fakePlayer{ch: make(chan string, 4)})
+
+	first := make(chan int, 1)
+	go func() {
+		rec := httptest.NewRecorder()
+		srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(`{"text":"first"}`)))
+		first <- rec.Code
+	}()
+	deadline := time.Now().Add(time.Second)
+	for fp.count() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("first synthesis never started")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(`{"text":"second"}`)))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("missing Retry-After header")
+	}
+
+	close(gate)
+	if code := <-first; code != http.StatusOK {
+		t.Fatalf("first request failed with %d", code)
+	}
+
+	statusRec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(statusRec, httptest.NewRequest(http.MethodGet, "/status", nil))
+	var status statusResponse
+	if err := json.Unmarshal(statusRec.Body.Bytes(), &status); err != nil {
+		t.Fatalf("unmarshal status: %v", err)
+	}
+	if status.Synthesis.Rejected != 1 || status.Synthesis.Completed != 1 {
+		t.Fatalf("unexpected synthesis status %+v", status.Synthesis)
+	}
+}
+
 type fakePiper struct {
+	mu    sync.Mutex
 	calls int
+	delay time.Duration
+	gate  chan struct{}
+}
+
+func (f *fakePiper) Capabilities() engine.Capabilities {
+	return engine.Capabilities{SampleRate: 22050}
 }
 
-func (f *fakePiper) Synthesize(_ context.Context, _ string, outPath string) error {
+func (f *fakePiper) Synthesize(_ context.Context, _ engine.Request, outPath string) error {
+	f.mu.Lock()
 	f.calls++
+	f.mu.Unlock()
+	time.Sleep(f.delay)
+	if f.gate != nil {
+		<-f.gate
+	}
 	return os.WriteFile(outPath, []byte("wav"), 0o644)
 }
 
+func (f *fakePiper) count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
 type fakePlayer struct {
 	ch chan string
 }
@@ -138,3 +987,609 @@ func (f *fakePlayer) PlayWav(path string) {
 }
 
 var logDiscard = log.New(io.Discard, "", 0)
+
+// newTestServer builds a server over cfg.CacheDir with a 1 MiB cache, unless
+// cfg sets CacheMaxBytes.
+func newTestServer(t *testing.T, cfg config.Config, engines *engine.Registry, player Player) *Server {
+	t.Helper()
+	if cfg.CacheMaxBytes == 0 {
+		cfg.CacheMaxBytes = 1024 * 1024
+	}
+	return New(cfg, cache.NewManager(cfg.CacheDir, cfg.CacheMaxBytes, logDiscard), engines, player, logDiscard)
+}
+
+// postTTS sends body to srv's /tts handler.
+func postTTS(t *testing.T, srv *Server, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(body)))
+	return rec
+}
+
+// postTTSOK is postTTS for requests that must succeed.
+func postTTSOK(t *testing.T, srv *Server, body string) ttsResponse {
+	t.Helper()
+	rec := postTTS(t, srv, body)
+	var resp ttsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("%s: unexpected response %d %s", body, rec.Code, rec.Body.String())
+	}
+	return resp
+}
+
+// single returns a registry serving e as the "default" voice.
+func single(e engine.Engine) *engine.Registry {
+	reg := engine.NewRegistry("default")
+	reg.AddEngine("test", e)
+	if err := reg.AddVoice(engine.Voice{Name: "default", Engine: "test"}); err != nil {
+		panic(err)
+	}
+	return reg
+}
+
+func TestHandleTTSStreamsPlaybackAndCaches(t *testing.T) {
+	dir := t.TempDir()
+	fp := &fakeRawPiper{pcm: []byte{1, 2, 3, 4, 5, 6}, rate: 16000}
+	player := &fakePCMPlayer{fakePlayer: fakePlayer{ch: make(chan string, 1)}, closed: make(chan []byte, 1)}
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, StreamPlayback: true}
+	srv := newTestServer(t, cfg, single(fp), player)
+
+	rec := httptest.NewRecorder()
+	srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(`{"text":"stream me"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	select {
+	case got := <-player.closed:
+		if !bytes.Equal(got, fp.pcm) {
+			t.Fatalf("player received %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("stream not delivered to player")
+	}
+	select {
+	case <-player.ch:
+		t.Fatalf("streamed audio must not be replayed from file")
+	default:
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, cache.BuildKey("default", "stream me")+".wav"))
+	if err != nil {
+		t.Fatalf("cached wav missing: %v", err)
+	}
+	if len(data) != 44+len(fp.pcm) || string(data[:4]) != "RIFF" || !bytes.Equal(data[44:], fp.pcm) {
+		t.Fatalf("unexpected cached wav %v", data)
+	}
+	if size := binary.LittleEndian.Uint32(data[40:44]); size != uint32(len(fp.pcm)) {
+		t.Fatalf("data size not patched: %d", size)
+	}
+	if rate := binary.LittleEndian.Uint32(data[24:28]); rate != 16000 {
+		t.Fatalf("unexpected sample rate %d", rate)
+	}
+}
+
+type fakeRawPiper struct {
+	fakePiper
+	pcm  []byte
+	rate int
+}
+
+func (f *fakeRawPiper) Capabilities() engine.Capabilities {
+	rate := f.rate
+	if rate == 0 {
+		rate = 22050
+	}
+	return engine.Capabilities{SampleRate: rate, Streaming: true}
+}
+
+func (f *fakeRawPiper) SynthesizeRaw(_ context.Context, _ engine.Request, w io.Writer) error {
+	_, err := w.Write(f.pcm)
+	return err
+}
+
+type fakePCMPlayer struct {
+	fakePlayer
+	closed chan []byte
+}
+
+func (f *fakePCMPlayer) StartPCM(int) (io.WriteCloser, error) {
+	return &capture{done: f.closed}, nil
+}
+
+type capture struct {
+	bytes.Buffer
+	done chan []byte
+}
+
+func (c *capture) Close() error {
+	c.done <- c.Bytes()
+	return nil
+}
+
+func TestHandleTTSStreamChunkedThenCached(t *testing.T) {
+	dir := t.TempDir()
+	fp := &fakeRawPiper{pcm: []byte{9, 8, 7, 6}}
+	cfg := config.Config{VoiceID: "default", CacheDir: dir}
+	srv := newTestServer(t, cfg, single(fp), &fakePlayer{ch: make(chan string, 1)})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL+"/tts/stream", "application/json", bytes.NewBufferString(`{"text":"remote"}`))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("X-Cache-Status") != "cache_miss" {
+		t.Fatalf("unexpected response %s %v", resp.Status, resp.Header)
+	}
+	if len(resp.TransferEncoding) == 0 || resp.TransferEncoding[0] != "chunked" {
+		t.Fatalf("expected chunked transfer encoding, got %v", resp.TransferEncoding)
+	}
+	if len(body) != 44+4 || binary.LittleEndian.Uint32(body[40:44]) != 0xFFFFFFFF || !bytes.Equal(body[44:], fp.pcm) {
+		t.Fatalf("unexpected streamed body %v", body)
+	}
+	if got := resp.Trailer.Get("X-Cache-Status"); got != "cache_miss" {
+		t.Fatalf("trailer status %q, want cache_miss", got)
+	}
+
+	resp, err = http.Post(ts.URL+"/tts/stream", "application/json", bytes.NewBufferString(`{"text":"remote"}`))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.Header.Get("X-Cache-Status") != "cache_hit" || resp.ContentLength != 48 {
+		t.Fatalf("expected cached wav with length, got %v len=%d", resp.Header, resp.ContentLength)
+	}
+	if binary.LittleEndian.Uint32(body[40:44]) != 4 {
+		t.Fatalf("cached wav header not finalized")
+	}
+}
+
+func TestHandleTTSStreamDisconnectCancelsSynthesis(t *testing.T) {
+	dir := t.TempDir()
+	fp := &blockingRawPiper{cancelled: make(chan struct{})}
+	cfg := config.Config{VoiceID: "default", CacheDir: dir}
+	srv := newTestServer(t, cfg, single(fp), &fakePlayer{})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, ts.URL+"/tts/stream", bytes.NewBufferString(`{"text":"long"}`))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	buf := make([]byte, 46)
+	if _, err := io.ReadFull(resp.Body, buf); err != nil {
+		t.Fatalf("read first chunk: %v", err)
+	}
+	cancel()
+	resp.Body.Close()
+
+	select {
+	case <-fp.cancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("synthesis not cancelled after disconnect")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*.wav*")); len(matches) != 0 {
+		t.Fatalf("partial audio left in cache: %v", matches)
+	}
+}
+
+type blockingRawPiper struct {
+	fakePiper
+	cancelled chan struct{}
+}
+
+func (f *blockingRawPiper) Capabilities() engine.Capabilities {
+	return engine.Capabilities{SampleRate: 22050, Streaming: true}
+}
+
+func (f *blockingRawPiper) SynthesizeRaw(ctx context.Context, _ engine.Request, w io.Writer) error {
+	if _, err := w.Write([]byte{1, 2}); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	close(f.cancelled)
+	return ctx.Err()
+}
+
+func TestSentenceCacheReusesUnchangedSentences(t *testing.T) {
+	dir := t.TempDir()
+	fp := &wavPiper{}
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, SentenceCache: true, SentenceGap: 10 * time.Millisecond, SynthConcurrency: 2}
+	srv := newTestServer(t, cfg, single(fp), &fakePlayer{ch: make(chan string, 4)})
+
+	first := postTTSOK(t, srv, `{"text":"Disk is full. Backup failed."}`)
+	if first.Status != "cache_miss" || first.Sentences == nil || first.Sentences.Total != 2 || first.Sentences.Cached != 0 {
+		t.Fatalf("unexpected first response %+v", first)
+	}
+
+	second := postTTSOK(t, srv, `{"text":"Disk is full. Backup succeeded."}`)
+	if second.Status != "partial_hit" || second.Sentences.Total != 2 || second.Sentences.Cached != 1 {
+		t.Fatalf("unexpected second response %+v", second)
+	}
+	if n := fp.count(); n != 3 {
+		t.Fatalf("expected 3 sentence syntheses, got %d", n)
+	}
+
+	f, err := os.Open(filepath.Join(dir, second.File))
+	if err != nil {
+		t.Fatalf("open composite: %v", err)
+	}
+	defer f.Close()
+	format, pcm, err := audio.ReadWAV(f)
+	if err != nil {
+		t.Fatalf("read composite: %v", err)
+	}
+	gap := len(audio.Silence(format, 10*time.Millisecond))
+	want := len("Disk is full.") + gap + len("Backup succeeded.")
+	if len(pcm) != want {
+		t.Fatalf("composite pcm length %d, want %d", len(pcm), want)
+	}
+
+	if third := postTTSOK(t, srv, `{"text":"Disk is full. Backup succeeded."}`); third.Status != "cache_hit" {
+		t.Fatalf("expected composite cache_hit, got %+v", third)
+	}
+}
+
+// silenceWavPiper supports sentence_silence and, like Piper, ends its output
+// with that much silence.
+type silenceWavPiper struct{ wavPiper }
+
+func (f *silenceWavPiper) Capabilities() engine.Capabilities {
+	return engine.Capabilities{SampleRate: 22050, Params: []string{engine.ParamSentenceSilence}}
+}
+
+func (f *silenceWavPiper) Synthesize(_ context.Context, req engine.Request, outPath string) error {
+	format := audio.PiperFormat(22050)
+	pcm := []byte(req.Text)
+	if ss := req.Params.SentenceSilence; ss != nil {
+		pcm = append(pcm, audio.Silence(format, time.Duration(*ss*float64(time.Second)))...)
+	}
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	return audio.WriteWAV(out, format, pcm)
+}
+
+func TestSentenceSilenceIsNotDoubled(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, SentenceCache: true, SentenceGap: 10 * time.Millisecond}
+	srv := newTestServer(t, cfg, single(&silenceWavPiper{}), &fakePlayer{ch: make(chan string, 4)})
+
+	rec := httptest.NewRecorder()
+	srv.handleTTS(rec, httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(`{"text":"Disk is full. Backup failed.","sentence_silence":0.5}`)))
+	var resp ttsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d %s", rec.Code, rec.Body.String())
+	}
+	f, err := os.Open(filepath.Join(dir, resp.File))
+	if err != nil {
+		t.Fatalf("open composite: %v", err)
+	}
+	defer f.Close()
+	format, pcm, err := audio.ReadWAV(f)
+	if err != nil {
+		t.Fatalf("read composite: %v", err)
+	}
+	silence := len(audio.Silence(format, 500*time.Millisecond))
+	if want := len("Disk is full.") + silence + len("Backup failed.") + silence; len(pcm) != want {
+		t.Fatalf("composite pcm length %d, want %d", len(pcm), want)
+	}
+}
+
+func TestSentenceCacheRespectsSynthQueue(t *testing.T) {
+	dir := t.TempDir()
+	fp := &wavPiper{}
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, SentenceCache: true, SynthConcurrency: 1}
+	srv := newTestServer(t, cfg, single(fp), &fakePlayer{ch: make(chan string, 4)})
+
+	body := `{"text":"One. Two. Three."}`
+
+	// The only slot is busy and SYNTH_QUEUE is 0: the request is turned away
+	// as a whole rather than its sentences waiting without bound.
+	release, err := srv.sched.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	if rec := postTTS(t, srv, body); rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected 503 with Retry-After, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if n := fp.count(); n != 0 {
+		t.Fatalf("rejected request synthesized %d sentences", n)
+	}
+	release()
+
+	if rec := postTTS(t, srv, body); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if st := srv.sched.Stats(); st.Queued != 0 || st.Background != 0 || fp.count() != 3 {
+		t.Fatalf("unexpected scheduler state %+v after %d syntheses", st, fp.count())
+	}
+}
+
+func TestDialogueRespectsSynthQueueAndWritesMeta(t *testing.T) {
+	dir := t.TempDir()
+	fp := &wavPiper{}
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, SynthConcurrency: 1}
+	srv := newTestServer(t, cfg, single(fp), &fakePlayer{ch: make(chan string, 4)})
+
+	body := `{"segments":[{"text":"One","pause":"10ms"},{"text":"Two"},{"text":"Three"}]}`
+	release, err := srv.sched.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	if rec := postTTS(t, srv, body); rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Fatalf("expected 503 with Retry-After, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if n := fp.count(); n != 0 {
+		t.Fatalf("rejected dialogue synthesized %d segments", n)
+	}
+	release()
+
+	rec := postTTS(t, srv, body)
+	var resp ttsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d: %s", rec.Code, rec.Body.String())
+	}
+	if st := srv.sched.Stats(); st.Queued != 0 || st.Background != 0 || fp.count() != 3 {
+		t.Fatalf("unexpected scheduler state %+v after %d syntheses", st, fp.count())
+	}
+
+	// The clip's metadata rebuilds its key, so cache export includes it.
+	key := strings.TrimSuffix(resp.File, ".wav")
+	meta, err := srv.cache.ReadMeta(key)
+	if err != nil {
+		t.Fatalf("read dialogue metadata: %v", err)
+	}
+	if meta.Voice != cache.DialogueVoice || cache.BuildKey(meta.Voice, meta.Text, meta.Params) != key {
+		t.Fatalf("metadata %+v does not describe key %s", meta, key)
+	}
+	if models := cache.ParseDialogueModel(meta.Model); len(models) != 1 {
+		t.Fatalf("expected the model of one voice, got %q", meta.Model)
+	}
+}
+
+func TestRequestLimitsAndLongTextUnits(t *testing.T) {
+	dir := t.TempDir()
+	fp := &wavPiper{}
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, MaxBodyBytes: 200, MaxTextChars: 50, MaxUnitChars: 20, SentenceGap: 10 * time.Millisecond, SynthConcurrency: 2}
+	srv := newTestServer(t, cfg, single(fp), &fakePlayer{ch: make(chan string, 4)})
+
+	tooLarge := func(rec *httptest.ResponseRecorder, code string, limit, size int64) {
+		t.Helper()
+		var le limitError
+		if rec.Code != http.StatusRequestEntityTooLarge || json.Unmarshal(rec.Body.Bytes(), &le) != nil {
+			t.Fatalf("expected 413 json, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if le.Code != code || le.Limit != limit || le.Size != size || le.Message == "" {
+			t.Fatalf("unexpected error body %+v", le)
+		}
+	}
+
+	// httptest sets the Content-Length, so oversized bodies fail before decoding.
+	big := `{"text":"` + strings.Repeat("a ", 150) + `"}`
+	tooLarge(postTTS(t, srv, big), "body_too_large", 200, int64(len(big)))
+	req := httptest.NewRequest(http.MethodPost, "/tts", bytes.NewBufferString(big))
+	req.ContentLength = -1
+	rec := httptest.NewRecorder()
+	srv.handleTTS(rec, req)
+	tooLarge(rec, "body_too_large", 200, 0)
+
+	// Characters are counted after whitespace is collapsed.
+	tooLarge(postTTS(t, srv, `{"text":"`+strings.Repeat("é", 51)+`"}`), "text_too_long", 50, 51)
+	if rec := postTTS(t, srv, `{"text":"`+strings.Repeat("é   ", 25)+`"}`); rec.Code != http.StatusOK {
+		t.Fatalf("expected 200 for 49 characters, got %d: %s", rec.Code, rec.Body.String())
+	}
+	calls := fp.count()
+	tooLarge(postTTS(t, srv, `{"segments":[{"text":"`+strings.Repeat("a", 30)+`"},{"text":"`+strings.Repeat("b", 30)+`"}]}`), "text_too_long", 50, 60)
+	if n := fp.count(); n != calls {
+		t.Fatalf("rejected dialogue was synthesized: %d calls, want %d", n, calls)
+	}
+
+	text := "One two three. Four five six. Seven eight."
+	rec = postTTS(t, srv, `{"text":"`+text+`"}`)
+	var resp ttsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("unexpected response %d: %s", rec.Code, rec.Body.String())
+	}
+	if resp.File != cache.BuildKey("default", text)+".wav" || resp.Sentences == nil || resp.Sentences.Total != 3 {
+		t.Fatalf("expected the text split into 3 units, got %+v", resp)
+	}
+	for _, unit := range []string{"One two three.", "Four five six.", "Seven eight."} {
+		if _, err := os.Stat(filepath.Join(dir, cache.BuildKey("default", unit)+".wav")); err != nil {
+			t.Fatalf("unit %q not cached: %v", unit, err)
+		}
+	}
+}
+
+func TestLongTextStreamsFirstUnit(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, MaxUnitChars: 20, SentenceGap: 10 * time.Millisecond, SynthConcurrency: 2}
+	srv := newTestServer(t, cfg, single(&streamWavPiper{}), &fakePlayer{ch: make(chan string, 1)})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	text := "One two three. Four five six. Seven eight."
+	resp, err := http.Post(ts.URL+"/tts/stream", "application/json", bytes.NewBufferString(`{"text":"`+text+`"}`))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(resp.TransferEncoding) == 0 || resp.TransferEncoding[0] != "chunked" {
+		t.Fatalf("expected a chunked stream, got %s %v", resp.Status, resp.TransferEncoding)
+	}
+
+	// The first unit is streamed as it is synthesized and the rest of the
+	// composite follows it, so the client hears the whole text.
+	f, err := os.Open(filepath.Join(dir, cache.BuildKey("default", text)+".wav"))
+	if err != nil {
+		t.Fatalf("composite not cached: %v", err)
+	}
+	_, pcm, err := audio.ReadWAV(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("read composite: %v", err)
+	}
+	if len(body) < 44 || binary.LittleEndian.Uint32(body[40:44]) != 0xFFFFFFFF || !bytes.Equal(body[44:], pcm) {
+		t.Fatalf("streamed %d bytes, want a streaming header and the composite's %d", len(body), len(pcm))
+	}
+	if !bytes.HasPrefix(pcm, []byte("One two three.")) {
+		t.Fatalf("composite does not start with the first unit")
+	}
+}
+
+func TestLongTextStreamTrailerReportsFinalStatus(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, MaxUnitChars: 20, SentenceGap: 10 * time.Millisecond, SynthConcurrency: 2}
+	srv := newTestServer(t, cfg, single(&streamWavPiper{}), &fakePlayer{ch: make(chan string, 1)})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	postTTSOK(t, srv, `{"text":"Four five six.","play":false}`)
+	resp, err := http.Post(ts.URL+"/tts/stream", "application/json", bytes.NewBufferString(`{"text":"One two three. Four five six. Seven eight."}`))
+	if err != nil {
+		t.Fatalf("post: %v", err)
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if len(resp.TransferEncoding) == 0 || resp.Header.Get("X-Cache-Status") != "cache_miss" {
+		t.Fatalf("expected a stream with a provisional cache_miss, got %v %v", resp.TransferEncoding, resp.Header)
+	}
+	if got := resp.Trailer.Get("X-Cache-Status"); got != "partial_hit" {
+		t.Fatalf("trailer status %q, want partial_hit", got)
+	}
+}
+
+func TestLongTextPlaybackDoesNotHoldRequest(t *testing.T) {
+	dir := t.TempDir()
+	player := &gatedPCMPlayer{fakePlayer: fakePlayer{ch: make(chan string, 1)}, gate: make(chan struct{}), closed: make(chan []byte, 1), limit: len("One two three.")}
+	cfg := config.Config{VoiceID: "default", CacheDir: dir, MaxUnitChars: 20, SentenceGap: 10 * time.Millisecond, SynthConcurrency: 1, StreamPlayback: true}
+	srv := newTestServer(t, cfg, single(&streamWavPiper{}), player)
+
+	// The player takes the first unit, then blocks as aplay does in real
+	// time; the request and its queue place must not wait for it.
+	body := `{"text":"One two three. Four five six. Seven eight."}`
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() { done <- postTTS(t, srv, body) }()
+	select {
+	case rec := <-done:
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	case <-time.After(2 * time.Second):
+		close(player.gate)
+		t.Fatalf("request waited for playback")
+	}
+	if st := srv.sched.Stats(); st.Queued != 0 {
+		t.Fatalf("queue place held during playback: %+v", st)
+	}
+	if resp := postTTSOK(t, srv, body); resp.Status != "cache_hit" {
+		t.Fatalf("expected cache_hit during playback, got %+v", resp)
+	}
+
+	close(player.gate)
+	select {
+	case got := <-player.closed:
+		data, err := os.ReadFile(filepath.Join(dir, cache.BuildKey("default", "One two three. Four five six. Seven eight.")+".wav"))
+		if err != nil || !bytes.Equal(got, data[44:]) {
+			t.Fatalf("player got %d bytes, want the composite (%v)", len(got), err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("playback not closed")
+	}
+}
+
+// gatedPCMPlayer accepts limit bytes of PCM, then blocks writes until gate is
+// closed.
+type gatedPCMPlayer struct {
+	fakePlayer
+	gate   chan struct{}
+	closed chan []byte
+	limit  int
+}
+
+func (f *gatedPCMPlayer) StartPCM(int) (io.WriteCloser, error) {
+	return &gatedCapture{capture: capture{done: f.closed}, gate: f.gate, limit: f.limit}, nil
+}
+
+type gatedCapture struct {
+	capture
+	gate  chan struct{}
+	limit int
+}
+
+func (c *gatedCapture) Write(p []byte) (int, error) {
+	if c.Len() >= c.limit {
+		<-c.gate
+	}
+	return c.capture.Write(p)
+}
+
+// streamWavPiper is a wavPiper that can also stream the text bytes as PCM.
+type streamWavPiper struct {
+	wavPiper
+}
+
+func (f *streamWavPiper) Capabilities() engine.Capabilities {
+	return engine.Capabilities{SampleRate: 22050, Streaming: true}
+}
+
+func (f *streamWavPiper) SynthesizeRaw(_ context.Context, req engine.Request, w io.Writer) error {
+	_, err := w.Write([]byte(req.Text))
+	return err
+}
+
+// wavPiper writes a valid 16-bit wav whose PCM is the text bytes, so odd-length
+// texts leave a trailing half sample.
+type wavPiper struct {
+	fakePiper
+	rate int
+}
+
+func (f *wavPiper) Synthesize(_ context.Context, req engine.Request, outPath string) error {
+	f.mu.Lock()
+	f.calls++
+	f.mu.Unlock()
+	out, err := os.Create(outPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	rate := f.rate
+	if rate == 0 {
+		rate = 22050
+	}
+	return audio.WriteWAV(out, audio.PiperFormat(rate), []byte(req.Text))
+}
+
+func TestSymbolsFollowVoiceLanguage(t *testing.T) {
+	dir := t.TempDir()
+	reg := engine.NewRegistry("amy")
+	reg.AddEngine("test", &fakePiper{})
+	for _, v := range []engine.Voice{{Name: "amy", Engine: "test", Language: "en_US"}, {Name: "thorsten", Engine: "test", Language: "de_DE"}} {
+		if err := reg.AddVoice(v); err != nil {
+			t.Fatalf("add voice: %v", err)
+		}
+	}
+	cfg := config.Config{VoiceID: "amy", CacheDir: dir, Emoji: unicodetext.EmojiStrip}
+	srv := newTestServer(t, cfg, reg, &fakePlayer{ch: make(chan string, 8)})
+
+	// German voices get no English words; degrees are left to the German rules.
+	de := postTTSOK(t, srv, `{"text":"Es sind 21,5 °C → morgen 1°C & Regen","voice":"thorsten"}`)
+	if want := cache.BuildKey("thorsten", "Es sind einundzwanzig Komma fünf Grad Celsius → morgen ein Grad Celsius & Regen") + ".wav"; de.File != want {
+		t.Fatalf("german text spoken as %s, want %s", de.File, want)
+	}
+	en := postTTSOK(t, srv, `{"text":"It is 1 °C → 21.5°C & sunny","voice":"amy"}`)
+	if want := cache.BuildKey("amy", "It is one degree Celsius to twenty-one point five degrees Celsius and sunny") + ".wav"; en.File != want {
+		t.Fatalf("english text spoken as %s, want %s", en.File, want)
+	}
+}
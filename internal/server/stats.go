@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/venkytv/tts-cached/internal/config"
+)
+
+const (
+	defaultTopPhrases = 10
+	statsSaveInterval = 5 * time.Minute
+	statsLockTimeout  = 10 * time.Second
+	statsFile         = ".stats.json"
+)
+
+func statsPath(cfg config.Config) string {
+	return filepath.Join(cfg.CacheDir, statsFile)
+}
+
+// persistStats periodically saves statistics until the server is closed.
+func (s *Server) persistStats() {
+	ticker := time.NewTicker(statsSaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.bgCtx.Done():
+			return
+		case <-ticker.C:
+			s.saveStats()
+		}
+	}
+}
+
+// saveStats merges this daemon's statistics into the shared stats file,
+// holding the cache lock for it so daemons sharing CACHE_DIR take turns.
+func (s *Server) saveStats() {
+	ctx, cancel := context.WithTimeout(context.Background(), statsLockTimeout)
+	defer cancel()
+	unlock, err := s.cache.LockKey(ctx, statsFile)
+	if err != nil {
+		s.logger.Printf("ERROR: save cache stats failed: %v", err)
+		return
+	}
+	defer unlock()
+	if err := s.stats.Save(statsPath(s.cfg)); err != nil {
+		s.logger.Printf("ERROR: save cache stats failed: %v", err)
+	}
+}
+
+// recordPlay feeds a /tts outcome into the cache analytics. Audio fetched
+// from a peer counts as a hit: nothing was synthesized for it.
+func (s *Server) recordPlay(res synthResult, text string) {
+	var size int64
+	if info, err := os.Stat(res.path); err == nil {
+		size = info.Size()
+	}
+	s.stats.Record(res.key, text, size, res.status == "cache_hit" || res.status == "peer_hit")
+}
+
+// handleCacheStats reports cache usage, hit ratios, top phrases and evictions.
+func (s *Server) handleCacheStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	top := defaultTopPhrases
+	if v := r.URL.Query().Get("top"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid top", http.StatusBadRequest)
+			return
+		}
+		top = n
+	}
+
+	total, entries, err := s.cache.Usage()
+	if err != nil {
+		s.logger.Printf("ERROR: cache usage failed: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, s.stats.Report(total, s.cache.MaxBytes(), entries, top))
+}
@@ -0,0 +1,253 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/venkytv/tts-cached/internal/audio"
+	"github.com/venkytv/tts-cached/internal/engine"
+)
+
+// PCMPlayer is implemented by players that can play raw PCM as it arrives.
+type PCMPlayer interface {
+	StartPCM(sampleRate int) (io.WriteCloser, error)
+}
+
+// pcmSink opens a destination for PCM produced during synthesis at sampleRate.
+type pcmSink func(sampleRate int) (io.WriteCloser, error)
+
+// playbackSink returns a sink that streams PCM to the player, or nil when
+// streaming playback is disabled or unsupported.
+func (s *Server) playbackSink() pcmSink {
+	if !s.cfg.StreamPlayback {
+		return nil
+	}
+	pcm, ok := s.player.(PCMPlayer)
+	if !ok {
+		return nil
+	}
+	return pcm.StartPCM
+}
+
+// synthesizeStreaming runs raw synthesis, teeing PCM into sink and into a temp
+// wav that is renamed to outPath only if synthesis succeeds. It reports whether
+// the sink received the audio; after a failure, whether it received any.
+func (s *Server) synthesizeStreaming(ctx context.Context, st engine.Streamer, req engine.Request, sampleRate int, outPath string, sink pcmSink) (streamed bool, err error) {
+	tmp, err := os.CreateTemp(filepath.Dir(outPath), filepath.Base(outPath)+".*.tmp")
+	if err != nil {
+		return false, fmt.Errorf("create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+	defer tmp.Close()
+
+	ww, err := audio.NewWAVWriter(tmp, audio.PiperFormat(sampleRate))
+	if err != nil {
+		return false, fmt.Errorf("write wav header: %w", err)
+	}
+
+	var dst io.Writer = ww
+	out, err := sink(sampleRate)
+	if err != nil {
+		s.logger.Printf("ERROR: open stream sink failed, caching only: %v", err)
+	} else {
+		// Closing may block until playback drains; don't hold the request for it.
+		defer func() { go out.Close() }()
+		tw := &tolerantWriter{w: out, logger: s.logger.Printf}
+		dst = io.MultiWriter(ww, tw)
+		streamed = true
+		defer func() {
+			if err != nil && tw.n == 0 {
+				streamed = false
+			}
+		}()
+	}
+
+	if err := st.SynthesizeRaw(ctx, req, dst); err != nil {
+		s.logger.Printf("ERROR: stream synth failed: %v", err)
+		return streamed, err
+	}
+	if err := ww.Close(); err != nil {
+		return streamed, fmt.Errorf("finalize wav: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return streamed, fmt.Errorf("close wav: %w", err)
+	}
+	if err := os.Rename(tmpPath, outPath); err != nil {
+		return streamed, fmt.Errorf("rename stream output failed: %w", err)
+	}
+	return streamed, nil
+}
+
+// tolerantWriter keeps a failing secondary destination (e.g. a player that
+// exited) from aborting synthesis; after the first error, writes are dropped.
+type tolerantWriter struct {
+	w      io.Writer
+	n      int64
+	failed bool
+	logger func(format string, args ...interface{})
+}
+
+func (t *tolerantWriter) Write(p []byte) (int, error) {
+	if t.failed {
+		return len(p), nil
+	}
+	t.n += int64(len(p))
+	if _, err := t.w.Write(p); err != nil {
+		t.failed = true
+		t.logger("ERROR: stream sink write failed, continuing without it: %v", err)
+	}
+	return len(p), nil
+}
+
+// handleTTSStream returns the audio to the caller instead of playing it. Cache
+// misses are streamed with chunked transfer encoding as Piper produces PCM,
+// using a WAV header with unknown-length sizes; the cache entry is committed
+// once synthesis completes. A client disconnect cancels synthesis.
+func (s *Server) handleTTSStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req ttsRequest
+	if !s.decodeRequest(w, r, &req) {
+		return
+	}
+	if err := extractText(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if parts, ok, err := s.dialogueRequest(r, req); ok || err != nil {
+		if err != nil {
+			s.writeRequestError(w, err)
+			return
+		}
+		// Dialogue clips are assembled before anything is sent.
+		res, err := s.ensureDialogue(r.Context(), parts, req.Params)
+		if err != nil {
+			s.writeSynthError(w, err)
+			return
+		}
+		s.recordPlay(res, dialogueText(parts))
+		s.logger.Printf("INFO: /tts/stream dialogue %s key=%s segments=%d", res.status, res.key, len(parts))
+		s.serveCached(w, r, res)
+		return
+	}
+
+	normalized := s.redact(normalizeText(req.Text))
+	if normalized == "" {
+		http.Error(w, "text is required", http.StatusBadRequest)
+		return
+	}
+	if err := s.checkLength(normalized); err != nil {
+		s.writeRequestError(w, err)
+		return
+	}
+
+	voice, reason, err := s.selectVoice(r, req, normalized)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if reason != "" {
+		s.logger.Printf("INFO: /tts/stream selected voice %s (%s)", voice, reason)
+		w.Header().Set("X-Voice", voice)
+	}
+
+	text, err := s.prepare(r.Context(), normalized, voice)
+	if err != nil {
+		s.writeRequestError(w, err)
+		return
+	}
+
+	hw := &httpPCMWriter{w: w}
+	res, err := s.ensureCached(r.Context(), synthJob{
+		text:   text,
+		voice:  voice,
+		params: req.Params,
+		sink: func(sampleRate int) (io.WriteCloser, error) {
+			hw.format = audio.PiperFormat(sampleRate)
+			return hw, nil
+		},
+	})
+	if err != nil {
+		if hw.started {
+			// Headers are gone; dropping the connection mid-stream is all that is left.
+			s.logger.Printf("ERROR: /tts/stream aborted after %d bytes: %v", hw.n, err)
+			panic(http.ErrAbortHandler)
+		}
+		s.writeSynthError(w, err)
+		return
+	}
+	s.recordPlay(res, normalized)
+	s.logger.Printf("INFO: /tts/stream %s key=%s streamed=%t", res.status, res.key, res.streamed)
+
+	if res.streamed {
+		// Split texts can finish as a partial or full hit after their first
+		// unit streamed under a provisional cache_miss, so the final status
+		// follows the body.
+		w.Header().Set(http.TrailerPrefix+"X-Cache-Status", res.status)
+		if res.fallback != "" {
+			w.Header().Set(http.TrailerPrefix+"X-Fallback-Voice", res.fallback)
+		}
+		return
+	}
+	s.serveCached(w, r, res)
+}
+
+// serveCached sends a cached wav with its length and cache status headers.
+func (s *Server) serveCached(w http.ResponseWriter, r *http.Request, res synthResult) {
+	f, err := os.Open(res.path)
+	if err != nil {
+		s.logger.Printf("ERROR: open cache file failed: %v", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "audio/wav")
+	w.Header().Set("X-Cache-Status", res.status)
+	if res.fallback != "" {
+		w.Header().Set("X-Fallback-Voice", res.fallback)
+	}
+	http.ServeContent(w, r, filepath.Base(res.path), info.ModTime(), f)
+}
+
+// httpPCMWriter sends a streaming WAV header before the first PCM bytes and
+// flushes every write so clients can start playing immediately. Its
+// X-Cache-Status is provisional; the handler sends the final one as a trailer.
+type httpPCMWriter struct {
+	w       http.ResponseWriter
+	format  audio.Format
+	started bool
+	n       int64
+}
+
+func (h *httpPCMWriter) Write(p []byte) (int, error) {
+	if !h.started {
+		h.started = true
+		h.w.Header().Set("Content-Type", "audio/wav")
+		h.w.Header().Set("X-Cache-Status", "cache_miss")
+		h.w.WriteHeader(http.StatusOK)
+		if _, err := h.w.Write(audio.StreamingHeader(h.format)); err != nil {
+			return 0, err
+		}
+	}
+	n, err := h.w.Write(p)
+	h.n += int64(n)
+	if f, ok := h.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return n, err
+}
+
+func (h *httpPCMWriter) Close() error { return nil }
@@ -0,0 +1,51 @@
+package server
+
+import (
+	"net/http"
+)
+
+// voiceInfo describes a configured voice for GET /voices.
+type voiceInfo struct {
+	Name       string   `json:"name"`
+	Engine     string   `json:"engine"`
+	Default    bool     `json:"default,omitempty"`
+	Language   string   `json:"language,omitempty"`
+	SampleRate int      `json:"sample_rate"`
+	Speakers   []string `json:"speakers,omitempty"`
+	Params     []string `json:"params,omitempty"`
+	Fallback   []string `json:"fallback,omitempty"`
+}
+
+// handleVoices lists configured voices with their engines' capabilities. A
+// voice's own language and speaker list take precedence over the engine's.
+func (s *Server) handleVoices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	voices := s.engines.Voices()
+	out := make([]voiceInfo, 0, len(voices))
+	for _, v := range voices {
+		info := voiceInfo{
+			Name:     v.Name,
+			Engine:   v.Engine,
+			Default:  v.Name == s.engines.DefaultVoice(),
+			Language: v.Language,
+			Speakers: v.Speakers,
+			Fallback: v.Fallback,
+		}
+		if eng, ok := s.engines.Engine(v.Engine); ok {
+			caps := eng.Capabilities()
+			info.SampleRate = caps.SampleRate
+			info.Params = caps.Params
+			if info.Language == "" && len(caps.Languages) > 0 {
+				info.Language = caps.Languages[0]
+			}
+			if len(info.Speakers) == 0 {
+				info.Speakers = caps.Speakers
+			}
+		}
+		out = append(out, info)
+	}
+	s.writeJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/venkytv/tts-cached/internal/cache"
+	"github.com/venkytv/tts-cached/internal/warmup"
+)
+
+// ErrWarmupRunning is returned when a warmup is requested while one is in progress.
+var ErrWarmupRunning = errors.New("warmup already running")
+
+// RunWarmup precomputes entries and blocks until every entry has been attempted.
+func (s *Server) RunWarmup(ctx context.Context, entries []warmup.Entry) (warmup.Snapshot, error) {
+	snap, ok := warmup.Run(ctx, entries, s.cfg.WarmupConcurrency, s.warmEntry, &s.warmup, s.logger)
+	if !ok {
+		return snap, ErrWarmupRunning
+	}
+	return snap, nil
+}
+
+// StartWarmup precomputes entries in the background until done or Close is called.
+func (s *Server) StartWarmup(entries []warmup.Entry) error {
+	if !warmup.Start(s.bgCtx, entries, s.cfg.WarmupConcurrency, s.warmEntry, &s.warmup, s.logger) {
+		return ErrWarmupRunning
+	}
+	return nil
+}
+
+// PrunePins unpins cache entries that entries no longer pin, so phrases
+// dropped from the phrasebook become evictable again. It returns how many
+// pins were removed.
+func (s *Server) PrunePins(ctx context.Context, entries []warmup.Entry) (int, error) {
+	want := make(map[string]bool)
+	for _, e := range entries {
+		if !e.Pin {
+			continue
+		}
+		key, _, err := s.warmKey(ctx, e)
+		if err != nil {
+			return 0, fmt.Errorf("%q: %w", e.Text, err)
+		}
+		want[key] = true
+	}
+	pinned, err := s.cache.Pinned()
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	for _, key := range pinned {
+		if want[key] {
+			continue
+		}
+		if err := s.cache.Unpin(key); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// warmKey returns the text synthesized for a warmup entry and the key it is
+// cached under.
+func (s *Server) warmKey(ctx context.Context, e warmup.Entry) (key, text string, err error) {
+	text = s.redact(normalizeText(e.Text))
+	if text == "" {
+		return "", "", errors.New("text is required")
+	}
+	if text, err = s.prepare(ctx, text, e.Voice); err != nil {
+		return "", "", err
+	}
+	v, _, err := s.engines.Resolve(e.Voice)
+	if err != nil {
+		return "", "", err
+	}
+	return cache.BuildKey(v.Name, text), text, nil
+}
+
+func (s *Server) warmEntry(ctx context.Context, e warmup.Entry) (string, error) {
+	key, text, err := s.warmKey(ctx, e)
+	if err != nil {
+		return "", err
+	}
+	if e.Pin {
+		// Pin before synthesis so no eviction pass, including the one that
+		// commits this entry, can remove it first.
+		if err := s.cache.Pin(key); err != nil {
+			return "", fmt.Errorf("pin entry: %w", err)
+		}
+	}
+	// Warm the voice itself; fallback audio would only mask a broken engine.
+	res, err := s.ensureCached(ctx, synthJob{text: text, voice: e.Voice, background: true, exact: true})
+	if err != nil {
+		return "", err
+	}
+	return res.status, nil
+}
+
+// handleWarmup reports warmup progress (GET) or starts a warmup from a
+// phrasebook posted as the request body (POST).
+func (s *Server) handleWarmup(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.writeJSON(w, http.StatusOK, s.warmup.Snapshot())
+	case http.MethodPost:
+		yaml := strings.Contains(r.Header.Get("Content-Type"), "yaml")
+		entries, err := warmup.ParsePhrasebook(io.LimitReader(r.Body, 1<<20), yaml)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid phrasebook: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := s.StartWarmup(entries); err != nil {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		s.logger.Printf("INFO: /warmup started entries=%d", len(entries))
+		s.writeJSON(w, http.StatusAccepted, s.warmup.Snapshot())
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
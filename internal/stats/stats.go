@@ -0,0 +1,396 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	bucketCount  = 24 * 60 // one bucket per minute for a day
+	evictionDays = 30
+)
+
+// Recorder aggregates cache hit/miss and eviction events; safe for concurrent use.
+type Recorder struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	keys      map[string]*keyStat
+	buckets   [bucketCount]bucket
+	hits      int64
+	misses    int64
+	evictions map[string]*DayEvictions
+	// pending holds the events since the last Save, which merges them into
+	// the file other daemons sharing the cache directory also save to.
+	pending persisted
+}
+
+type keyStat struct {
+	Text  string `json:"text"`
+	Plays int64  `json:"plays"`
+	Size  int64  `json:"size"`
+}
+
+type bucket struct {
+	Minute int64 `json:"minute"`
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
+// DayEvictions counts evictions for a UTC day (YYYY-MM-DD).
+type DayEvictions struct {
+	Day   string `json:"day"`
+	Count int64  `json:"count"`
+	Bytes int64  `json:"bytes"`
+}
+
+// Window is a hit ratio over a time window.
+type Window struct {
+	Hits   int64   `json:"hits"`
+	Misses int64   `json:"misses"`
+	Ratio  float64 `json:"ratio"`
+}
+
+// Phrase is a frequently played cache entry.
+type Phrase struct {
+	Key   string `json:"key"`
+	Text  string `json:"text"`
+	Plays int64  `json:"plays"`
+	Size  int64  `json:"size"`
+}
+
+// Report is the analytics view served on /cache/stats.
+type Report struct {
+	TotalBytes       int64             `json:"total_bytes"`
+	MaxBytes         int64             `json:"max_bytes"`
+	Entries          int               `json:"entries"`
+	HitRatio         map[string]Window `json:"hit_ratio"`
+	TopPhrases       []Phrase          `json:"top_phrases"`
+	SingleHitEntries int               `json:"single_hit_entries"`
+	SingleHitBytes   int64             `json:"single_hit_bytes"`
+	Evictions        []DayEvictions    `json:"evictions"`
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{
+		now:       time.Now,
+		keys:      make(map[string]*keyStat),
+		evictions: make(map[string]*DayEvictions),
+		pending:   newPersisted(),
+	}
+}
+
+// Record notes a played entry; hit reports whether it was served from a cache
+// rather than synthesized.
+func (r *Recorder) Record(key, text string, size int64, hit bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ev := persisted{
+		Keys:    map[string]*keyStat{key: {Text: text, Plays: 1, Size: size}},
+		Buckets: []bucket{{Minute: r.now().Unix() / 60}},
+	}
+	if hit {
+		ev.Buckets[0].Hits, ev.Hits = 1, 1
+	} else {
+		ev.Buckets[0].Misses, ev.Misses = 1, 1
+	}
+	r.apply(ev)
+	r.pending.add(ev)
+}
+
+// RecordEviction notes that key was evicted to enforce the cache limit.
+func (r *Recorder) RecordEviction(key string, size int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	day := r.now().UTC().Format("2006-01-02")
+	ev := persisted{
+		Evictions: map[string]*DayEvictions{day: {Day: day, Count: 1, Bytes: size}},
+		Evicted:   []string{key},
+	}
+	r.apply(ev)
+	r.pending.add(ev)
+}
+
+// apply adds the counts in p to the recorder.
+func (r *Recorder) apply(p persisted) {
+	for _, key := range p.Evicted {
+		delete(r.keys, key)
+	}
+	for key, v := range p.Keys {
+		ks, ok := r.keys[key]
+		if !ok {
+			ks = &keyStat{}
+			r.keys[key] = ks
+		}
+		ks.Text = v.Text
+		ks.Plays += v.Plays
+		if v.Size > 0 {
+			ks.Size = v.Size
+		}
+	}
+	for _, v := range p.Buckets {
+		b := &r.buckets[v.Minute%bucketCount]
+		switch {
+		case b.Minute == v.Minute:
+			b.Hits += v.Hits
+			b.Misses += v.Misses
+		case b.Minute < v.Minute:
+			*b = v
+		}
+	}
+	r.hits += p.Hits
+	r.misses += p.Misses
+	for day, v := range p.Evictions {
+		d, ok := r.evictions[day]
+		if !ok {
+			d = &DayEvictions{Day: day}
+			r.evictions[day] = d
+		}
+		d.Count += v.Count
+		d.Bytes += v.Bytes
+	}
+	r.pruneEvictions()
+}
+
+func (r *Recorder) pruneEvictions() {
+	if len(r.evictions) <= evictionDays {
+		return
+	}
+	days := make([]string, 0, len(r.evictions))
+	for day := range r.evictions {
+		days = append(days, day)
+	}
+	sort.Strings(days)
+	for _, day := range days[:len(days)-evictionDays] {
+		delete(r.evictions, day)
+	}
+}
+
+// Report builds an analytics report; totalBytes, maxBytes and entries describe the
+// cache on disk and topN bounds the phrase list.
+func (r *Recorder) Report(totalBytes, maxBytes int64, entries, topN int) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rep := Report{
+		TotalBytes: totalBytes,
+		MaxBytes:   maxBytes,
+		Entries:    entries,
+		HitRatio: map[string]Window{
+			"1h":  r.window(60),
+			"24h": r.window(bucketCount),
+			"all": newWindow(r.hits, r.misses),
+		},
+		TopPhrases: []Phrase{},
+		Evictions:  []DayEvictions{},
+	}
+
+	phrases := make([]Phrase, 0, len(r.keys))
+	for key, ks := range r.keys {
+		phrases = append(phrases, Phrase{Key: key, Text: ks.Text, Plays: ks.Plays, Size: ks.Size})
+		if ks.Plays == 1 {
+			rep.SingleHitEntries++
+			rep.SingleHitBytes += ks.Size
+		}
+	}
+	sort.Slice(phrases, func(i, j int) bool {
+		if phrases[i].Plays != phrases[j].Plays {
+			return phrases[i].Plays > phrases[j].Plays
+		}
+		return phrases[i].Text < phrases[j].Text
+	})
+	if len(phrases) > topN {
+		phrases = phrases[:topN]
+	}
+	rep.TopPhrases = append(rep.TopPhrases, phrases...)
+
+	for _, d := range r.evictions {
+		rep.Evictions = append(rep.Evictions, *d)
+	}
+	sort.Slice(rep.Evictions, func(i, j int) bool { return rep.Evictions[i].Day > rep.Evictions[j].Day })
+	return rep
+}
+
+func (r *Recorder) window(minutes int64) Window {
+	now := r.now().Unix() / 60
+	var hits, misses int64
+	for _, b := range r.buckets {
+		if b.Minute > now-minutes && b.Minute <= now {
+			hits += b.Hits
+			misses += b.Misses
+		}
+	}
+	return newWindow(hits, misses)
+}
+
+func newWindow(hits, misses int64) Window {
+	w := Window{Hits: hits, Misses: misses}
+	if total := hits + misses; total > 0 {
+		w.Ratio = float64(hits) / float64(total)
+	}
+	return w
+}
+
+type persisted struct {
+	Keys      map[string]*keyStat      `json:"keys"`
+	Buckets   []bucket                 `json:"buckets"`
+	Hits      int64                    `json:"hits"`
+	Misses    int64                    `json:"misses"`
+	Evictions map[string]*DayEvictions `json:"evictions"`
+	// Evicted lists keys evicted since the last save; it is never written.
+	Evicted []string `json:"-"`
+}
+
+func newPersisted() persisted {
+	return persisted{Keys: make(map[string]*keyStat), Evictions: make(map[string]*DayEvictions)}
+}
+
+// add accumulates the events in q into p.
+func (p *persisted) add(q persisted) {
+	for _, key := range q.Evicted {
+		delete(p.Keys, key)
+	}
+	p.Evicted = append(p.Evicted, q.Evicted...)
+	for key, v := range q.Keys {
+		ks, ok := p.Keys[key]
+		if !ok {
+			ks = &keyStat{}
+			p.Keys[key] = ks
+		}
+		ks.Text = v.Text
+		ks.Plays += v.Plays
+		if v.Size > 0 {
+			ks.Size = v.Size
+		}
+	}
+	for _, v := range q.Buckets {
+		merged := false
+		for i := range p.Buckets {
+			if p.Buckets[i].Minute == v.Minute {
+				p.Buckets[i].Hits += v.Hits
+				p.Buckets[i].Misses += v.Misses
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			p.Buckets = append(p.Buckets, v)
+		}
+	}
+	p.Hits += q.Hits
+	p.Misses += q.Misses
+	for day, v := range q.Evictions {
+		d, ok := p.Evictions[day]
+		if !ok {
+			d = &DayEvictions{Day: day}
+			p.Evictions[day] = d
+		}
+		d.Count += v.Count
+		d.Bytes += v.Bytes
+	}
+}
+
+// Save merges the events recorded since the last Save into the counts stored
+// at path, so daemons sharing a cache directory add to each other's counts
+// rather than overwrite them, and reloads the merged counts. Callers in
+// different processes must serialise Save on the same path.
+func (r *Recorder) Save(path string) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = newPersisted()
+	r.mu.Unlock()
+
+	merged, err := Load(path)
+	var syntaxErr *json.SyntaxError
+	if err != nil && !errors.As(err, &syntaxErr) {
+		// Keep the events for the next attempt; a corrupt file is replaced.
+		r.requeue(pending)
+		return err
+	}
+	merged.apply(pending)
+
+	state := persisted{Keys: merged.keys, Hits: merged.hits, Misses: merged.misses, Evictions: merged.evictions}
+	for _, b := range merged.buckets {
+		if b.Minute != 0 {
+			state.Buckets = append(state.Buckets, b)
+		}
+	}
+	data, err := json.Marshal(state)
+	if err == nil {
+		err = writeFile(path, data)
+	}
+	if err != nil {
+		r.requeue(pending)
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys, r.buckets, r.hits, r.misses, r.evictions = merged.keys, merged.buckets, merged.hits, merged.misses, merged.evictions
+	// Events recorded while saving are in the file's counts next time.
+	r.apply(r.pending)
+	return nil
+}
+
+// requeue returns unsaved events to pending, ahead of any recorded since.
+func (r *Recorder) requeue(p persisted) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p.add(r.pending)
+	r.pending = p
+}
+
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load returns a recorder restored from path, or an empty one if path does not exist.
+func Load(path string) (*Recorder, error) {
+	r := NewRecorder()
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return r, err
+	}
+
+	var state persisted
+	if err := json.Unmarshal(data, &state); err != nil {
+		return r, err
+	}
+	for k, v := range state.Keys {
+		if v != nil {
+			r.keys[k] = v
+		}
+	}
+	for _, b := range state.Buckets {
+		r.buckets[b.Minute%bucketCount] = b
+	}
+	for k, v := range state.Evictions {
+		if v != nil {
+			r.evictions[k] = v
+		}
+	}
+	r.hits, r.misses = state.Hits, state.Misses
+	return r, nil
+}
@@ -0,0 +1,116 @@
+package stats
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReportWindowsTopAndSingleHits(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	r := NewRecorder()
+	r.now = func() time.Time { return now }
+
+	// Two hours ago: outside the 1h window, inside 24h.
+	now = now.Add(-2 * time.Hour)
+	r.Record("k1", "doorbell", 100, false)
+	now = now.Add(2 * time.Hour)
+
+	r.Record("k1", "doorbell", 100, true)
+	r.Record("k1", "doorbell", 100, true)
+	r.Record("k2", "lunch", 40, false)
+	r.Record("k3", "once", 30, false)
+	r.RecordEviction("k3", 30)
+
+	rep := r.Report(500, 1000, 2, 1)
+
+	if w := rep.HitRatio["1h"]; w.Hits != 2 || w.Misses != 2 {
+		t.Fatalf("unexpected 1h window %+v", w)
+	}
+	if w := rep.HitRatio["24h"]; w.Hits != 2 || w.Misses != 3 || w.Ratio != 0.4 {
+		t.Fatalf("unexpected 24h window %+v", w)
+	}
+	if len(rep.TopPhrases) != 1 || rep.TopPhrases[0].Text != "doorbell" || rep.TopPhrases[0].Plays != 3 {
+		t.Fatalf("unexpected top phrases %+v", rep.TopPhrases)
+	}
+	if rep.SingleHitEntries != 1 || rep.SingleHitBytes != 40 {
+		t.Fatalf("unexpected single-hit stats %d/%d", rep.SingleHitEntries, rep.SingleHitBytes)
+	}
+	if len(rep.Evictions) != 1 || rep.Evictions[0].Day != "2026-10-18" || rep.Evictions[0].Bytes != 30 {
+		t.Fatalf("unexpected evictions %+v", rep.Evictions)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	r := NewRecorder()
+	r.Record("k1", "doorbell", 100, true)
+	r.Record("k2", "lunch", 40, false)
+	r.RecordEviction("k2", 40)
+	if err := r.Save(path); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	rep := loaded.Report(0, 0, 0, 10)
+	if w := rep.HitRatio["1h"]; w.Hits != 1 || w.Misses != 1 {
+		t.Fatalf("window not restored: %+v", w)
+	}
+	if len(rep.TopPhrases) != 1 || rep.TopPhrases[0].Key != "k1" {
+		t.Fatalf("phrases not restored: %+v", rep.TopPhrases)
+	}
+	if len(rep.Evictions) != 1 {
+		t.Fatalf("evictions not restored: %+v", rep.Evictions)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	r, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || r == nil {
+		t.Fatalf("expected empty recorder, got %v", err)
+	}
+}
+
+func TestSaveMergesRecordersSharingAFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+	a, b := NewRecorder(), NewRecorder()
+	a.Record("k1", "doorbell", 100, true)
+	b.Record("k1", "doorbell", 100, false)
+	b.Record("k2", "lunch", 40, true)
+	if err := a.Save(path); err != nil {
+		t.Fatalf("save a: %v", err)
+	}
+	if err := b.Save(path); err != nil {
+		t.Fatalf("save b: %v", err)
+	}
+	a.Record("k1", "doorbell", 100, true)
+	a.RecordEviction("k2", 40)
+	if err := a.Save(path); err != nil {
+		t.Fatalf("save a again: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	rep := loaded.Report(0, 0, 0, 10)
+	if w := rep.HitRatio["all"]; w.Hits != 3 || w.Misses != 1 {
+		t.Fatalf("counts not merged: %+v", w)
+	}
+	if w := rep.HitRatio["1h"]; w.Hits != 3 || w.Misses != 1 {
+		t.Fatalf("windows not merged: %+v", w)
+	}
+	if len(rep.TopPhrases) != 1 || rep.TopPhrases[0].Key != "k1" || rep.TopPhrases[0].Plays != 3 {
+		t.Fatalf("phrases not merged: %+v", rep.TopPhrases)
+	}
+	if len(rep.Evictions) != 1 || rep.Evictions[0].Count != 1 {
+		t.Fatalf("evictions not merged: %+v", rep.Evictions)
+	}
+	// The saving recorder reports the merged counts too.
+	if w := a.Report(0, 0, 0, 10).HitRatio["all"]; w.Hits != 3 || w.Misses != 1 {
+		t.Fatalf("recorder not refreshed: %+v", w)
+	}
+}
@@ -0,0 +1,74 @@
+// Package textfilter runs an external command that rewrites text before
+// synthesis.
+package textfilter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ErrEmpty is returned when the filter prints no text.
+var ErrEmpty = errors.New("filter returned no text")
+
+// maxStderr bounds the filter output quoted in errors.
+const maxStderr = 200
+
+// waitDelay bounds how long Apply waits for the filter's output to close once
+// the command has been killed; children it started may still hold it open.
+const waitDelay = 100 * time.Millisecond
+
+// Filter pipes text through a command: the text is written to its stdin and
+// its stdout replaces it.
+type Filter struct {
+	cmd     string
+	args    []string
+	timeout time.Duration
+	logger  *log.Logger
+}
+
+// New constructs a Filter that kills cmd after timeout.
+func New(cmd string, args []string, timeout time.Duration, logger *log.Logger) *Filter {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &Filter{cmd: cmd, args: args, timeout: timeout, logger: logger}
+}
+
+// Apply runs the filter on text. It fails if the command exits non-zero, runs
+// past the timeout or prints only whitespace.
+func (f *Filter) Apply(ctx context.Context, text string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, f.cmd, f.args...)
+	cmd.WaitDelay = waitDelay
+	cmd.Stdin = strings.NewReader(text)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	start := time.Now()
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", fmt.Errorf("filter %s timed out after %s", f.cmd, f.timeout)
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > maxStderr {
+			msg = msg[:maxStderr] + "..."
+		}
+		if msg != "" {
+			return "", fmt.Errorf("filter %s: %w: %s", f.cmd, err, msg)
+		}
+		return "", fmt.Errorf("filter %s: %w", f.cmd, err)
+	}
+	out := strings.TrimSpace(stdout.String())
+	if out == "" {
+		return "", ErrEmpty
+	}
+	f.logger.Printf("INFO: filter %s took %s", f.cmd, time.Since(start).Round(time.Millisecond))
+	return out, nil
+}
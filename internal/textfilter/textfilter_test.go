@@ -0,0 +1,81 @@
+package textfilter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+var logDiscard = log.New(io.Discard, "", 0)
+
+func helper(mode string, timeout time.Duration) *Filter {
+	return New(os.Args[0], []string{"-test.run=^TestHelperProcess$", "--", mode}, timeout, logDiscard)
+}
+
+func TestApplyReplacesText(t *testing.T) {
+	t.Setenv("TEXTFILTER_HELPER", "1")
+	got, err := helper("upper", 5*time.Second).Apply(context.Background(), "ship v2 today")
+	if err != nil || got != "SHIP V2 TODAY" {
+		t.Fatalf("Apply = %q, %v", got, err)
+	}
+}
+
+func TestApplyFailures(t *testing.T) {
+	t.Setenv("TEXTFILTER_HELPER", "1")
+	if _, err := helper("fail", 5*time.Second).Apply(context.Background(), "x"); err == nil || !strings.Contains(err.Error(), "bad input") {
+		t.Fatalf("expected exit failure with stderr, got %v", err)
+	}
+	if _, err := helper("empty", 5*time.Second).Apply(context.Background(), "x"); !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+	if _, err := helper("hang", 100*time.Millisecond).Apply(context.Background(), "x"); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+}
+
+func TestApplyTimeoutIgnoresChildren(t *testing.T) {
+	t.Setenv("TEXTFILTER_HELPER", "1")
+	start := time.Now()
+	_, err := helper("spawn", 200*time.Millisecond).Apply(context.Background(), "x")
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+	// A child holding the output open must not keep Apply waiting.
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Apply returned after %s, want about 200ms", elapsed)
+	}
+}
+
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("TEXTFILTER_HELPER") == "" {
+		return
+	}
+	data, _ := io.ReadAll(os.Stdin)
+	switch os.Args[len(os.Args)-1] {
+	case "upper":
+		fmt.Println(strings.ToUpper(string(data)))
+	case "fail":
+		fmt.Fprintln(os.Stderr, "bad input")
+		os.Exit(3)
+	case "hang":
+		time.Sleep(10 * time.Second)
+	case "spawn":
+		// The child inherits stdout and outlives this process.
+		child := exec.Command(os.Args[0], "-test.run=^TestHelperProcess$", "--", "nap")
+		child.Stdout = os.Stdout
+		if err := child.Start(); err != nil {
+			os.Exit(4)
+		}
+		time.Sleep(10 * time.Second)
+	case "nap":
+		time.Sleep(3 * time.Second)
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,116 @@
+package textformat
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlTag = regexp.MustCompile(`^<(/?)([A-Za-z][A-Za-z0-9-]*)((?:[^>"']|"[^"]*"|'[^']*')*)>`)
+	htmlAlt = regexp.MustCompile(`(?i)\balt\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`)
+)
+
+// htmlSkipped elements are dropped with their content.
+var htmlSkipped = map[string]bool{
+	"script": true, "style": true, "head": true, "title": true, "template": true,
+	"svg": true, "math": true, "noscript": true, "iframe": true, "object": true,
+	"select": true, "textarea": true, "button": true,
+}
+
+// htmlBlocks elements start and end a sentence.
+var htmlBlocks = map[string]bool{
+	"html": true, "body": true, "p": true, "div": true, "section": true,
+	"article": true, "header": true, "footer": true, "main": true, "nav": true,
+	"aside": true, "blockquote": true, "address": true, "center": true,
+	"figure": true, "figcaption": true, "ul": true, "ol": true, "li": true,
+	"dl": true, "dt": true, "dd": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "table": true, "caption": true,
+	"thead": true, "tbody": true, "tfoot": true, "tr": true, "hr": true,
+	"form": true, "fieldset": true, "legend": true, "details": true,
+	"summary": true, "pre": true,
+}
+
+// FromHTML extracts speakable text from an HTML document or fragment.
+// Preformatted text is kept unless it holds code.
+func FromHTML(src string) string {
+	var b blocks
+	skip := ""     // element whose content is being dropped
+	inPre := false // inside <pre> with no text yet
+	text := func(s string) {
+		if skip != "" {
+			return
+		}
+		if strings.TrimSpace(s) != "" {
+			inPre = false
+		}
+		b.write(html.UnescapeString(s))
+	}
+
+	for src != "" {
+		i := strings.IndexByte(src, '<')
+		if i < 0 {
+			text(src)
+			break
+		}
+		text(src[:i])
+		src = src[i:]
+
+		switch {
+		case strings.HasPrefix(src, "<!--"):
+			if end := strings.Index(src, "-->"); end >= 0 {
+				src = src[end+3:]
+			} else {
+				src = ""
+			}
+			continue
+		case strings.HasPrefix(src, "<!"), strings.HasPrefix(src, "<?"):
+			if end := strings.IndexByte(src, '>'); end >= 0 {
+				src = src[end+1:]
+			} else {
+				src = ""
+			}
+			continue
+		}
+		m := htmlTag.FindStringSubmatch(src)
+		if m == nil {
+			text("<")
+			src = src[1:]
+			continue
+		}
+		src = src[len(m[0]):]
+		closing, name, attrs := m[1] == "/", strings.ToLower(m[2]), m[3]
+
+		if skip != "" {
+			if closing && name == skip {
+				skip = ""
+			}
+			continue
+		}
+		switch {
+		case htmlSkipped[name]:
+			if !closing && !strings.HasSuffix(attrs, "/") {
+				skip = name
+			}
+		case name == "code" && inPre && !closing:
+			b.add(CodeNote)
+			skip, inPre = "pre", false
+		case name == "br":
+			b.write(" ")
+		case name == "img":
+			if a := htmlAlt.FindStringSubmatch(attrs); a != nil {
+				b.write(" " + html.UnescapeString(a[1]+a[2]+a[3]) + " ")
+			}
+		case name == "td" || name == "th":
+			// Cells of a row are read as a list.
+			if row := strings.TrimSpace(b.cur.String()); !closing && row != "" && !strings.HasSuffix(row, ",") {
+				b.cur.Reset()
+				b.write(row + ", ")
+			}
+		case htmlBlocks[name]:
+			b.end()
+			inPre = name == "pre" && !closing
+		}
+	}
+	return b.String()
+}
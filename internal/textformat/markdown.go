@@ -0,0 +1,190 @@
+package textformat
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdFence     = regexp.MustCompile("^(`{3,}|~{3,})")
+	mdHeading   = regexp.MustCompile(`^#{1,6}(?:\s+(.*?))?(?:\s+#+)?\s*$`)
+	mdSetext    = regexp.MustCompile(`^(?:=+|-+)$`)
+	mdRule      = regexp.MustCompile(`^(?:(?:\*\s*){3,}|(?:-\s*){3,}|(?:_\s*){3,})$`)
+	mdListItem  = regexp.MustCompile(`^(?:[-*+]|\d{1,9}[.)])\s+(?:\[[ xX]\]\s+)?(.*)$`)
+	mdRefDef    = regexp.MustCompile(`^\[[^\]]+\]:\s*\S+`)
+	mdTableSep  = regexp.MustCompile(`^\|?\s*:?-+:?\s*(?:\|\s*:?-+:?\s*)*\|?$`)
+	mdEscape    = regexp.MustCompile("\\\\([!-/:-@\\[-`{-~])")
+	mdImage     = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLink      = regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`)
+	mdRefLink   = regexp.MustCompile(`\[([^\]]+)\]\[[^\]=]*\]`)
+	mdFootnote  = regexp.MustCompile(`\[\^[^\]]+\]`)
+	mdAutolink  = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+|[^@>\s]+@[^>\s]+)>`)
+	mdTag       = regexp.MustCompile(`</?[A-Za-z][^>]*>`)
+	mdStrong    = regexp.MustCompile(`\*\*([^*\s](?:[^*]*[^*\s])?)\*\*`)
+	mdStrike    = regexp.MustCompile(`~~([^~\s](?:[^~]*[^~\s])?)~~`)
+	mdEmphasis  = regexp.MustCompile(`\*([^*\s](?:[^*]*[^*\s])?)\*`)
+	mdUnderline = regexp.MustCompile(`(^|[^\w])__?([^_\s](?:[^_]*[^_\s])?)__?([^\w]|$)`)
+)
+
+// escapeBase shifts escaped ASCII punctuation into the private use area so
+// inline markup rules leave it alone.
+const escapeBase = 0xE000
+
+// FromMarkdown extracts speakable text from Markdown.
+func FromMarkdown(src string) string {
+	var b blocks
+	var para []string
+	flush := func() {
+		b.write(mdInline(strings.Join(para, " ")))
+		b.end()
+		para = nil
+	}
+
+	fence := ""
+	// code is set inside an indented code block; list while list items may
+	// still continue, where an indent belongs to the item instead.
+	code, list := false, false
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		indented := strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t")
+		line = strings.TrimSpace(line)
+		if fence == "" && line != "" {
+			switch {
+			case indented && (code || len(para) == 0 && !list):
+				if !code {
+					b.add(CodeNote)
+					code = true
+				}
+				continue
+			case !indented:
+				code = false
+				list = mdListItem.MatchString(line)
+			}
+		}
+		if code {
+			continue
+		}
+		for strings.HasPrefix(line, ">") {
+			line = strings.TrimSpace(line[1:])
+		}
+		if fence != "" {
+			if strings.HasPrefix(line, fence) && strings.Trim(line, fence[:1]) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if m := mdFence.FindStringSubmatch(line); m != nil {
+			flush()
+			fence = m[1]
+			b.add(CodeNote)
+			continue
+		}
+
+		switch {
+		case line == "":
+			flush()
+		case len(para) > 0 && mdSetext.MatchString(line):
+			// The paragraph so far was a heading.
+			flush()
+		case mdRule.MatchString(line), mdRefDef.MatchString(line):
+			flush()
+		case mdHeading.MatchString(line):
+			flush()
+			b.add(mdInline(mdHeading.FindStringSubmatch(line)[1]))
+		case mdListItem.MatchString(line):
+			flush()
+			para = append(para, mdListItem.FindStringSubmatch(line)[1])
+		case strings.HasPrefix(line, "|"):
+			flush()
+			if !mdTableSep.MatchString(line) {
+				b.add(mdRow(line))
+			}
+		default:
+			para = append(para, line)
+		}
+	}
+	flush()
+	return b.String()
+}
+
+// mdRow joins a table row's cells into a list.
+func mdRow(line string) string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	var cells []string
+	for _, cell := range strings.Split(line, "|") {
+		if cell = strings.TrimSpace(mdInline(cell)); cell != "" {
+			cells = append(cells, cell)
+		}
+	}
+	return strings.Join(cells, ", ")
+}
+
+// mdInline removes inline markup: code spans keep their text, images their
+// alt text and links their text; emphasis markers and HTML tags are dropped.
+func mdInline(s string) string {
+	s = mdEscape.ReplaceAllStringFunc(s, func(m string) string {
+		return string(rune(escapeBase + int(m[1])))
+	})
+	s = mdCodeSpans(s)
+	s = mdImage.ReplaceAllString(s, "$1")
+	s = mdLink.ReplaceAllString(s, "$1")
+	s = mdRefLink.ReplaceAllString(s, "$1")
+	s = mdFootnote.ReplaceAllString(s, "")
+	s = mdAutolink.ReplaceAllString(s, "$1")
+	s = mdTag.ReplaceAllString(s, "")
+	s = mdStrong.ReplaceAllString(s, "$1")
+	s = mdStrike.ReplaceAllString(s, "$1")
+	s = mdEmphasis.ReplaceAllString(s, "$1")
+	s = mdUnderline.ReplaceAllString(s, "$1$2$3")
+	s = html.UnescapeString(s)
+	return strings.Map(func(r rune) rune {
+		if r >= escapeBase && r < escapeBase+0x80 {
+			return r - escapeBase
+		}
+		return r
+	}, s)
+}
+
+// mdCodeSpans replaces code spans with their text, shielding it from the
+// inline markup rules. A span closes at the next run of as many backticks as
+// opened it; an unmatched run is literal.
+func mdCodeSpans(s string) string {
+	var out strings.Builder
+	for {
+		i := strings.IndexByte(s, '`')
+		if i < 0 {
+			out.WriteString(s)
+			return out.String()
+		}
+		out.WriteString(s[:i])
+		s = s[i:]
+		n := len(s) - len(strings.TrimLeft(s, "`"))
+		fence, rest := s[:n], s[n:]
+		end := -1
+		for j := 0; j < len(rest); {
+			k := strings.Index(rest[j:], fence)
+			if k < 0 {
+				break
+			}
+			k += j
+			m := len(rest[k:]) - len(strings.TrimLeft(rest[k:], "`"))
+			if m == n {
+				end = k
+				break
+			}
+			j = k + m
+		}
+		if end < 0 {
+			out.WriteString(fence)
+			s = rest
+			continue
+		}
+		out.WriteString(strings.Map(func(r rune) rune {
+			if r < 0x80 && strings.ContainsRune("*_~[]<>!\\", r) {
+				return escapeBase + r
+			}
+			return r
+		}, strings.TrimSpace(rest[:end])))
+		s = rest[end+n:]
+	}
+}
@@ -0,0 +1,99 @@
+// Package textformat extracts speakable text from Markdown and HTML.
+// Headings, list items, table rows and paragraphs become sentences, code
+// blocks are replaced by a short note, and links keep their text but lose
+// their URLs.
+package textformat
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Input formats.
+const (
+	Plain    = "plain"
+	Markdown = "markdown"
+	HTML     = "html"
+)
+
+// CodeNote is spoken in place of a code block.
+const CodeNote = "Code block omitted."
+
+// Convert extracts speakable text from text in format. An empty format is
+// plain text, which is returned unchanged.
+func Convert(text, format string) (string, error) {
+	switch format {
+	case "", Plain:
+		return text, nil
+	case Markdown:
+		return FromMarkdown(text), nil
+	case HTML:
+		return FromHTML(text), nil
+	}
+	return "", fmt.Errorf("unknown format %q (known: %s, %s, %s)", format, Plain, Markdown, HTML)
+}
+
+// ForFile guesses the format of a file from its extension, returning "" when
+// the extension is not a known one.
+func ForFile(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		return Markdown
+	case ".html", ".htm":
+		return HTML
+	case ".txt":
+		return Plain
+	}
+	return ""
+}
+
+// blocks collects sentences, one per block of the source document.
+type blocks struct {
+	out []string
+	cur strings.Builder
+}
+
+func (b *blocks) write(s string) {
+	b.cur.WriteString(s)
+}
+
+// end finishes the current block as a sentence.
+func (b *blocks) end() {
+	s := sentence(b.cur.String())
+	b.cur.Reset()
+	if s != "" {
+		b.out = append(b.out, s)
+	}
+}
+
+// add appends s as a block of its own.
+func (b *blocks) add(s string) {
+	b.end()
+	b.write(s)
+	b.end()
+}
+
+func (b *blocks) String() string {
+	b.end()
+	return strings.Join(b.out, "\n")
+}
+
+// sentence collapses whitespace in s and ends it with a full stop unless it
+// already ends in punctuation.
+func sentence(s string) string {
+	s = strings.TrimRight(strings.Join(strings.Fields(s), " "), ",")
+	if s == "" {
+		return ""
+	}
+	last, size := utf8.DecodeLastRuneInString(s)
+	if strings.ContainsRune(`"')]`+"”’", last) {
+		// Look past a closing quote or bracket: `He said "hi."`.
+		last, _ = utf8.DecodeLastRuneInString(s[:len(s)-size])
+	}
+	if strings.ContainsRune(".!?:;…", last) {
+		return s
+	}
+	return s + "."
+}
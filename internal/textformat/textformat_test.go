@@ -0,0 +1,84 @@
+package textformat
+
+import "testing"
+
+func TestFromMarkdown(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"heading", "# Install\nRun it", "Install.\nRun it."},
+		{"setext heading", "Usage\n=====\nRun it.", "Usage.\nRun it."},
+		{"list items", "- first\n* second *item*\n  continued\n1. one\n2) [x] two", "first.\nsecond item continued.\none.\ntwo."},
+		{"fenced code", "Try:\n```go\nfmt.Println(\"*x*\")\n```\nDone", "Try:\nCode block omitted.\nDone."},
+		{"indented code", "Run:\n\n    make build\n\n\tmake test\nDone", "Run:\nCode block omitted.\nDone."},
+		{"indented continuations", "- item\n\n    more of it\nwrapped\n    lazily", "item.\nmore of it wrapped lazily."},
+		{"unclosed fence", "Intro\n~~~\ncode", "Intro.\nCode block omitted."},
+		{"links", "See [the docs](https://example.com/docs) and ![logo](logo.png) [here][ref].\n\n[ref]: https://example.com", "See the docs and logo here."},
+		{"autolink", "Visit <https://example.com>", "Visit https://example.com."},
+		{"emphasis", "**bold**, *em*, __strong__, _em_ and ~~gone~~ snake_case_name", "bold, em, strong, em and gone snake_case_name."},
+		{"inline code", "Use `a*b*c` or ``x`y``", "Use a*b*c or x`y."},
+		{"escapes", `\*not emphasis\* and \[not a link\](x)`, "*not emphasis* and [not a link](x)."},
+		{"table", "| Name | Age |\n|:---|---:|\n| Bob | 3 |", "Name, Age.\nBob, 3."},
+		{"blockquote and rule", "> quoted\n> text\n\n---\nafter", "quoted text.\nafter."},
+		{"html and entities", "A <b>bold</b> claim &amp; a footnote[^1]", "A bold claim & a footnote."},
+		{"hashtag", "#release is out!", "#release is out!"},
+		{"dialogue markup", "[voice=amy][pause=1s] Hello", "[voice=amy][pause=1s] Hello."},
+		{"quoted ending", `He said "hi."`, `He said "hi."`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := FromMarkdown(tc.in); got != tc.want {
+				t.Fatalf("FromMarkdown(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFromHTML(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"document", "<!DOCTYPE html><html><head><title>Mail</title><style>p{}</style></head><body><h1>Hello&nbsp;there</h1><p>Click <a href=\"https://example.com\">here</a> now</p></body></html>", "Hello there.\nClick here now."},
+		{"list", "<ul><li>one</li><li>two,</li></ul>", "one.\ntwo."},
+		{"code", "<p>Run:</p><pre><code>x = 1 &lt; 2</code></pre><pre>kept as text</pre>", "Run:\nCode block omitted.\nkept as text."},
+		{"table", "<table><tr><th>Name</th><th>Age</th></tr><tr><td>Bob </td><td>3</td></tr></table>", "Name, Age.\nBob, 3."},
+		{"scripts and comments", "Hi<script>alert('<p>')</script><!-- hidden --> there", "Hi there."},
+		{"images and breaks", "Thanks,<br>Bob <img src=x.png alt=\"waving hand\">", "Thanks, Bob waving hand."},
+		{"stray brackets", "a < b and c<3", "a < b and c<3."},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := FromHTML(tc.in); got != tc.want {
+				t.Fatalf("FromHTML(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	for _, format := range []string{"", Plain} {
+		if got, err := Convert("# *as is*", format); err != nil || got != "# *as is*" {
+			t.Fatalf("Convert(%q) = %q, %v", format, got, err)
+		}
+	}
+	if got, err := Convert("# Title", Markdown); err != nil || got != "Title." {
+		t.Fatalf("Convert(markdown) = %q, %v", got, err)
+	}
+	if got, err := Convert("<p>Title</p>", HTML); err != nil || got != "Title." {
+		t.Fatalf("Convert(html) = %q, %v", got, err)
+	}
+	if _, err := Convert("x", "rtf"); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+}
+
+func TestForFile(t *testing.T) {
+	for path, want := range map[string]string{
+		"README.md": Markdown, "notes.MARKDOWN": Markdown, "mail.htm": HTML,
+		"page.html": HTML, "a.txt": Plain, "data.json": "",
+	} {
+		if got := ForFile(path); got != want {
+			t.Fatalf("ForFile(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,1453 @@
+package unicodetext
+
+// Tables below are derived from the Unicode Character Database (Unicode 14).
+
+// compositions maps a base character and a following combining mark to their
+// canonical composition, as used by NFC.
+var compositions = map[[2]rune]rune{
+	{'A', '\u0300'}:      '\u00c0', // latin capital letter a with grave
+	{'A', '\u0301'}:      '\u00c1', // latin capital letter a with acute
+	{'A', '\u0302'}:      '\u00c2', // latin capital letter a with circumflex
+	{'A', '\u0303'}:      '\u00c3', // latin capital letter a with tilde
+	{'A', '\u0308'}:      '\u00c4', // latin capital letter a with diaeresis
+	{'A', '\u030a'}:      '\u00c5', // latin capital letter a with ring above
+	{'C', '\u0327'}:      '\u00c7', // latin capital letter c with cedilla
+	{'E', '\u0300'}:      '\u00c8', // latin capital letter e with grave
+	{'E', '\u0301'}:      '\u00c9', // latin capital letter e with acute
+	{'E', '\u0302'}:      '\u00ca', // latin capital letter e with circumflex
+	{'E', '\u0308'}:      '\u00cb', // latin capital letter e with diaeresis
+	{'I', '\u0300'}:      '\u00cc', // latin capital letter i with grave
+	{'I', '\u0301'}:      '\u00cd', // latin capital letter i with acute
+	{'I', '\u0302'}:      '\u00ce', // latin capital letter i with circumflex
+	{'I', '\u0308'}:      '\u00cf', // latin capital letter i with diaeresis
+	{'N', '\u0303'}:      '\u00d1', // latin capital letter n with tilde
+	{'O', '\u0300'}:      '\u00d2', // latin capital letter o with grave
+	{'O', '\u0301'}:      '\u00d3', // latin capital letter o with acute
+	{'O', '\u0302'}:      '\u00d4', // latin capital letter o with circumflex
+	{'O', '\u0303'}:      '\u00d5', // latin capital letter o with tilde
+	{'O', '\u0308'}:      '\u00d6', // latin capital letter o with diaeresis
+	{'U', '\u0300'}:      '\u00d9', // latin capital letter u with grave
+	{'U', '\u0301'}:      '\u00da', // latin capital letter u with acute
+	{'U', '\u0302'}:      '\u00db', // latin capital letter u with circumflex
+	{'U', '\u0308'}:      '\u00dc', // latin capital letter u with diaeresis
+	{'Y', '\u0301'}:      '\u00dd', // latin capital letter y with acute
+	{'a', '\u0300'}:      '\u00e0', // latin small letter a with grave
+	{'a', '\u0301'}:      '\u00e1', // latin small letter a with acute
+	{'a', '\u0302'}:      '\u00e2', // latin small letter a with circumflex
+	{'a', '\u0303'}:      '\u00e3', // latin small letter a with tilde
+	{'a', '\u0308'}:      '\u00e4', // latin small letter a with diaeresis
+	{'a', '\u030a'}:      '\u00e5', // latin small letter a with ring above
+	{'c', '\u0327'}:      '\u00e7', // latin small letter c with cedilla
+	{'e', '\u0300'}:      '\u00e8', // latin small letter e with grave
+	{'e', '\u0301'}:      '\u00e9', // latin small letter e with acute
+	{'e', '\u0302'}:      '\u00ea', // latin small letter e with circumflex
+	{'e', '\u0308'}:      '\u00eb', // latin small letter e with diaeresis
+	{'i', '\u0300'}:      '\u00ec', // latin small letter i with grave
+	{'i', '\u0301'}:      '\u00ed', // latin small letter i with acute
+	{'i', '\u0302'}:      '\u00ee', // latin small letter i with circumflex
+	{'i', '\u0308'}:      '\u00ef', // latin small letter i with diaeresis
+	{'n', '\u0303'}:      '\u00f1', // latin small letter n with tilde
+	{'o', '\u0300'}:      '\u00f2', // latin small letter o with grave
+	{'o', '\u0301'}:      '\u00f3', // latin small letter o with acute
+	{'o', '\u0302'}:      '\u00f4', // latin small letter o with circumflex
+	{'o', '\u0303'}:      '\u00f5', // latin small letter o with tilde
+	{'o', '\u0308'}:      '\u00f6', // latin small letter o with diaeresis
+	{'u', '\u0300'}:      '\u00f9', // latin small letter u with grave
+	{'u', '\u0301'}:      '\u00fa', // latin small letter u with acute
+	{'u', '\u0302'}:      '\u00fb', // latin small letter u with circumflex
+	{'u', '\u0308'}:      '\u00fc', // latin small letter u with diaeresis
+	{'y', '\u0301'}:      '\u00fd', // latin small letter y with acute
+	{'y', '\u0308'}:      '\u00ff', // latin small letter y with diaeresis
+	{'A', '\u0304'}:      '\u0100', // latin capital letter a with macron
+	{'a', '\u0304'}:      '\u0101', // latin small letter a with macron
+	{'A', '\u0306'}:      '\u0102', // latin capital letter a with breve
+	{'a', '\u0306'}:      '\u0103', // latin small letter a with breve
+	{'A', '\u0328'}:      '\u0104', // latin capital letter a with ogonek
+	{'a', '\u0328'}:      '\u0105', // latin small letter a with ogonek
+	{'C', '\u0301'}:      '\u0106', // latin capital letter c with acute
+	{'c', '\u0301'}:      '\u0107', // latin small letter c with acute
+	{'C', '\u0302'}:      '\u0108', // latin capital letter c with circumflex
+	{'c', '\u0302'}:      '\u0109', // latin small letter c with circumflex
+	{'C', '\u0307'}:      '\u010a', // latin capital letter c with dot above
+	{'c', '\u0307'}:      '\u010b', // latin small letter c with dot above
+	{'C', '\u030c'}:      '\u010c', // latin capital letter c with caron
+	{'c', '\u030c'}:      '\u010d', // latin small letter c with caron
+	{'D', '\u030c'}:      '\u010e', // latin capital letter d with caron
+	{'d', '\u030c'}:      '\u010f', // latin small letter d with caron
+	{'E', '\u0304'}:      '\u0112', // latin capital letter e with macron
+	{'e', '\u0304'}:      '\u0113', // latin small letter e with macron
+	{'E', '\u0306'}:      '\u0114', // latin capital letter e with breve
+	{'e', '\u0306'}:      '\u0115', // latin small letter e with breve
+	{'E', '\u0307'}:      '\u0116', // latin capital letter e with dot above
+	{'e', '\u0307'}:      '\u0117', // latin small letter e with dot above
+	{'E', '\u0328'}:      '\u0118', // latin capital letter e with ogonek
+	{'e', '\u0328'}:      '\u0119', // latin small letter e with ogonek
+	{'E', '\u030c'}:      '\u011a', // latin capital letter e with caron
+	{'e', '\u030c'}:      '\u011b', // latin small letter e with caron
+	{'G', '\u0302'}:      '\u011c', // latin capital letter g with circumflex
+	{'g', '\u0302'}:      '\u011d', // latin small letter g with circumflex
+	{'G', '\u0306'}:      '\u011e', // latin capital letter g with breve
+	{'g', '\u0306'}:      '\u011f', // latin small letter g with breve
+	{'G', '\u0307'}:      '\u0120', // latin capital letter g with dot above
+	{'g', '\u0307'}:      '\u0121', // latin small letter g with dot above
+	{'G', '\u0327'}:      '\u0122', // latin capital letter g with cedilla
+	{'g', '\u0327'}:      '\u0123', // latin small letter g with cedilla
+	{'H', '\u0302'}:      '\u0124', // latin capital letter h with circumflex
+	{'h', '\u0302'}:      '\u0125', // latin small letter h with circumflex
+	{'I', '\u0303'}:      '\u0128', // latin capital letter i with tilde
+	{'i', '\u0303'}:      '\u0129', // latin small letter i with tilde
+	{'I', '\u0304'}:      '\u012a', // latin capital letter i with macron
+	{'i', '\u0304'}:      '\u012b', // latin small letter i with macron
+	{'I', '\u0306'}:      '\u012c', // latin capital letter i with breve
+	{'i', '\u0306'}:      '\u012d', // latin small letter i with breve
+	{'I', '\u0328'}:      '\u012e', // latin capital letter i with ogonek
+	{'i', '\u0328'}:      '\u012f', // latin small letter i with ogonek
+	{'I', '\u0307'}:      '\u0130', // latin capital letter i with dot above
+	{'J', '\u0302'}:      '\u0134', // latin capital letter j with circumflex
+	{'j', '\u0302'}:      '\u0135', // latin small letter j with circumflex
+	{'K', '\u0327'}:      '\u0136', // latin capital letter k with cedilla
+	{'k', '\u0327'}:      '\u0137', // latin small letter k with cedilla
+	{'L', '\u0301'}:      '\u0139', // latin capital letter l with acute
+	{'l', '\u0301'}:      '\u013a', // latin small letter l with acute
+	{'L', '\u0327'}:      '\u013b', // latin capital letter l with cedilla
+	{'l', '\u0327'}:      '\u013c', // latin small letter l with cedilla
+	{'L', '\u030c'}:      '\u013d', // latin capital letter l with caron
+	{'l', '\u030c'}:      '\u013e', // latin small letter l with caron
+	{'N', '\u0301'}:      '\u0143', // latin capital letter n with acute
+	{'n', '\u0301'}:      '\u0144', // latin small letter n with acute
+	{'N', '\u0327'}:      '\u0145', // latin capital letter n with cedilla
+	{'n', '\u0327'}:      '\u0146', // latin small letter n with cedilla
+	{'N', '\u030c'}:      '\u0147', // latin capital letter n with caron
+	{'n', '\u030c'}:      '\u0148', // latin small letter n with caron
+	{'O', '\u0304'}:      '\u014c', // latin capital letter o with macron
+	{'o', '\u0304'}:      '\u014d', // latin small letter o with macron
+	{'O', '\u0306'}:      '\u014e', // latin capital letter o with breve
+	{'o', '\u0306'}:      '\u014f', // latin small letter o with breve
+	{'O', '\u030b'}:      '\u0150', // latin capital letter o with double acute
+	{'o', '\u030b'}:      '\u0151', // latin small letter o with double acute
+	{'R', '\u0301'}:      '\u0154', // latin capital letter r with acute
+	{'r', '\u0301'}:      '\u0155', // latin small letter r with acute
+	{'R', '\u0327'}:      '\u0156', // latin capital letter r with cedilla
+	{'r', '\u0327'}:      '\u0157', // latin small letter r with cedilla
+	{'R', '\u030c'}:      '\u0158', // latin capital letter r with caron
+	{'r', '\u030c'}:      '\u0159', // latin small letter r with caron
+	{'S', '\u0301'}:      '\u015a', // latin capital letter s with acute
+	{'s', '\u0301'}:      '\u015b', // latin small letter s with acute
+	{'S', '\u0302'}:      '\u015c', // latin capital letter s with circumflex
+	{'s', '\u0302'}:      '\u015d', // latin small letter s with circumflex
+	{'S', '\u0327'}:      '\u015e', // latin capital letter s with cedilla
+	{'s', '\u0327'}:      '\u015f', // latin small letter s with cedilla
+	{'S', '\u030c'}:      '\u0160', // latin capital letter s with caron
+	{'s', '\u030c'}:      '\u0161', // latin small letter s with caron
+	{'T', '\u0327'}:      '\u0162', // latin capital letter t with cedilla
+	{'t', '\u0327'}:      '\u0163', // latin small letter t with cedilla
+	{'T', '\u030c'}:      '\u0164', // latin capital letter t with caron
+	{'t', '\u030c'}:      '\u0165', // latin small letter t with caron
+	{'U', '\u0303'}:      '\u0168', // latin capital letter u with tilde
+	{'u', '\u0303'}:      '\u0169', // latin small letter u with tilde
+	{'U', '\u0304'}:      '\u016a', // latin capital letter u with macron
+	{'u', '\u0304'}:      '\u016b', // latin small letter u with macron
+	{'U', '\u0306'}:      '\u016c', // latin capital letter u with breve
+	{'u', '\u0306'}:      '\u016d', // latin small letter u with breve
+	{'U', '\u030a'}:      '\u016e', // latin capital letter u with ring above
+	{'u', '\u030a'}:      '\u016f', // latin small letter u with ring above
+	{'U', '\u030b'}:      '\u0170', // latin capital letter u with double acute
+	{'u', '\u030b'}:      '\u0171', // latin small letter u with double acute
+	{'U', '\u0328'}:      '\u0172', // latin capital letter u with ogonek
+	{'u', '\u0328'}:      '\u0173', // latin small letter u with ogonek
+	{'W', '\u0302'}:      '\u0174', // latin capital letter w with circumflex
+	{'w', '\u0302'}:      '\u0175', // latin small letter w with circumflex
+	{'Y', '\u0302'}:      '\u0176', // latin capital letter y with circumflex
+	{'y', '\u0302'}:      '\u0177', // latin small letter y with circumflex
+	{'Y', '\u0308'}:      '\u0178', // latin capital letter y with diaeresis
+	{'Z', '\u0301'}:      '\u0179', // latin capital letter z with acute
+	{'z', '\u0301'}:      '\u017a', // latin small letter z with acute
+	{'Z', '\u0307'}:      '\u017b', // latin capital letter z with dot above
+	{'z', '\u0307'}:      '\u017c', // latin small letter z with dot above
+	{'Z', '\u030c'}:      '\u017d', // latin capital letter z with caron
+	{'z', '\u030c'}:      '\u017e', // latin small letter z with caron
+	{'O', '\u031b'}:      '\u01a0', // latin capital letter o with horn
+	{'o', '\u031b'}:      '\u01a1', // latin small letter o with horn
+	{'U', '\u031b'}:      '\u01af', // latin capital letter u with horn
+	{'u', '\u031b'}:      '\u01b0', // latin small letter u with horn
+	{'A', '\u030c'}:      '\u01cd', // latin capital letter a with caron
+	{'a', '\u030c'}:      '\u01ce', // latin small letter a with caron
+	{'I', '\u030c'}:      '\u01cf', // latin capital letter i with caron
+	{'i', '\u030c'}:      '\u01d0', // latin small letter i with caron
+	{'O', '\u030c'}:      '\u01d1', // latin capital letter o with caron
+	{'o', '\u030c'}:      '\u01d2', // latin small letter o with caron
+	{'U', '\u030c'}:      '\u01d3', // latin capital letter u with caron
+	{'u', '\u030c'}:      '\u01d4', // latin small letter u with caron
+	{'\u00dc', '\u0304'}: '\u01d5', // latin capital letter u with diaeresis and macron
+	{'\u00fc', '\u0304'}: '\u01d6', // latin small letter u with diaeresis and macron
+	{'\u00dc', '\u0301'}: '\u01d7', // latin capital letter u with diaeresis and acute
+	{'\u00fc', '\u0301'}: '\u01d8', // latin small letter u with diaeresis and acute
+	{'\u00dc', '\u030c'}: '\u01d9', // latin capital letter u with diaeresis and caron
+	{'\u00fc', '\u030c'}: '\u01da', // latin small letter u with diaeresis and caron
+	{'\u00dc', '\u0300'}: '\u01db', // latin capital letter u with diaeresis and grave
+	{'\u00fc', '\u0300'}: '\u01dc', // latin small letter u with diaeresis and grave
+	{'\u00c4', '\u0304'}: '\u01de', // latin capital letter a with diaeresis and macron
+	{'\u00e4', '\u0304'}: '\u01df', // latin small letter a with diaeresis and macron
+	{'\u0226', '\u0304'}: '\u01e0', // latin capital letter a with dot above and macron
+	{'\u0227', '\u0304'}: '\u01e1', // latin small letter a with dot above and macron
+	{'\u00c6', '\u0304'}: '\u01e2', // latin capital letter ae with macron
+	{'\u00e6', '\u0304'}: '\u01e3', // latin small letter ae with macron
+	{'G', '\u030c'}:      '\u01e6', // latin capital letter g with caron
+	{'g', '\u030c'}:      '\u01e7', // latin small letter g with caron
+	{'K', '\u030c'}:      '\u01e8', // latin capital letter k with caron
+	{'k', '\u030c'}:      '\u01e9', // latin small letter k with caron
+	{'O', '\u0328'}:      '\u01ea', // latin capital letter o with ogonek
+	{'o', '\u0328'}:      '\u01eb', // latin small letter o with ogonek
+	{'\u01ea', '\u0304'}: '\u01ec', // latin capital letter o with ogonek and macron
+	{'\u01eb', '\u0304'}: '\u01ed', // latin small letter o with ogonek and macron
+	{'\u01b7', '\u030c'}: '\u01ee', // latin capital letter ezh with caron
+	{'\u0292', '\u030c'}: '\u01ef', // latin small letter ezh with caron
+	{'j', '\u030c'}:      '\u01f0', // latin small letter j with caron
+	{'G', '\u0301'}:      '\u01f4', // latin capital letter g with acute
+	{'g', '\u0301'}:      '\u01f5', // latin small letter g with acute
+	{'N', '\u0300'}:      '\u01f8', // latin capital letter n with grave
+	{'n', '\u0300'}:      '\u01f9', // latin small letter n with grave
+	{'\u00c5', '\u0301'}: '\u01fa', // latin capital letter a with ring above and acute
+	{'\u00e5', '\u0301'}: '\u01fb', // latin small letter a with ring above and acute
+	{'\u00c6', '\u0301'}: '\u01fc', // latin capital letter ae with acute
+	{'\u00e6', '\u0301'}: '\u01fd', // latin small letter ae with acute
+	{'\u00d8', '\u0301'}: '\u01fe', // latin capital letter o with stroke and acute
+	{'\u00f8', '\u0301'}: '\u01ff', // latin small letter o with stroke and acute
+	{'A', '\u030f'}:      '\u0200', // latin capital letter a with double grave
+	{'a', '\u030f'}:      '\u0201', // latin small letter a with double grave
+	{'A', '\u0311'}:      '\u0202', // latin capital letter a with inverted breve
+	{'a', '\u0311'}:      '\u0203', // latin small letter a with inverted breve
+	{'E', '\u030f'}:      '\u0204', // latin capital letter e with double grave
+	{'e', '\u030f'}:      '\u0205', // latin small letter e with double grave
+	{'E', '\u0311'}:      '\u0206', // latin capital letter e with inverted breve
+	{'e', '\u0311'}:      '\u0207', // latin small letter e with inverted breve
+	{'I', '\u030f'}:      '\u0208', // latin capital letter i with double grave
+	{'i', '\u030f'}:      '\u0209', // latin small letter i with double grave
+	{'I', '\u0311'}:      '\u020a', // latin capital letter i with inverted breve
+	{'i', '\u0311'}:      '\u020b', // latin small letter i with inverted breve
+	{'O', '\u030f'}:      '\u020c', // latin capital letter o with double grave
+	{'o', '\u030f'}:      '\u020d', // latin small letter o with double grave
+	{'O', '\u0311'}:      '\u020e', // latin capital letter o with inverted breve
+	{'o', '\u0311'}:      '\u020f', // latin small letter o with inverted breve
+	{'R', '\u030f'}:      '\u0210', // latin capital letter r with double grave
+	{'r', '\u030f'}:      '\u0211', // latin small letter r with double grave
+	{'R', '\u0311'}:      '\u0212', // latin capital letter r with inverted breve
+	{'r', '\u0311'}:      '\u0213', // latin small letter r with inverted breve
+	{'U', '\u030f'}:      '\u0214', // latin capital letter u with double grave
+	{'u', '\u030f'}:      '\u0215', // latin small letter u with double grave
+	{'U', '\u0311'}:      '\u0216', // latin capital letter u with inverted breve
+	{'u', '\u0311'}:      '\u0217', // latin small letter u with inverted breve
+	{'S', '\u0326'}:      '\u0218', // latin capital letter s with comma below
+	{'s', '\u0326'}:      '\u0219', // latin small letter s with comma below
+	{'T', '\u0326'}:      '\u021a', // latin capital letter t with comma below
+	{'t', '\u0326'}:      '\u021b', // latin small letter t with comma below
+	{'H', '\u030c'}:      '\u021e', // latin capital letter h with caron
+	{'h', '\u030c'}:      '\u021f', // latin small letter h with caron
+	{'A', '\u0307'}:      '\u0226', // latin capital letter a with dot above
+	{'a', '\u0307'}:      '\u0227', // latin small letter a with dot above
+	{'E', '\u0327'}:      '\u0228', // latin capital letter e with cedilla
+	{'e', '\u0327'}:      '\u0229', // latin small letter e with cedilla
+	{'\u00d6', '\u0304'}: '\u022a', // latin capital letter o with diaeresis and macron
+	{'\u00f6', '\u0304'}: '\u022b', // latin small letter o with diaeresis and macron
+	{'\u00d5', '\u0304'}: '\u022c', // latin capital letter o with tilde and macron
+	{'\u00f5', '\u0304'}: '\u022d', // latin small letter o with tilde and macron
+	{'O', '\u0307'}:      '\u022e', // latin capital letter o with dot above
+	{'o', '\u0307'}:      '\u022f', // latin small letter o with dot above
+	{'\u022e', '\u0304'}: '\u0230', // latin capital letter o with dot above and macron
+	{'\u022f', '\u0304'}: '\u0231', // latin small letter o with dot above and macron
+	{'Y', '\u0304'}:      '\u0232', // latin capital letter y with macron
+	{'y', '\u0304'}:      '\u0233', // latin small letter y with macron
+	{'\u00a8', '\u0301'}: '\u0385', // greek dialytika tonos
+	{'\u0391', '\u0301'}: '\u0386', // greek capital letter alpha with tonos
+	{'\u0395', '\u0301'}: '\u0388', // greek capital letter epsilon with tonos
+	{'\u0397', '\u0301'}: '\u0389', // greek capital letter eta with tonos
+	{'\u0399', '\u0301'}: '\u038a', // greek capital letter iota with tonos
+	{'\u039f', '\u0301'}: '\u038c', // greek capital letter omicron with tonos
+	{'\u03a5', '\u0301'}: '\u038e', // greek capital letter upsilon with tonos
+	{'\u03a9', '\u0301'}: '\u038f', // greek capital letter omega with tonos
+	{'\u03ca', '\u0301'}: '\u0390', // greek small letter iota with dialytika and tonos
+	{'\u0399', '\u0308'}: '\u03aa', // greek capital letter iota with dialytika
+	{'\u03a5', '\u0308'}: '\u03ab', // greek capital letter upsilon with dialytika
+	{'\u03b1', '\u0301'}: '\u03ac', // greek small letter alpha with tonos
+	{'\u03b5', '\u0301'}: '\u03ad', // greek small letter epsilon with tonos
+	{'\u03b7', '\u0301'}: '\u03ae', // greek small letter eta with tonos
+	{'\u03b9', '\u0301'}: '\u03af', // greek small letter iota with tonos
+	{'\u03cb', '\u0301'}: '\u03b0', // greek small letter upsilon with dialytika and tonos
+	{'\u03b9', '\u0308'}: '\u03ca', // greek small letter iota with dialytika
+	{'\u03c5', '\u0308'}: '\u03cb', // greek small letter upsilon with dialytika
+	{'\u03bf', '\u0301'}: '\u03cc', // greek small letter omicron with tonos
+	{'\u03c5', '\u0301'}: '\u03cd', // greek small letter upsilon with tonos
+	{'\u03c9', '\u0301'}: '\u03ce', // greek small letter omega with tonos
+	{'\u03d2', '\u0301'}: '\u03d3', // greek upsilon with acute and hook symbol
+	{'\u03d2', '\u0308'}: '\u03d4', // greek upsilon with diaeresis and hook symbol
+	{'\u0415', '\u0300'}: '\u0400', // cyrillic capital letter ie with grave
+	{'\u0415', '\u0308'}: '\u0401', // cyrillic capital letter io
+	{'\u0413', '\u0301'}: '\u0403', // cyrillic capital letter gje
+	{'\u0406', '\u0308'}: '\u0407', // cyrillic capital letter yi
+	{'\u041a', '\u0301'}: '\u040c', // cyrillic capital letter kje
+	{'\u0418', '\u0300'}: '\u040d', // cyrillic capital letter i with grave
+	{'\u0423', '\u0306'}: '\u040e', // cyrillic capital letter short u
+	{'\u0418', '\u0306'}: '\u0419', // cyrillic capital letter short i
+	{'\u0438', '\u0306'}: '\u0439', // cyrillic small letter short i
+	{'\u0435', '\u0300'}: '\u0450', // cyrillic small letter ie with grave
+	{'\u0435', '\u0308'}: '\u0451', // cyrillic small letter io
+	{'\u0433', '\u0301'}: '\u0453', // cyrillic small letter gje
+	{'\u0456', '\u0308'}: '\u0457', // cyrillic small letter yi
+	{'\u043a', '\u0301'}: '\u045c', // cyrillic small letter kje
+	{'\u0438', '\u0300'}: '\u045d', // cyrillic small letter i with grave
+	{'\u0443', '\u0306'}: '\u045e', // cyrillic small letter short u
+	{'\u0474', '\u030f'}: '\u0476', // cyrillic capital letter izhitsa with double grave accent
+	{'\u0475', '\u030f'}: '\u0477', // cyrillic small letter izhitsa with double grave accent
+	{'\u0416', '\u0306'}: '\u04c1', // cyrillic capital letter zhe with breve
+	{'\u0436', '\u0306'}: '\u04c2', // cyrillic small letter zhe with breve
+	{'\u0410', '\u0306'}: '\u04d0', // cyrillic capital letter a with breve
+	{'\u0430', '\u0306'}: '\u04d1', // cyrillic small letter a with breve
+	{'\u0410', '\u0308'}: '\u04d2', // cyrillic capital letter a with diaeresis
+	{'\u0430', '\u0308'}: '\u04d3', // cyrillic small letter a with diaeresis
+	{'\u0415', '\u0306'}: '\u04d6', // cyrillic capital letter ie with breve
+	{'\u0435', '\u0306'}: '\u04d7', // cyrillic small letter ie with breve
+	{'\u04d8', '\u0308'}: '\u04da', // cyrillic capital letter schwa with diaeresis
+	{'\u04d9', '\u0308'}: '\u04db', // cyrillic small letter schwa with diaeresis
+	{'\u0416', '\u0308'}: '\u04dc', // cyrillic capital letter zhe with diaeresis
+	{'\u0436', '\u0308'}: '\u04dd', // cyrillic small letter zhe with diaeresis
+	{'\u0417', '\u0308'}: '\u04de', // cyrillic capital letter ze with diaeresis
+	{'\u0437', '\u0308'}: '\u04df', // cyrillic small letter ze with diaeresis
+	{'\u0418', '\u0304'}: '\u04e2', // cyrillic capital letter i with macron
+	{'\u0438', '\u0304'}: '\u04e3', // cyrillic small letter i with macron
+	{'\u0418', '\u0308'}: '\u04e4', // cyrillic capital letter i with diaeresis
+	{'\u0438', '\u0308'}: '\u04e5', // cyrillic small letter i with diaeresis
+	{'\u041e', '\u0308'}: '\u04e6', // cyrillic capital letter o with diaeresis
+	{'\u043e', '\u0308'}: '\u04e7', // cyrillic small letter o with diaeresis
+	{'\u04e8', '\u0308'}: '\u04ea', // cyrillic capital letter barred o with diaeresis
+	{'\u04e9', '\u0308'}: '\u04eb', // cyrillic small letter barred o with diaeresis
+	{'\u042d', '\u0308'}: '\u04ec', // cyrillic capital letter e with diaeresis
+	{'\u044d', '\u0308'}: '\u04ed', // cyrillic small letter e with diaeresis
+	{'\u0423', '\u0304'}: '\u04ee', // cyrillic capital letter u with macron
+	{'\u0443', '\u0304'}: '\u04ef', // cyrillic small letter u with macron
+	{'\u0423', '\u0308'}: '\u04f0', // cyrillic capital letter u with diaeresis
+	{'\u0443', '\u0308'}: '\u04f1', // cyrillic small letter u with diaeresis
+	{'\u0423', '\u030b'}: '\u04f2', // cyrillic capital letter u with double acute
+	{'\u0443', '\u030b'}: '\u04f3', // cyrillic small letter u with double acute
+	{'\u0427', '\u0308'}: '\u04f4', // cyrillic capital letter che with diaeresis
+	{'\u0447', '\u0308'}: '\u04f5', // cyrillic small letter che with diaeresis
+	{'\u042b', '\u0308'}: '\u04f8', // cyrillic capital letter yeru with diaeresis
+	{'\u044b', '\u0308'}: '\u04f9', // cyrillic small letter yeru with diaeresis
+	{'\u0627', '\u0653'}: '\u0622', // arabic letter alef with madda above
+	{'\u0627', '\u0654'}: '\u0623', // arabic letter alef with hamza above
+	{'\u0648', '\u0654'}: '\u0624', // arabic letter waw with hamza above
+	{'\u0627', '\u0655'}: '\u0625', // arabic letter alef with hamza below
+	{'\u064a', '\u0654'}: '\u0626', // arabic letter yeh with hamza above
+	{'\u06d5', '\u0654'}: '\u06c0', // arabic letter heh with yeh above
+	{'\u06c1', '\u0654'}: '\u06c2', // arabic letter heh goal with hamza above
+	{'\u06d2', '\u0654'}: '\u06d3', // arabic letter yeh barree with hamza above
+	{'\u0928', '\u093c'}: '\u0929', // devanagari letter nnna
+	{'\u0930', '\u093c'}: '\u0931', // devanagari letter rra
+	{'\u0933', '\u093c'}: '\u0934', // devanagari letter llla
+	{'\u09c7', '\u09be'}: '\u09cb', // bengali vowel sign o
+	{'\u09c7', '\u09d7'}: '\u09cc', // bengali vowel sign au
+	{'\u0b47', '\u0b56'}: '\u0b48', // oriya vowel sign ai
+	{'\u0b47', '\u0b3e'}: '\u0b4b', // oriya vowel sign o
+	{'\u0b47', '\u0b57'}: '\u0b4c', // oriya vowel sign au
+	{'\u0b92', '\u0bd7'}: '\u0b94', // tamil letter au
+	{'\u0bc6', '\u0bbe'}: '\u0bca', // tamil vowel sign o
+	{'\u0bc7', '\u0bbe'}: '\u0bcb', // tamil vowel sign oo
+	{'\u0bc6', '\u0bd7'}: '\u0bcc', // tamil vowel sign au
+	{'\u0c46', '\u0c56'}: '\u0c48', // telugu vowel sign ai
+	{'\u0cbf', '\u0cd5'}: '\u0cc0', // kannada vowel sign ii
+	{'\u0cc6', '\u0cd5'}: '\u0cc7', // kannada vowel sign ee
+	{'\u0cc6', '\u0cd6'}: '\u0cc8', // kannada vowel sign ai
+	{'\u0cc6', '\u0cc2'}: '\u0cca', // kannada vowel sign o
+	{'\u0cca', '\u0cd5'}: '\u0ccb', // kannada vowel sign oo
+	{'\u0d46', '\u0d3e'}: '\u0d4a', // malayalam vowel sign o
+	{'\u0d47', '\u0d3e'}: '\u0d4b', // malayalam vowel sign oo
+	{'\u0d46', '\u0d57'}: '\u0d4c', // malayalam vowel sign au
+	{'\u0dd9', '\u0dca'}: '\u0dda', // sinhala vowel sign diga kombuva
+	{'\u0dd9', '\u0dcf'}: '\u0ddc', // sinhala vowel sign kombuva haa aela-pilla
+	{'\u0ddc', '\u0dca'}: '\u0ddd', // sinhala vowel sign kombuva haa diga aela-pilla
+	{'\u0dd9', '\u0ddf'}: '\u0dde', // sinhala vowel sign kombuva haa gayanukitta
+	{'\u1025', '\u102e'}: '\u1026', // myanmar letter uu
+	{'\u1b05', '\u1b35'}: '\u1b06', // balinese letter akara tedung
+	{'\u1b07', '\u1b35'}: '\u1b08', // balinese letter ikara tedung
+	{'\u1b09', '\u1b35'}: '\u1b0a', // balinese letter ukara tedung
+	{'\u1b0b', '\u1b35'}: '\u1b0c', // balinese letter ra repa tedung
+	{'\u1b0d', '\u1b35'}: '\u1b0e', // balinese letter la lenga tedung
+	{'\u1b11', '\u1b35'}: '\u1b12', // balinese letter okara tedung
+	{'\u1b3a', '\u1b35'}: '\u1b3b', // balinese vowel sign ra repa tedung
+	{'\u1b3c', '\u1b35'}: '\u1b3d', // balinese vowel sign la lenga tedung
+	{'\u1b3e', '\u1b35'}: '\u1b40', // balinese vowel sign taling tedung
+	{'\u1b3f', '\u1b35'}: '\u1b41', // balinese vowel sign taling repa tedung
+	{'\u1b42', '\u1b35'}: '\u1b43', // balinese vowel sign pepet tedung
+	{'A', '\u0325'}:      '\u1e00', // latin capital letter a with ring below
+	{'a', '\u0325'}:      '\u1e01', // latin small letter a with ring below
+	{'B', '\u0307'}:      '\u1e02', // latin capital letter b with dot above
+	{'b', '\u0307'}:      '\u1e03', // latin small letter b with dot above
+	{'B', '\u0323'}:      '\u1e04', // latin capital letter b with dot below
+	{'b', '\u0323'}:      '\u1e05', // latin small letter b with dot below
+	{'B', '\u0331'}:      '\u1e06', // latin capital letter b with line below
+	{'b', '\u0331'}:      '\u1e07', // latin small letter b with line below
+	{'\u00c7', '\u0301'}: '\u1e08', // latin capital letter c with cedilla and acute
+	{'\u00e7', '\u0301'}: '\u1e09', // latin small letter c with cedilla and acute
+	{'D', '\u0307'}:      '\u1e0a', // latin capital letter d with dot above
+	{'d', '\u0307'}:      '\u1e0b', // latin small letter d with dot above
+	{'D', '\u0323'}:      '\u1e0c', // latin capital letter d with dot below
+	{'d', '\u0323'}:      '\u1e0d', // latin small letter d with dot below
+	{'D', '\u0331'}:      '\u1e0e', // latin capital letter d with line below
+	{'d', '\u0331'}:      '\u1e0f', // latin small letter d with line below
+	{'D', '\u0327'}:      '\u1e10', // latin capital letter d with cedilla
+	{'d', '\u0327'}:      '\u1e11', // latin small letter d with cedilla
+	{'D', '\u032d'}:      '\u1e12', // latin capital letter d with circumflex below
+	{'d', '\u032d'}:      '\u1e13', // latin small letter d with circumflex below
+	{'\u0112', '\u0300'}: '\u1e14', // latin capital letter e with macron and grave
+	{'\u0113', '\u0300'}: '\u1e15', // latin small letter e with macron and grave
+	{'\u0112', '\u0301'}: '\u1e16', // latin capital letter e with macron and acute
+	{'\u0113', '\u0301'}: '\u1e17', // latin small letter e with macron and acute
+	{'E', '\u032d'}:      '\u1e18', // latin capital letter e with circumflex below
+	{'e', '\u032d'}:      '\u1e19', // latin small letter e with circumflex below
+	{'E', '\u0330'}:      '\u1e1a', // latin capital letter e with tilde below
+	{'e', '\u0330'}:      '\u1e1b', // latin small letter e with tilde below
+	{'\u0228', '\u0306'}: '\u1e1c', // latin capital letter e with cedilla and breve
+	{'\u0229', '\u0306'}: '\u1e1d', // latin small letter e with cedilla and breve
+	{'F', '\u0307'}:      '\u1e1e', // latin capital letter f with dot above
+	{'f', '\u0307'}:      '\u1e1f', // latin small letter f with dot above
+	{'G', '\u0304'}:      '\u1e20', // latin capital letter g with macron
+	{'g', '\u0304'}:      '\u1e21', // latin small letter g with macron
+	{'H', '\u0307'}:      '\u1e22', // latin capital letter h with dot above
+	{'h', '\u0307'}:      '\u1e23', // latin small letter h with dot above
+	{'H', '\u0323'}:      '\u1e24', // latin capital letter h with dot below
+	{'h', '\u0323'}:      '\u1e25', // latin small letter h with dot below
+	{'H', '\u0308'}:      '\u1e26', // latin capital letter h with diaeresis
+	{'h', '\u0308'}:      '\u1e27', // latin small letter h with diaeresis
+	{'H', '\u0327'}:      '\u1e28', // latin capital letter h with cedilla
+	{'h', '\u0327'}:      '\u1e29', // latin small letter h with cedilla
+	{'H', '\u032e'}:      '\u1e2a', // latin capital letter h with breve below
+	{'h', '\u032e'}:      '\u1e2b', // latin small letter h with breve below
+	{'I', '\u0330'}:      '\u1e2c', // latin capital letter i with tilde below
+	{'i', '\u0330'}:      '\u1e2d', // latin small letter i with tilde below
+	{'\u00cf', '\u0301'}: '\u1e2e', // latin capital letter i with diaeresis and acute
+	{'\u00ef', '\u0301'}: '\u1e2f', // latin small letter i with diaeresis and acute
+	{'K', '\u0301'}:      '\u1e30', // latin capital letter k with acute
+	{'k', '\u0301'}:      '\u1e31', // latin small letter k with acute
+	{'K', '\u0323'}:      '\u1e32', // latin capital letter k with dot below
+	{'k', '\u0323'}:      '\u1e33', // latin small letter k with dot below
+	{'K', '\u0331'}:      '\u1e34', // latin capital letter k with line below
+	{'k', '\u0331'}:      '\u1e35', // latin small letter k with line below
+	{'L', '\u0323'}:      '\u1e36', // latin capital letter l with dot below
+	{'l', '\u0323'}:      '\u1e37', // latin small letter l with dot below
+	{'\u1e36', '\u0304'}: '\u1e38', // latin capital letter l with dot below and macron
+	{'\u1e37', '\u0304'}: '\u1e39', // latin small letter l with dot below and macron
+	{'L', '\u0331'}:      '\u1e3a', // latin capital letter l with line below
+	{'l', '\u0331'}:      '\u1e3b', // latin small letter l with line below
+	{'L', '\u032d'}:      '\u1e3c', // latin capital letter l with circumflex below
+	{'l', '\u032d'}:      '\u1e3d', // latin small letter l with circumflex below
+	{'M', '\u0301'}:      '\u1e3e', // latin capital letter m with acute
+	{'m', '\u0301'}:      '\u1e3f', // latin small letter m with acute
+	{'M', '\u0307'}:      '\u1e40', // latin capital letter m with dot above
+	{'m', '\u0307'}:      '\u1e41', // latin small letter m with dot above
+	{'M', '\u0323'}:      '\u1e42', // latin capital letter m with dot below
+	{'m', '\u0323'}:      '\u1e43', // latin small letter m with dot below
+	{'N', '\u0307'}:      '\u1e44', // latin capital letter n with dot above
+	{'n', '\u0307'}:      '\u1e45', // latin small letter n with dot above
+	{'N', '\u0323'}:      '\u1e46', // latin capital letter n with dot below
+	{'n', '\u0323'}:      '\u1e47', // latin small letter n with dot below
+	{'N', '\u0331'}:      '\u1e48', // latin capital letter n with line below
+	{'n', '\u0331'}:      '\u1e49', // latin small letter n with line below
+	{'N', '\u032d'}:      '\u1e4a', // latin capital letter n with circumflex below
+	{'n', '\u032d'}:      '\u1e4b', // latin small letter n with circumflex below
+	{'\u00d5', '\u0301'}: '\u1e4c', // latin capital letter o with tilde and acute
+	{'\u00f5', '\u0301'}: '\u1e4d', // latin small letter o with tilde and acute
+	{'\u00d5', '\u0308'}: '\u1e4e', // latin capital letter o with tilde and diaeresis
+	{'\u00f5', '\u0308'}: '\u1e4f', // latin small letter o with tilde and diaeresis
+	{'\u014c', '\u0300'}: '\u1e50', // latin capital letter o with macron and grave
+	{'\u014d', '\u0300'}: '\u1e51', // latin small letter o with macron and grave
+	{'\u014c', '\u0301'}: '\u1e52', // latin capital letter o with macron and acute
+	{'\u014d', '\u0301'}: '\u1e53', // latin small letter o with macron and acute
+	{'P', '\u0301'}:      '\u1e54', // latin capital letter p with acute
+	{'p', '\u0301'}:      '\u1e55', // latin small letter p with acute
+	{'P', '\u0307'}:      '\u1e56', // latin capital letter p with dot above
+	{'p', '\u0307'}:      '\u1e57', // latin small letter p with dot above
+	{'R', '\u0307'}:      '\u1e58', // latin capital letter r with dot above
+	{'r', '\u0307'}:      '\u1e59', // latin small letter r with dot above
+	{'R', '\u0323'}:      '\u1e5a', // latin capital letter r with dot below
+	{'r', '\u0323'}:      '\u1e5b', // latin small letter r with dot below
+	{'\u1e5a', '\u0304'}: '\u1e5c', // latin capital letter r with dot below and macron
+	{'\u1e5b', '\u0304'}: '\u1e5d', // latin small letter r with dot below and macron
+	{'R', '\u0331'}:      '\u1e5e', // latin capital letter r with line below
+	{'r', '\u0331'}:      '\u1e5f', // latin small letter r with line below
+	{'S', '\u0307'}:      '\u1e60', // latin capital letter s with dot above
+	{'s', '\u0307'}:      '\u1e61', // latin small letter s with dot above
+	{'S', '\u0323'}:      '\u1e62', // latin capital letter s with dot below
+	{'s', '\u0323'}:      '\u1e63', // latin small letter s with dot below
+	{'\u015a', '\u0307'}: '\u1e64', // latin capital letter s with acute and dot above
+	{'\u015b', '\u0307'}: '\u1e65', // latin small letter s with acute and dot above
+	{'\u0160', '\u0307'}: '\u1e66', // latin capital letter s with caron and dot above
+	{'\u0161', '\u0307'}: '\u1e67', // latin small letter s with caron and dot above
+	{'\u1e62', '\u0307'}: '\u1e68', // latin capital letter s with dot below and dot above
+	{'\u1e63', '\u0307'}: '\u1e69', // latin small letter s with dot below and dot above
+	{'T', '\u0307'}:      '\u1e6a', // latin capital letter t with dot above
+	{'t', '\u0307'}:      '\u1e6b', // latin small letter t with dot above
+	{'T', '\u0323'}:      '\u1e6c', // latin capital letter t with dot below
+	{'t', '\u0323'}:      '\u1e6d', // latin small letter t with dot below
+	{'T', '\u0331'}:      '\u1e6e', // latin capital letter t with line below
+	{'t', '\u0331'}:      '\u1e6f', // latin small letter t with line below
+	{'T', '\u032d'}:      '\u1e70', // latin capital letter t with circumflex below
+	{'t', '\u032d'}:      '\u1e71', // latin small letter t with circumflex below
+	{'U', '\u0324'}:      '\u1e72', // latin capital letter u with diaeresis below
+	{'u', '\u0324'}:      '\u1e73', // latin small letter u with diaeresis below
+	{'U', '\u0330'}:      '\u1e74', // latin capital letter u with tilde below
+	{'u', '\u0330'}:      '\u1e75', // latin small letter u with tilde below
+	{'U', '\u032d'}:      '\u1e76', // latin capital letter u with circumflex below
+	{'u', '\u032d'}:      '\u1e77', // latin small letter u with circumflex below
+	{'\u0168', '\u0301'}: '\u1e78', // latin capital letter u with tilde and acute
+	{'\u0169', '\u0301'}: '\u1e79', // latin small letter u with tilde and acute
+	{'\u016a', '\u0308'}: '\u1e7a', // latin capital letter u with macron and diaeresis
+	{'\u016b', '\u0308'}: '\u1e7b', // latin small letter u with macron and diaeresis
+	{'V', '\u0303'}:      '\u1e7c', // latin capital letter v with tilde
+	{'v', '\u0303'}:      '\u1e7d', // latin small letter v with tilde
+	{'V', '\u0323'}:      '\u1e7e', // latin capital letter v with dot below
+	{'v', '\u0323'}:      '\u1e7f', // latin small letter v with dot below
+	{'W', '\u0300'}:      '\u1e80', // latin capital letter w with grave
+	{'w', '\u0300'}:      '\u1e81', // latin small letter w with grave
+	{'W', '\u0301'}:      '\u1e82', // latin capital letter w with acute
+	{'w', '\u0301'}:      '\u1e83', // latin small letter w with acute
+	{'W', '\u0308'}:      '\u1e84', // latin capital letter w with diaeresis
+	{'w', '\u0308'}:      '\u1e85', // latin small letter w with diaeresis
+	{'W', '\u0307'}:      '\u1e86', // latin capital letter w with dot above
+	{'w', '\u0307'}:      '\u1e87', // latin small letter w with dot above
+	{'W', '\u0323'}:      '\u1e88', // latin capital letter w with dot below
+	{'w', '\u0323'}:      '\u1e89', // latin small letter w with dot below
+	{'X', '\u0307'}:      '\u1e8a', // latin capital letter x with dot above
+	{'x', '\u0307'}:      '\u1e8b', // latin small letter x with dot above
+	{'X', '\u0308'}:      '\u1e8c', // latin capital letter x with diaeresis
+	{'x', '\u0308'}:      '\u1e8d', // latin small letter x with diaeresis
+	{'Y', '\u0307'}:      '\u1e8e', // latin capital letter y with dot above
+	{'y', '\u0307'}:      '\u1e8f', // latin small letter y with dot above
+	{'Z', '\u0302'}:      '\u1e90', // latin capital letter z with circumflex
+	{'z', '\u0302'}:      '\u1e91', // latin small letter z with circumflex
+	{'Z', '\u0323'}:      '\u1e92', // latin capital letter z with dot below
+	{'z', '\u0323'}:      '\u1e93', // latin small letter z with dot below
+	{'Z', '\u0331'}:      '\u1e94', // latin capital letter z with line below
+	{'z', '\u0331'}:      '\u1e95', // latin small letter z with line below
+	{'h', '\u0331'}:      '\u1e96', // latin small letter h with line below
+	{'t', '\u0308'}:      '\u1e97', // latin small letter t with diaeresis
+	{'w', '\u030a'}:      '\u1e98', // latin small letter w with ring above
+	{'y', '\u030a'}:      '\u1e99', // latin small letter y with ring above
+	{'\u017f', '\u0307'}: '\u1e9b', // latin small letter long s with dot above
+	{'A', '\u0323'}:      '\u1ea0', // latin capital letter a with dot below
+	{'a', '\u0323'}:      '\u1ea1', // latin small letter a with dot below
+	{'A', '\u0309'}:      '\u1ea2', // latin capital letter a with hook above
+	{'a', '\u0309'}:      '\u1ea3', // latin small letter a with hook above
+	{'\u00c2', '\u0301'}: '\u1ea4', // latin capital letter a with circumflex and acute
+	{'\u00e2', '\u0301'}: '\u1ea5', // latin small letter a with circumflex and acute
+	{'\u00c2', '\u0300'}: '\u1ea6', // latin capital letter a with circumflex and grave
+	{'\u00e2', '\u0300'}: '\u1ea7', // latin small letter a with circumflex and grave
+	{'\u00c2', '\u0309'}: '\u1ea8', // latin capital letter a with circumflex and hook above
+	{'\u00e2', '\u0309'}: '\u1ea9', // latin small letter a with circumflex and hook above
+	{'\u00c2', '\u0303'}: '\u1eaa', // latin capital letter a with circumflex and tilde
+	{'\u00e2', '\u0303'}: '\u1eab', // latin small letter a with circumflex and tilde
+	{'\u1ea0', '\u0302'}: '\u1eac', // latin capital letter a with circumflex and dot below
+	{'\u1ea1', '\u0302'}: '\u1ead', // latin small letter a with circumflex and dot below
+	{'\u0102', '\u0301'}: '\u1eae', // latin capital letter a with breve and acute
+	{'\u0103', '\u0301'}: '\u1eaf', // latin small letter a with breve and acute
+	{'\u0102', '\u0300'}: '\u1eb0', // latin capital letter a with breve and grave
+	{'\u0103', '\u0300'}: '\u1eb1', // latin small letter a with breve and grave
+	{'\u0102', '\u0309'}: '\u1eb2', // latin capital letter a with breve and hook above
+	{'\u0103', '\u0309'}: '\u1eb3', // latin small letter a with breve and hook above
+	{'\u0102', '\u0303'}: '\u1eb4', // latin capital letter a with breve and tilde
+	{'\u0103', '\u0303'}: '\u1eb5', // latin small letter a with breve and tilde
+	{'\u1ea0', '\u0306'}: '\u1eb6', // latin capital letter a with breve and dot below
+	{'\u1ea1', '\u0306'}: '\u1eb7', // latin small letter a with breve and dot below
+	{'E', '\u0323'}:      '\u1eb8', // latin capital letter e with dot below
+	{'e', '\u0323'}:      '\u1eb9', // latin small letter e with dot below
+	{'E', '\u0309'}:      '\u1eba', // latin capital letter e with hook above
+	{'e', '\u0309'}:      '\u1ebb', // latin small letter e with hook above
+	{'E', '\u0303'}:      '\u1ebc', // latin capital letter e with tilde
+	{'e', '\u0303'}:      '\u1ebd', // latin small letter e with tilde
+	{'\u00ca', '\u0301'}: '\u1ebe', // latin capital letter e with circumflex and acute
+	{'\u00ea', '\u0301'}: '\u1ebf', // latin small letter e with circumflex and acute
+	{'\u00ca', '\u0300'}: '\u1ec0', // latin capital letter e with circumflex and grave
+	{'\u00ea', '\u0300'}: '\u1ec1', // latin small letter e with circumflex and grave
+	{'\u00ca', '\u0309'}: '\u1ec2', // latin capital letter e with circumflex and hook above
+	{'\u00ea', '\u0309'}: '\u1ec3', // latin small letter e with circumflex and hook above
+	{'\u00ca', '\u0303'}: '\u1ec4', // latin capital letter e with circumflex and tilde
+	{'\u00ea', '\u0303'}: '\u1ec5', // latin small letter e with circumflex and tilde
+	{'\u1eb8', '\u0302'}: '\u1ec6', // latin capital letter e with circumflex and dot below
+	{'\u1eb9', '\u0302'}: '\u1ec7', // latin small letter e with circumflex and dot below
+	{'I', '\u0309'}:      '\u1ec8', // latin capital letter i with hook above
+	{'i', '\u0309'}:      '\u1ec9', // latin small letter i with hook above
+	{'I', '\u0323'}:      '\u1eca', // latin capital letter i with dot below
+	{'i', '\u0323'}:      '\u1ecb', // latin small letter i with dot below
+	{'O', '\u0323'}:      '\u1ecc', // latin capital letter o with dot below
+	{'o', '\u0323'}:      '\u1ecd', // latin small letter o with dot below
+	{'O', '\u0309'}:      '\u1ece', // latin capital letter o with hook above
+	{'o', '\u0309'}:      '\u1ecf', // latin small letter o with hook above
+	{'\u00d4', '\u0301'}: '\u1ed0', // latin capital letter o with circumflex and acute
+	{'\u00f4', '\u0301'}: '\u1ed1', // latin small letter o with circumflex and acute
+	{'\u00d4', '\u0300'}: '\u1ed2', // latin capital letter o with circumflex and grave
+	{'\u00f4', '\u0300'}: '\u1ed3', // latin small letter o with circumflex and grave
+	{'\u00d4', '\u0309'}: '\u1ed4', // latin capital letter o with circumflex and hook above
+	{'\u00f4', '\u0309'}: '\u1ed5', // latin small letter o with circumflex and hook above
+	{'\u00d4', '\u0303'}: '\u1ed6', // latin capital letter o with circumflex and tilde
+	{'\u00f4', '\u0303'}: '\u1ed7', // latin small letter o with circumflex and tilde
+	{'\u1ecc', '\u0302'}: '\u1ed8', // latin capital letter o with circumflex and dot below
+	{'\u1ecd', '\u0302'}: '\u1ed9', // latin small letter o with circumflex and dot below
+	{'\u01a0', '\u0301'}: '\u1eda', // latin capital letter o with horn and acute
+	{'\u01a1', '\u0301'}: '\u1edb', // latin small letter o with horn and acute
+	{'\u01a0', '\u0300'}: '\u1edc', // latin capital letter o with horn and grave
+	{'\u01a1', '\u0300'}: '\u1edd', // latin small letter o with horn and grave
+	{'\u01a0', '\u0309'}: '\u1ede', // latin capital letter o with horn and hook above
+	{'\u01a1', '\u0309'}: '\u1edf', // latin small letter o with horn and hook above
+	{'\u01a0', '\u0303'}: '\u1ee0', // latin capital letter o with horn and tilde
+	{'\u01a1', '\u0303'}: '\u1ee1', // latin small letter o with horn and tilde
+	{'\u01a0', '\u0323'}: '\u1ee2', // latin capital letter o with horn and dot below
+	{'\u01a1', '\u0323'}: '\u1ee3', // latin small letter o with horn and dot below
+	{'U', '\u0323'}:      '\u1ee4', // latin capital letter u with dot below
+	{'u', '\u0323'}:      '\u1ee5', // latin small letter u with dot below
+	{'U', '\u0309'}:      '\u1ee6', // latin capital letter u with hook above
+	{'u', '\u0309'}:      '\u1ee7', // latin small letter u with hook above
+	{'\u01af', '\u0301'}: '\u1ee8', // latin capital letter u with horn and acute
+	{'\u01b0', '\u0301'}: '\u1ee9', // latin small letter u with horn and acute
+	{'\u01af', '\u0300'}: '\u1eea', // latin capital letter u with horn and grave
+	{'\u01b0', '\u0300'}: '\u1eeb', // latin small letter u with horn and grave
+	{'\u01af', '\u0309'}: '\u1eec', // latin capital letter u with horn and hook above
+	{'\u01b0', '\u0309'}: '\u1eed', // latin small letter u with horn and hook above
+	{'\u01af', '\u0303'}: '\u1eee', // latin capital letter u with horn and tilde
+	{'\u01b0', '\u0303'}: '\u1eef', // latin small letter u with horn and tilde
+	{'\u01af', '\u0323'}: '\u1ef0', // latin capital letter u with horn and dot below
+	{'\u01b0', '\u0323'}: '\u1ef1', // latin small letter u with horn and dot below
+	{'Y', '\u0300'}:      '\u1ef2', // latin capital letter y with grave
+	{'y', '\u0300'}:      '\u1ef3', // latin small letter y with grave
+	{'Y', '\u0323'}:      '\u1ef4', // latin capital letter y with dot below
+	{'y', '\u0323'}:      '\u1ef5', // latin small letter y with dot below
+	{'Y', '\u0309'}:      '\u1ef6', // latin capital letter y with hook above
+	{'y', '\u0309'}:      '\u1ef7', // latin small letter y with hook above
+	{'Y', '\u0303'}:      '\u1ef8', // latin capital letter y with tilde
+	{'y', '\u0303'}:      '\u1ef9', // latin small letter y with tilde
+	{'\u03b1', '\u0313'}: '\u1f00', // greek small letter alpha with psili
+	{'\u03b1', '\u0314'}: '\u1f01', // greek small letter alpha with dasia
+	{'\u1f00', '\u0300'}: '\u1f02', // greek small letter alpha with psili and varia
+	{'\u1f01', '\u0300'}: '\u1f03', // greek small letter alpha with dasia and varia
+	{'\u1f00', '\u0301'}: '\u1f04', // greek small letter alpha with psili and oxia
+	{'\u1f01', '\u0301'}: '\u1f05', // greek small letter alpha with dasia and oxia
+	{'\u1f00', '\u0342'}: '\u1f06', // greek small letter alpha with psili and perispomeni
+	{'\u1f01', '\u0342'}: '\u1f07', // greek small letter alpha with dasia and perispomeni
+	{'\u0391', '\u0313'}: '\u1f08', // greek capital letter alpha with psili
+	{'\u0391', '\u0314'}: '\u1f09', // greek capital letter alpha with dasia
+	{'\u1f08', '\u0300'}: '\u1f0a', // greek capital letter alpha with psili and varia
+	{'\u1f09', '\u0300'}: '\u1f0b', // greek capital letter alpha with dasia and varia
+	{'\u1f08', '\u0301'}: '\u1f0c', // greek capital letter alpha with psili and oxia
+	{'\u1f09', '\u0301'}: '\u1f0d', // greek capital letter alpha with dasia and oxia
+	{'\u1f08', '\u0342'}: '\u1f0e', // greek capital letter alpha with psili and perispomeni
+	{'\u1f09', '\u0342'}: '\u1f0f', // greek capital letter alpha with dasia and perispomeni
+	{'\u03b5', '\u0313'}: '\u1f10', // greek small letter epsilon with psili
+	{'\u03b5', '\u0314'}: '\u1f11', // greek small letter epsilon with dasia
+	{'\u1f10', '\u0300'}: '\u1f12', // greek small letter epsilon with psili and varia
+	{'\u1f11', '\u0300'}: '\u1f13', // greek small letter epsilon with dasia and varia
+	{'\u1f10', '\u0301'}: '\u1f14', // greek small letter epsilon with psili and oxia
+	{'\u1f11', '\u0301'}: '\u1f15', // greek small letter epsilon with dasia and oxia
+	{'\u0395', '\u0313'}: '\u1f18', // greek capital letter epsilon with psili
+	{'\u0395', '\u0314'}: '\u1f19', // greek capital letter epsilon with dasia
+	{'\u1f18', '\u0300'}: '\u1f1a', // greek capital letter epsilon with psili and varia
+	{'\u1f19', '\u0300'}: '\u1f1b', // greek capital letter epsilon with dasia and varia
+	{'\u1f18', '\u0301'}: '\u1f1c', // greek capital letter epsilon with psili and oxia
+	{'\u1f19', '\u0301'}: '\u1f1d', // greek capital letter epsilon with dasia and oxia
+	{'\u03b7', '\u0313'}: '\u1f20', // greek small letter eta with psili
+	{'\u03b7', '\u0314'}: '\u1f21', // greek small letter eta with dasia
+	{'\u1f20', '\u0300'}: '\u1f22', // greek small letter eta with psili and varia
+	{'\u1f21', '\u0300'}: '\u1f23', // greek small letter eta with dasia and varia
+	{'\u1f20', '\u0301'}: '\u1f24', // greek small letter eta with psili and oxia
+	{'\u1f21', '\u0301'}: '\u1f25', // greek small letter eta with dasia and oxia
+	{'\u1f20', '\u0342'}: '\u1f26', // greek small letter eta with psili and perispomeni
+	{'\u1f21', '\u0342'}: '\u1f27', // greek small letter eta with dasia and perispomeni
+	{'\u0397', '\u0313'}: '\u1f28', // greek capital letter eta with psili
+	{'\u0397', '\u0314'}: '\u1f29', // greek capital letter eta with dasia
+	{'\u1f28', '\u0300'}: '\u1f2a', // greek capital letter eta with psili and varia
+	{'\u1f29', '\u0300'}: '\u1f2b', // greek capital letter eta with dasia and varia
+	{'\u1f28', '\u0301'}: '\u1f2c', // greek capital letter eta with psili and oxia
+	{'\u1f29', '\u0301'}: '\u1f2d', // greek capital letter eta with dasia and oxia
+	{'\u1f28', '\u0342'}: '\u1f2e', // greek capital letter eta with psili and perispomeni
+	{'\u1f29', '\u0342'}: '\u1f2f', // greek capital letter eta with dasia and perispomeni
+	{'\u03b9', '\u0313'}: '\u1f30', // greek small letter iota with psili
+	{'\u03b9', '\u0314'}: '\u1f31', // greek small letter iota with dasia
+	{'\u1f30', '\u0300'}: '\u1f32', // greek small letter iota with psili and varia
+	{'\u1f31', '\u0300'}: '\u1f33', // greek small letter iota with dasia and varia
+	{'\u1f30', '\u0301'}: '\u1f34', // greek small letter iota with psili and oxia
+	{'\u1f31', '\u0301'}: '\u1f35', // greek small letter iota with dasia and oxia
+	{'\u1f30', '\u0342'}: '\u1f36', // greek small letter iota with psili and perispomeni
+	{'\u1f31', '\u0342'}: '\u1f37', // greek small letter iota with dasia and perispomeni
+	{'\u0399', '\u0313'}: '\u1f38', // greek capital letter iota with psili
+	{'\u0399', '\u0314'}: '\u1f39', // greek capital letter iota with dasia
+	{'\u1f38', '\u0300'}: '\u1f3a', // greek capital letter iota with psili and varia
+	{'\u1f39', '\u0300'}: '\u1f3b', // greek capital letter iota with dasia and varia
+	{'\u1f38', '\u0301'}: '\u1f3c', // greek capital letter iota with psili and oxia
+	{'\u1f39', '\u0301'}: '\u1f3d', // greek capital letter iota with dasia and oxia
+	{'\u1f38', '\u0342'}: '\u1f3e', // greek capital letter iota with psili and perispomeni
+	{'\u1f39', '\u0342'}: '\u1f3f', // greek capital letter iota with dasia and perispomeni
+	{'\u03bf', '\u0313'}: '\u1f40', // greek small letter omicron with psili
+	{'\u03bf', '\u0314'}: '\u1f41', // greek small letter omicron with dasia
+	{'\u1f40', '\u0300'}: '\u1f42', // greek small letter omicron with psili and varia
+	{'\u1f41', '\u0300'}: '\u1f43', // greek small letter omicron with dasia and varia
+	{'\u1f40', '\u0301'}: '\u1f44', // greek small letter omicron with psili and oxia
+	{'\u1f41', '\u0301'}: '\u1f45', // greek small letter omicron with dasia and oxia
+	{'\u039f', '\u0313'}: '\u1f48', // greek capital letter omicron with psili
+	{'\u039f', '\u0314'}: '\u1f49', // greek capital letter omicron with dasia
+	{'\u1f48', '\u0300'}: '\u1f4a', // greek capital letter omicron with psili and varia
+	{'\u1f49', '\u0300'}: '\u1f4b', // greek capital letter omicron with dasia and varia
+	{'\u1f48', '\u0301'}: '\u1f4c', // greek capital letter omicron with psili and oxia
+	{'\u1f49', '\u0301'}: '\u1f4d', // greek capital letter omicron with dasia and oxia
+	{'\u03c5', '\u0313'}: '\u1f50', // greek small letter upsilon with psili
+	{'\u03c5', '\u0314'}: '\u1f51', // greek small letter upsilon with dasia
+	{'\u1f50', '\u0300'}: '\u1f52', // greek small letter upsilon with psili and varia
+	{'\u1f51', '\u0300'}: '\u1f53', // greek small letter upsilon with dasia and varia
+	{'\u1f50', '\u0301'}: '\u1f54', // greek small letter upsilon with psili and oxia
+	{'\u1f51', '\u0301'}: '\u1f55', // greek small letter upsilon with dasia and oxia
+	{'\u1f50', '\u0342'}: '\u1f56', // greek small letter upsilon with psili and perispomeni
+	{'\u1f51', '\u0342'}: '\u1f57', // greek small letter upsilon with dasia and perispomeni
+	{'\u03a5', '\u0314'}: '\u1f59', // greek capital letter upsilon with dasia
+	{'\u1f59', '\u0300'}: '\u1f5b', // greek capital letter upsilon with dasia and varia
+	{'\u1f59', '\u0301'}: '\u1f5d', // greek capital letter upsilon with dasia and oxia
+	{'\u1f59', '\u0342'}: '\u1f5f', // greek capital letter upsilon with dasia and perispomeni
+	{'\u03c9', '\u0313'}: '\u1f60', // greek small letter omega with psili
+	{'\u03c9', '\u0314'}: '\u1f61', // greek small letter omega with dasia
+	{'\u1f60', '\u0300'}: '\u1f62', // greek small letter omega with psili and varia
+	{'\u1f61', '\u0300'}: '\u1f63', // greek small letter omega with dasia and varia
+	{'\u1f60', '\u0301'}: '\u1f64', // greek small letter omega with psili and oxia
+	{'\u1f61', '\u0301'}: '\u1f65', // greek small letter omega with dasia and oxia
+	{'\u1f60', '\u0342'}: '\u1f66', // greek small letter omega with psili and perispomeni
+	{'\u1f61', '\u0342'}: '\u1f67', // greek small letter omega with dasia and perispomeni
+	{'\u03a9', '\u0313'}: '\u1f68', // greek capital letter omega with psili
+	{'\u03a9', '\u0314'}: '\u1f69', // greek capital letter omega with dasia
+	{'\u1f68', '\u0300'}: '\u1f6a', // greek capital letter omega with psili and varia
+	{'\u1f69', '\u0300'}: '\u1f6b', // greek capital letter omega with dasia and varia
+	{'\u1f68', '\u0301'}: '\u1f6c', // greek capital letter omega with psili and oxia
+	{'\u1f69', '\u0301'}: '\u1f6d', // greek capital letter omega with dasia and oxia
+	{'\u1f68', '\u0342'}: '\u1f6e', // greek capital letter omega with psili and perispomeni
+	{'\u1f69', '\u0342'}: '\u1f6f', // greek capital letter omega with dasia and perispomeni
+	{'\u03b1', '\u0300'}: '\u1f70', // greek small letter alpha with varia
+	{'\u03b5', '\u0300'}: '\u1f72', // greek small letter epsilon with varia
+	{'\u03b7', '\u0300'}: '\u1f74', // greek small letter eta with varia
+	{'\u03b9', '\u0300'}: '\u1f76', // greek small letter iota with varia
+	{'\u03bf', '\u0300'}: '\u1f78', // greek small letter omicron with varia
+	{'\u03c5', '\u0300'}: '\u1f7a', // greek small letter upsilon with varia
+	{'\u03c9', '\u0300'}: '\u1f7c', // greek small letter omega with varia
+	{'\u1f00', '\u0345'}: '\u1f80', // greek small letter alpha with psili and ypogegrammeni
+	{'\u1f01', '\u0345'}: '\u1f81', // greek small letter alpha with dasia and ypogegrammeni
+	{'\u1f02', '\u0345'}: '\u1f82', // greek small letter alpha with psili and varia and ypogegrammeni
+	{'\u1f03', '\u0345'}: '\u1f83', // greek small letter alpha with dasia and varia and ypogegrammeni
+	{'\u1f04', '\u0345'}: '\u1f84', // greek small letter alpha with psili and oxia and ypogegrammeni
+	{'\u1f05', '\u0345'}: '\u1f85', // greek small letter alpha with dasia and oxia and ypogegrammeni
+	{'\u1f06', '\u0345'}: '\u1f86', // greek small letter alpha with psili and perispomeni and ypogegrammeni
+	{'\u1f07', '\u0345'}: '\u1f87', // greek small letter alpha with dasia and perispomeni and ypogegrammeni
+	{'\u1f08', '\u0345'}: '\u1f88', // greek capital letter alpha with psili and prosgegrammeni
+	{'\u1f09', '\u0345'}: '\u1f89', // greek capital letter alpha with dasia and prosgegrammeni
+	{'\u1f0a', '\u0345'}: '\u1f8a', // greek capital letter alpha with psili and varia and prosgegrammeni
+	{'\u1f0b', '\u0345'}: '\u1f8b', // greek capital letter alpha with dasia and varia and prosgegrammeni
+	{'\u1f0c', '\u0345'}: '\u1f8c', // greek capital letter alpha with psili and oxia and prosgegrammeni
+	{'\u1f0d', '\u0345'}: '\u1f8d', // greek capital letter alpha with dasia and oxia and prosgegrammeni
+	{'\u1f0e', '\u0345'}: '\u1f8e', // greek capital letter alpha with psili and perispomeni and prosgegrammeni
+	{'\u1f0f', '\u0345'}: '\u1f8f', // greek capital letter alpha with dasia and perispomeni and prosgegrammeni
+	{'\u1f20', '\u0345'}: '\u1f90', // greek small letter eta with psili and ypogegrammeni
+	{'\u1f21', '\u0345'}: '\u1f91', // greek small letter eta with dasia and ypogegrammeni
+	{'\u1f22', '\u0345'}: '\u1f92', // greek small letter eta with psili and varia and ypogegrammeni
+	{'\u1f23', '\u0345'}: '\u1f93', // greek small letter eta with dasia and varia and ypogegrammeni
+	{'\u1f24', '\u0345'}: '\u1f94', // greek small letter eta with psili and oxia and ypogegrammeni
+	{'\u1f25', '\u0345'}: '\u1f95', // greek small letter eta with dasia and oxia and ypogegrammeni
+	{'\u1f26', '\u0345'}: '\u1f96', // greek small letter eta with psili and perispomeni and ypogegrammeni
+	{'\u1f27', '\u0345'}: '\u1f97', // greek small letter eta with dasia and perispomeni and ypogegrammeni
+	{'\u1f28', '\u0345'}: '\u1f98', // greek capital letter eta with psili and prosgegrammeni
+	{'\u1f29', '\u0345'}: '\u1f99', // greek capital letter eta with dasia and prosgegrammeni
+	{'\u1f2a', '\u0345'}: '\u1f9a', // greek capital letter eta with psili and varia and prosgegrammeni
+	{'\u1f2b', '\u0345'}: '\u1f9b', // greek capital letter eta with dasia and varia and prosgegrammeni
+	{'\u1f2c', '\u0345'}: '\u1f9c', // greek capital letter eta with psili and oxia and prosgegrammeni
+	{'\u1f2d', '\u0345'}: '\u1f9d', // greek capital letter eta with dasia and oxia and prosgegrammeni
+	{'\u1f2e', '\u0345'}: '\u1f9e', // greek capital letter eta with psili and perispomeni and prosgegrammeni
+	{'\u1f2f', '\u0345'}: '\u1f9f', // greek capital letter eta with dasia and perispomeni and prosgegrammeni
+	{'\u1f60', '\u0345'}: '\u1fa0', // greek small letter omega with psili and ypogegrammeni
+	{'\u1f61', '\u0345'}: '\u1fa1', // greek small letter omega with dasia and ypogegrammeni
+	{'\u1f62', '\u0345'}: '\u1fa2', // greek small letter omega with psili and varia and ypogegrammeni
+	{'\u1f63', '\u0345'}: '\u1fa3', // greek small letter omega with dasia and varia and ypogegrammeni
+	{'\u1f64', '\u0345'}: '\u1fa4', // greek small letter omega with psili and oxia and ypogegrammeni
+	{'\u1f65', '\u0345'}: '\u1fa5', // greek small letter omega with dasia and oxia and ypogegrammeni
+	{'\u1f66', '\u0345'}: '\u1fa6', // greek small letter omega with psili and perispomeni and ypogegrammeni
+	{'\u1f67', '\u0345'}: '\u1fa7', // greek small letter omega with dasia and perispomeni and ypogegrammeni
+	{'\u1f68', '\u0345'}: '\u1fa8', // greek capital letter omega with psili and prosgegrammeni
+	{'\u1f69', '\u0345'}: '\u1fa9', // greek capital letter omega with dasia and prosgegrammeni
+	{'\u1f6a', '\u0345'}: '\u1faa', // greek capital letter omega with psili and varia and prosgegrammeni
+	{'\u1f6b', '\u0345'}: '\u1fab', // greek capital letter omega with dasia and varia and prosgegrammeni
+	{'\u1f6c', '\u0345'}: '\u1fac', // greek capital letter omega with psili and oxia and prosgegrammeni
+	{'\u1f6d', '\u0345'}: '\u1fad', // greek capital letter omega with dasia and oxia and prosgegrammeni
+	{'\u1f6e', '\u0345'}: '\u1fae', // greek capital letter omega with psili and perispomeni and prosgegrammeni
+	{'\u1f6f', '\u0345'}: '\u1faf', // greek capital letter omega with dasia and perispomeni and prosgegrammeni
+	{'\u03b1', '\u0306'}: '\u1fb0', // greek small letter alpha with vrachy
+	{'\u03b1', '\u0304'}: '\u1fb1', // greek small letter alpha with macron
+	{'\u1f70', '\u0345'}: '\u1fb2', // greek small letter alpha with varia and ypogegrammeni
+	{'\u03b1', '\u0345'}: '\u1fb3', // greek small letter alpha with ypogegrammeni
+	{'\u03ac', '\u0345'}: '\u1fb4', // greek small letter alpha with oxia and ypogegrammeni
+	{'\u03b1', '\u0342'}: '\u1fb6', // greek small letter alpha with perispomeni
+	{'\u1fb6', '\u0345'}: '\u1fb7', // greek small letter alpha with perispomeni and ypogegrammeni
+	{'\u0391', '\u0306'}: '\u1fb8', // greek capital letter alpha with vrachy
+	{'\u0391', '\u0304'}: '\u1fb9', // greek capital letter alpha with macron
+	{'\u0391', '\u0300'}: '\u1fba', // greek capital letter alpha with varia
+	{'\u0391', '\u0345'}: '\u1fbc', // greek capital letter alpha with prosgegrammeni
+	{'\u00a8', '\u0342'}: '\u1fc1', // greek dialytika and perispomeni
+	{'\u1f74', '\u0345'}: '\u1fc2', // greek small letter eta with varia and ypogegrammeni
+	{'\u03b7', '\u0345'}: '\u1fc3', // greek small letter eta with ypogegrammeni
+	{'\u03ae', '\u0345'}: '\u1fc4', // greek small letter eta with oxia and ypogegrammeni
+	{'\u03b7', '\u0342'}: '\u1fc6', // greek small letter eta with perispomeni
+	{'\u1fc6', '\u0345'}: '\u1fc7', // greek small letter eta with perispomeni and ypogegrammeni
+	{'\u0395', '\u0300'}: '\u1fc8', // greek capital letter epsilon with varia
+	{'\u0397', '\u0300'}: '\u1fca', // greek capital letter eta with varia
+	{'\u0397', '\u0345'}: '\u1fcc', // greek capital letter eta with prosgegrammeni
+	{'\u1fbf', '\u0300'}: '\u1fcd', // greek psili and varia
+	{'\u1fbf', '\u0301'}: '\u1fce', // greek psili and oxia
+	{'\u1fbf', '\u0342'}: '\u1fcf', // greek psili and perispomeni
+	{'\u03b9', '\u0306'}: '\u1fd0', // greek small letter iota with vrachy
+	{'\u03b9', '\u0304'}: '\u1fd1', // greek small letter iota with macron
+	{'\u03ca', '\u0300'}: '\u1fd2', // greek small letter iota with dialytika and varia
+	{'\u03b9', '\u0342'}: '\u1fd6', // greek small letter iota with perispomeni
+	{'\u03ca', '\u0342'}: '\u1fd7', // greek small letter iota with dialytika and perispomeni
+	{'\u0399', '\u0306'}: '\u1fd8', // greek capital letter iota with vrachy
+	{'\u0399', '\u0304'}: '\u1fd9', // greek capital letter iota with macron
+	{'\u0399', '\u0300'}: '\u1fda', // greek capital letter iota with varia
+	{'\u1ffe', '\u0300'}: '\u1fdd', // greek dasia and varia
+	{'\u1ffe', '\u0301'}: '\u1fde', // greek dasia and oxia
+	{'\u1ffe', '\u0342'}: '\u1fdf', // greek dasia and perispomeni
+	{'\u03c5', '\u0306'}: '\u1fe0', // greek small letter upsilon with vrachy
+	{'\u03c5', '\u0304'}: '\u1fe1', // greek small letter upsilon with macron
+	{'\u03cb', '\u0300'}: '\u1fe2', // greek small letter upsilon with dialytika and varia
+	{'\u03c1', '\u0313'}: '\u1fe4', // greek small letter rho with psili
+	{'\u03c1', '\u0314'}: '\u1fe5', // greek small letter rho with dasia
+	{'\u03c5', '\u0342'}: '\u1fe6', // greek small letter upsilon with perispomeni
+	{'\u03cb', '\u0342'}: '\u1fe7', // greek small letter upsilon with dialytika and perispomeni
+	{'\u03a5', '\u0306'}: '\u1fe8', // greek capital letter upsilon with vrachy
+	{'\u03a5', '\u0304'}: '\u1fe9', // greek capital letter upsilon with macron
+	{'\u03a5', '\u0300'}: '\u1fea', // greek capital letter upsilon with varia
+	{'\u03a1', '\u0314'}: '\u1fec', // greek capital letter rho with dasia
+	{'\u00a8', '\u0300'}: '\u1fed', // greek dialytika and varia
+	{'\u1f7c', '\u0345'}: '\u1ff2', // greek small letter omega with varia and ypogegrammeni
+	{'\u03c9', '\u0345'}: '\u1ff3', // greek small letter omega with ypogegrammeni
+	{'\u03ce', '\u0345'}: '\u1ff4', // greek small letter omega with oxia and ypogegrammeni
+	{'\u03c9', '\u0342'}: '\u1ff6', // greek small letter omega with perispomeni
+	{'\u1ff6', '\u0345'}: '\u1ff7', // greek small letter omega with perispomeni and ypogegrammeni
+	{'\u039f', '\u0300'}: '\u1ff8', // greek capital letter omicron with varia
+	{'\u03a9', '\u0300'}: '\u1ffa', // greek capital letter omega with varia
+	{'\u03a9', '\u0345'}: '\u1ffc', // greek capital letter omega with prosgegrammeni
+	{'\u2190', '\u0338'}: '\u219a', // leftwards arrow with stroke
+	{'\u2192', '\u0338'}: '\u219b', // rightwards arrow with stroke
+	{'\u2194', '\u0338'}: '\u21ae', // left right arrow with stroke
+	{'\u21d0', '\u0338'}: '\u21cd', // leftwards double arrow with stroke
+	{'\u21d4', '\u0338'}: '\u21ce', // left right double arrow with stroke
+	{'\u21d2', '\u0338'}: '\u21cf', // rightwards double arrow with stroke
+	{'\u2203', '\u0338'}: '\u2204', // there does not exist
+	{'\u2208', '\u0338'}: '\u2209', // not an element of
+	{'\u220b', '\u0338'}: '\u220c', // does not contain as member
+	{'\u2223', '\u0338'}: '\u2224', // does not divide
+	{'\u2225', '\u0338'}: '\u2226', // not parallel to
+	{'\u223c', '\u0338'}: '\u2241', // not tilde
+	{'\u2243', '\u0338'}: '\u2244', // not asymptotically equal to
+	{'\u2245', '\u0338'}: '\u2247', // neither approximately nor actually equal to
+	{'\u2248', '\u0338'}: '\u2249', // not almost equal to
+	{'=', '\u0338'}:      '\u2260', // not equal to
+	{'\u2261', '\u0338'}: '\u2262', // not identical to
+	{'\u224d', '\u0338'}: '\u226d', // not equivalent to
+	{'<', '\u0338'}:      '\u226e', // not less-than
+	{'>', '\u0338'}:      '\u226f', // not greater-than
+	{'\u2264', '\u0338'}: '\u2270', // neither less-than nor equal to
+	{'\u2265', '\u0338'}: '\u2271', // neither greater-than nor equal to
+	{'\u2272', '\u0338'}: '\u2274', // neither less-than nor equivalent to
+	{'\u2273', '\u0338'}: '\u2275', // neither greater-than nor equivalent to
+	{'\u2276', '\u0338'}: '\u2278', // neither less-than nor greater-than
+	{'\u2277', '\u0338'}: '\u2279', // neither greater-than nor less-than
+	{'\u227a', '\u0338'}: '\u2280', // does not precede
+	{'\u227b', '\u0338'}: '\u2281', // does not succeed
+	{'\u2282', '\u0338'}: '\u2284', // not a subset of
+	{'\u2283', '\u0338'}: '\u2285', // not a superset of
+	{'\u2286', '\u0338'}: '\u2288', // neither a subset of nor equal to
+	{'\u2287', '\u0338'}: '\u2289', // neither a superset of nor equal to
+	{'\u22a2', '\u0338'}: '\u22ac', // does not prove
+	{'\u22a8', '\u0338'}: '\u22ad', // not true
+	{'\u22a9', '\u0338'}: '\u22ae', // does not force
+	{'\u22ab', '\u0338'}: '\u22af', // negated double vertical bar double right turnstile
+	{'\u227c', '\u0338'}: '\u22e0', // does not precede or equal
+	{'\u227d', '\u0338'}: '\u22e1', // does not succeed or equal
+	{'\u2291', '\u0338'}: '\u22e2', // not square image of or equal to
+	{'\u2292', '\u0338'}: '\u22e3', // not square original of or equal to
+	{'\u22b2', '\u0338'}: '\u22ea', // not normal subgroup of
+	{'\u22b3', '\u0338'}: '\u22eb', // does not contain as normal subgroup
+	{'\u22b4', '\u0338'}: '\u22ec', // not normal subgroup of or equal to
+	{'\u22b5', '\u0338'}: '\u22ed', // does not contain as normal subgroup or equal
+	{'\u304b', '\u3099'}: '\u304c', // hiragana letter ga
+	{'\u304d', '\u3099'}: '\u304e', // hiragana letter gi
+	{'\u304f', '\u3099'}: '\u3050', // hiragana letter gu
+	{'\u3051', '\u3099'}: '\u3052', // hiragana letter ge
+	{'\u3053', '\u3099'}: '\u3054', // hiragana letter go
+	{'\u3055', '\u3099'}: '\u3056', // hiragana letter za
+	{'\u3057', '\u3099'}: '\u3058', // hiragana letter zi
+	{'\u3059', '\u3099'}: '\u305a', // hiragana letter zu
+	{'\u305b', '\u3099'}: '\u305c', // hiragana letter ze
+	{'\u305d', '\u3099'}: '\u305e', // hiragana letter zo
+	{'\u305f', '\u3099'}: '\u3060', // hiragana letter da
+	{'\u3061', '\u3099'}: '\u3062', // hiragana letter di
+	{'\u3064', '\u3099'}: '\u3065', // hiragana letter du
+	{'\u3066', '\u3099'}: '\u3067', // hiragana letter de
+	{'\u3068', '\u3099'}: '\u3069', // hiragana letter do
+	{'\u306f', '\u3099'}: '\u3070', // hiragana letter ba
+	{'\u306f', '\u309a'}: '\u3071', // hiragana letter pa
+	{'\u3072', '\u3099'}: '\u3073', // hiragana letter bi
+	{'\u3072', '\u309a'}: '\u3074', // hiragana letter pi
+	{'\u3075', '\u3099'}: '\u3076', // hiragana letter bu
+	{'\u3075', '\u309a'}: '\u3077', // hiragana letter pu
+	{'\u3078', '\u3099'}: '\u3079', // hiragana letter be
+	{'\u3078', '\u309a'}: '\u307a', // hiragana letter pe
+	{'\u307b', '\u3099'}: '\u307c', // hiragana letter bo
+	{'\u307b', '\u309a'}: '\u307d', // hiragana letter po
+	{'\u3046', '\u3099'}: '\u3094', // hiragana letter vu
+	{'\u309d', '\u3099'}: '\u309e', // hiragana voiced iteration mark
+	{'\u30ab', '\u3099'}: '\u30ac', // katakana letter ga
+	{'\u30ad', '\u3099'}: '\u30ae', // katakana letter gi
+	{'\u30af', '\u3099'}: '\u30b0', // katakana letter gu
+	{'\u30b1', '\u3099'}: '\u30b2', // katakana letter ge
+	{'\u30b3', '\u3099'}: '\u30b4', // katakana letter go
+	{'\u30b5', '\u3099'}: '\u30b6', // katakana letter za
+	{'\u30b7', '\u3099'}: '\u30b8', // katakana letter zi
+	{'\u30b9', '\u3099'}: '\u30ba', // katakana letter zu
+	{'\u30bb', '\u3099'}: '\u30bc', // katakana letter ze
+	{'\u30bd', '\u3099'}: '\u30be', // katakana letter zo
+	{'\u30bf', '\u3099'}: '\u30c0', // katakana letter da
+	{'\u30c1', '\u3099'}: '\u30c2', // katakana letter di
+	{'\u30c4', '\u3099'}: '\u30c5', // katakana letter du
+	{'\u30c6', '\u3099'}: '\u30c7', // katakana letter de
+	{'\u30c8', '\u3099'}: '\u30c9', // katakana letter do
+	{'\u30cf', '\u3099'}: '\u30d0', // katakana letter ba
+	{'\u30cf', '\u309a'}: '\u30d1', // katakana letter pa
+	{'\u30d2', '\u3099'}: '\u30d3', // katakana letter bi
+	{'\u30d2', '\u309a'}: '\u30d4', // katakana letter pi
+	{'\u30d5', '\u3099'}: '\u30d6', // katakana letter bu
+	{'\u30d5', '\u309a'}: '\u30d7', // katakana letter pu
+	{'\u30d8', '\u3099'}: '\u30d9', // katakana letter be
+	{'\u30d8', '\u309a'}: '\u30da', // katakana letter pe
+	{'\u30db', '\u3099'}: '\u30dc', // katakana letter bo
+	{'\u30db', '\u309a'}: '\u30dd', // katakana letter po
+	{'\u30a6', '\u3099'}: '\u30f4', // katakana letter vu
+	{'\u30ef', '\u3099'}: '\u30f7', // katakana letter va
+	{'\u30f0', '\u3099'}: '\u30f8', // katakana letter vi
+	{'\u30f1', '\u3099'}: '\u30f9', // katakana letter ve
+	{'\u30f2', '\u3099'}: '\u30fa', // katakana letter vo
+	{'\u30fd', '\u3099'}: '\u30fe', // katakana voiced iteration mark
+}
+
+// singletons are characters NFC replaces with a single other character.
+var singletons = map[rune]rune{
+	'\u0340': '\u0300', // combining grave tone mark
+	'\u0341': '\u0301', // combining acute tone mark
+	'\u0343': '\u0313', // combining greek koronis
+	'\u0374': '\u02b9', // greek numeral sign
+	'\u037e': ';',      // greek question mark
+	'\u0387': '\u00b7', // greek ano teleia
+	'\u1f71': '\u03ac', // greek small letter alpha with oxia
+	'\u1f73': '\u03ad', // greek small letter epsilon with oxia
+	'\u1f75': '\u03ae', // greek small letter eta with oxia
+	'\u1f77': '\u03af', // greek small letter iota with oxia
+	'\u1f79': '\u03cc', // greek small letter omicron with oxia
+	'\u1f7b': '\u03cd', // greek small letter upsilon with oxia
+	'\u1f7d': '\u03ce', // greek small letter omega with oxia
+	'\u1fbb': '\u0386', // greek capital letter alpha with oxia
+	'\u1fbe': '\u03b9', // greek prosgegrammeni
+	'\u1fc9': '\u0388', // greek capital letter epsilon with oxia
+	'\u1fcb': '\u0389', // greek capital letter eta with oxia
+	'\u1fd3': '\u0390', // greek small letter iota with dialytika and oxia
+	'\u1fdb': '\u038a', // greek capital letter iota with oxia
+	'\u1fe3': '\u03b0', // greek small letter upsilon with dialytika and oxia
+	'\u1feb': '\u038e', // greek capital letter upsilon with oxia
+	'\u1fee': '\u0385', // greek dialytika and oxia
+	'\u1fef': '`',      // greek varia
+	'\u1ff9': '\u038c', // greek capital letter omicron with oxia
+	'\u1ffb': '\u038f', // greek capital letter omega with oxia
+	'\u1ffd': '\u00b4', // greek oxia
+	'\u2000': '\u2002', // en quad
+	'\u2001': '\u2003', // em quad
+	'\u2126': '\u03a9', // ohm sign
+	'\u212a': 'K',      // kelvin sign
+	'\u212b': '\u00c5', // angstrom sign
+	'\u2329': '\u3008', // left-pointing angle bracket
+	'\u232a': '\u3009', // right-pointing angle bracket
+}
+
+// exclusions are characters NFC decomposes but never recomposes, with their
+// canonical decompositions.
+var exclusions = map[rune][2]rune{
+	'\u0344':     {'\u0308', '\u0301'},         // combining greek dialytika tonos
+	'\u0958':     {'\u0915', '\u093c'},         // devanagari letter qa
+	'\u0959':     {'\u0916', '\u093c'},         // devanagari letter khha
+	'\u095a':     {'\u0917', '\u093c'},         // devanagari letter ghha
+	'\u095b':     {'\u091c', '\u093c'},         // devanagari letter za
+	'\u095c':     {'\u0921', '\u093c'},         // devanagari letter dddha
+	'\u095d':     {'\u0922', '\u093c'},         // devanagari letter rha
+	'\u095e':     {'\u092b', '\u093c'},         // devanagari letter fa
+	'\u095f':     {'\u092f', '\u093c'},         // devanagari letter yya
+	'\u09dc':     {'\u09a1', '\u09bc'},         // bengali letter rra
+	'\u09dd':     {'\u09a2', '\u09bc'},         // bengali letter rha
+	'\u09df':     {'\u09af', '\u09bc'},         // bengali letter yya
+	'\u0a33':     {'\u0a32', '\u0a3c'},         // gurmukhi letter lla
+	'\u0a36':     {'\u0a38', '\u0a3c'},         // gurmukhi letter sha
+	'\u0a59':     {'\u0a16', '\u0a3c'},         // gurmukhi letter khha
+	'\u0a5a':     {'\u0a17', '\u0a3c'},         // gurmukhi letter ghha
+	'\u0a5b':     {'\u0a1c', '\u0a3c'},         // gurmukhi letter za
+	'\u0a5e':     {'\u0a2b', '\u0a3c'},         // gurmukhi letter fa
+	'\u0b5c':     {'\u0b21', '\u0b3c'},         // oriya letter rra
+	'\u0b5d':     {'\u0b22', '\u0b3c'},         // oriya letter rha
+	'\u0f43':     {'\u0f42', '\u0fb7'},         // tibetan letter gha
+	'\u0f4d':     {'\u0f4c', '\u0fb7'},         // tibetan letter ddha
+	'\u0f52':     {'\u0f51', '\u0fb7'},         // tibetan letter dha
+	'\u0f57':     {'\u0f56', '\u0fb7'},         // tibetan letter bha
+	'\u0f5c':     {'\u0f5b', '\u0fb7'},         // tibetan letter dzha
+	'\u0f69':     {'\u0f40', '\u0fb5'},         // tibetan letter kssa
+	'\u0f73':     {'\u0f71', '\u0f72'},         // tibetan vowel sign ii
+	'\u0f75':     {'\u0f71', '\u0f74'},         // tibetan vowel sign uu
+	'\u0f76':     {'\u0fb2', '\u0f80'},         // tibetan vowel sign vocalic r
+	'\u0f78':     {'\u0fb3', '\u0f80'},         // tibetan vowel sign vocalic l
+	'\u0f81':     {'\u0f71', '\u0f80'},         // tibetan vowel sign reversed ii
+	'\u0f93':     {'\u0f92', '\u0fb7'},         // tibetan subjoined letter gha
+	'\u0f9d':     {'\u0f9c', '\u0fb7'},         // tibetan subjoined letter ddha
+	'\u0fa2':     {'\u0fa1', '\u0fb7'},         // tibetan subjoined letter dha
+	'\u0fa7':     {'\u0fa6', '\u0fb7'},         // tibetan subjoined letter bha
+	'\u0fac':     {'\u0fab', '\u0fb7'},         // tibetan subjoined letter dzha
+	'\u0fb9':     {'\u0f90', '\u0fb5'},         // tibetan subjoined letter kssa
+	'\u2adc':     {'\u2add', '\u0338'},         // forking
+	'\ufb1d':     {'\u05d9', '\u05b4'},         // hebrew letter yod with hiriq
+	'\ufb1f':     {'\u05f2', '\u05b7'},         // hebrew ligature yiddish yod yod patah
+	'\ufb2a':     {'\u05e9', '\u05c1'},         // hebrew letter shin with shin dot
+	'\ufb2b':     {'\u05e9', '\u05c2'},         // hebrew letter shin with sin dot
+	'\ufb2c':     {'\ufb49', '\u05c1'},         // hebrew letter shin with dagesh and shin dot
+	'\ufb2d':     {'\ufb49', '\u05c2'},         // hebrew letter shin with dagesh and sin dot
+	'\ufb2e':     {'\u05d0', '\u05b7'},         // hebrew letter alef with patah
+	'\ufb2f':     {'\u05d0', '\u05b8'},         // hebrew letter alef with qamats
+	'\ufb30':     {'\u05d0', '\u05bc'},         // hebrew letter alef with mapiq
+	'\ufb31':     {'\u05d1', '\u05bc'},         // hebrew letter bet with dagesh
+	'\ufb32':     {'\u05d2', '\u05bc'},         // hebrew letter gimel with dagesh
+	'\ufb33':     {'\u05d3', '\u05bc'},         // hebrew letter dalet with dagesh
+	'\ufb34':     {'\u05d4', '\u05bc'},         // hebrew letter he with mapiq
+	'\ufb35':     {'\u05d5', '\u05bc'},         // hebrew letter vav with dagesh
+	'\ufb36':     {'\u05d6', '\u05bc'},         // hebrew letter zayin with dagesh
+	'\ufb38':     {'\u05d8', '\u05bc'},         // hebrew letter tet with dagesh
+	'\ufb39':     {'\u05d9', '\u05bc'},         // hebrew letter yod with dagesh
+	'\ufb3a':     {'\u05da', '\u05bc'},         // hebrew letter final kaf with dagesh
+	'\ufb3b':     {'\u05db', '\u05bc'},         // hebrew letter kaf with dagesh
+	'\ufb3c':     {'\u05dc', '\u05bc'},         // hebrew letter lamed with dagesh
+	'\ufb3e':     {'\u05de', '\u05bc'},         // hebrew letter mem with dagesh
+	'\ufb40':     {'\u05e0', '\u05bc'},         // hebrew letter nun with dagesh
+	'\ufb41':     {'\u05e1', '\u05bc'},         // hebrew letter samekh with dagesh
+	'\ufb43':     {'\u05e3', '\u05bc'},         // hebrew letter final pe with dagesh
+	'\ufb44':     {'\u05e4', '\u05bc'},         // hebrew letter pe with dagesh
+	'\ufb46':     {'\u05e6', '\u05bc'},         // hebrew letter tsadi with dagesh
+	'\ufb47':     {'\u05e7', '\u05bc'},         // hebrew letter qof with dagesh
+	'\ufb48':     {'\u05e8', '\u05bc'},         // hebrew letter resh with dagesh
+	'\ufb49':     {'\u05e9', '\u05bc'},         // hebrew letter shin with dagesh
+	'\ufb4a':     {'\u05ea', '\u05bc'},         // hebrew letter tav with dagesh
+	'\ufb4b':     {'\u05d5', '\u05b9'},         // hebrew letter vav with holam
+	'\ufb4c':     {'\u05d1', '\u05bf'},         // hebrew letter bet with rafe
+	'\ufb4d':     {'\u05db', '\u05bf'},         // hebrew letter kaf with rafe
+	'\ufb4e':     {'\u05e4', '\u05bf'},         // hebrew letter pe with rafe
+	'\U0001d15e': {'\U0001d157', '\U0001d165'}, // musical symbol half note
+	'\U0001d15f': {'\U0001d158', '\U0001d165'}, // musical symbol quarter note
+	'\U0001d160': {'\U0001d15f', '\U0001d16e'}, // musical symbol eighth note
+	'\U0001d161': {'\U0001d15f', '\U0001d16f'}, // musical symbol sixteenth note
+	'\U0001d162': {'\U0001d15f', '\U0001d170'}, // musical symbol thirty-second note
+	'\U0001d163': {'\U0001d15f', '\U0001d171'}, // musical symbol sixty-fourth note
+	'\U0001d164': {'\U0001d15f', '\U0001d172'}, // musical symbol one hundred twenty-eighth note
+	'\U0001d1bb': {'\U0001d1b9', '\U0001d165'}, // musical symbol minima
+	'\U0001d1bc': {'\U0001d1ba', '\U0001d165'}, // musical symbol minima black
+	'\U0001d1bd': {'\U0001d1bb', '\U0001d16e'}, // musical symbol semiminima white
+	'\U0001d1be': {'\U0001d1bc', '\U0001d16e'}, // musical symbol semiminima black
+	'\U0001d1bf': {'\U0001d1bb', '\U0001d16f'}, // musical symbol fusa white
+	'\U0001d1c0': {'\U0001d1bc', '\U0001d16f'}, // musical symbol fusa black
+}
+
+// combiningClasses lists the ranges of characters with a nonzero canonical
+// combining class, in code point order.
+var combiningClasses = []struct {
+	lo, hi rune
+	class  uint8
+}{
+	{'\u0300', '\u0314', 230},
+	{'\u0315', '\u0315', 232},
+	{'\u0316', '\u0319', 220},
+	{'\u031a', '\u031a', 232},
+	{'\u031b', '\u031b', 216},
+	{'\u031c', '\u0320', 220},
+	{'\u0321', '\u0322', 202},
+	{'\u0323', '\u0326', 220},
+	{'\u0327', '\u0328', 202},
+	{'\u0329', '\u0333', 220},
+	{'\u0334', '\u0338', 1},
+	{'\u0339', '\u033c', 220},
+	{'\u033d', '\u0344', 230},
+	{'\u0345', '\u0345', 240},
+	{'\u0346', '\u0346', 230},
+	{'\u0347', '\u0349', 220},
+	{'\u034a', '\u034c', 230},
+	{'\u034d', '\u034e', 220},
+	{'\u0350', '\u0352', 230},
+	{'\u0353', '\u0356', 220},
+	{'\u0357', '\u0357', 230},
+	{'\u0358', '\u0358', 232},
+	{'\u0359', '\u035a', 220},
+	{'\u035b', '\u035b', 230},
+	{'\u035c', '\u035c', 233},
+	{'\u035d', '\u035e', 234},
+	{'\u035f', '\u035f', 233},
+	{'\u0360', '\u0361', 234},
+	{'\u0362', '\u0362', 233},
+	{'\u0363', '\u036f', 230},
+	{'\u0483', '\u0487', 230},
+	{'\u0591', '\u0591', 220},
+	{'\u0592', '\u0595', 230},
+	{'\u0596', '\u0596', 220},
+	{'\u0597', '\u0599', 230},
+	{'\u059a', '\u059a', 222},
+	{'\u059b', '\u059b', 220},
+	{'\u059c', '\u05a1', 230},
+	{'\u05a2', '\u05a7', 220},
+	{'\u05a8', '\u05a9', 230},
+	{'\u05aa', '\u05aa', 220},
+	{'\u05ab', '\u05ac', 230},
+	{'\u05ad', '\u05ad', 222},
+	{'\u05ae', '\u05ae', 228},
+	{'\u05af', '\u05af', 230},
+	{'\u05b0', '\u05b0', 10},
+	{'\u05b1', '\u05b1', 11},
+	{'\u05b2', '\u05b2', 12},
+	{'\u05b3', '\u05b3', 13},
+	{'\u05b4', '\u05b4', 14},
+	{'\u05b5', '\u05b5', 15},
+	{'\u05b6', '\u05b6', 16},
+	{'\u05b7', '\u05b7', 17},
+	{'\u05b8', '\u05b8', 18},
+	{'\u05b9', '\u05ba', 19},
+	{'\u05bb', '\u05bb', 20},
+	{'\u05bc', '\u05bc', 21},
+	{'\u05bd', '\u05bd', 22},
+	{'\u05bf', '\u05bf', 23},
+	{'\u05c1', '\u05c1', 24},
+	{'\u05c2', '\u05c2', 25},
+	{'\u05c4', '\u05c4', 230},
+	{'\u05c5', '\u05c5', 220},
+	{'\u05c7', '\u05c7', 18},
+	{'\u0610', '\u0617', 230},
+	{'\u0618', '\u0618', 30},
+	{'\u0619', '\u0619', 31},
+	{'\u061a', '\u061a', 32},
+	{'\u064b', '\u064b', 27},
+	{'\u064c', '\u064c', 28},
+	{'\u064d', '\u064d', 29},
+	{'\u064e', '\u064e', 30},
+	{'\u064f', '\u064f', 31},
+	{'\u0650', '\u0650', 32},
+	{'\u0651', '\u0651', 33},
+	{'\u0652', '\u0652', 34},
+	{'\u0653', '\u0654', 230},
+	{'\u0655', '\u0656', 220},
+	{'\u0657', '\u065b', 230},
+	{'\u065c', '\u065c', 220},
+	{'\u065d', '\u065e', 230},
+	{'\u065f', '\u065f', 220},
+	{'\u0670', '\u0670', 35},
+	{'\u06d6', '\u06dc', 230},
+	{'\u06df', '\u06e2', 230},
+	{'\u06e3', '\u06e3', 220},
+	{'\u06e4', '\u06e4', 230},
+	{'\u06e7', '\u06e8', 230},
+	{'\u06ea', '\u06ea', 220},
+	{'\u06eb', '\u06ec', 230},
+	{'\u06ed', '\u06ed', 220},
+	{'\u0711', '\u0711', 36},
+	{'\u0730', '\u0730', 230},
+	{'\u0731', '\u0731', 220},
+	{'\u0732', '\u0733', 230},
+	{'\u0734', '\u0734', 220},
+	{'\u0735', '\u0736', 230},
+	{'\u0737', '\u0739', 220},
+	{'\u073a', '\u073a', 230},
+	{'\u073b', '\u073c', 220},
+	{'\u073d', '\u073d', 230},
+	{'\u073e', '\u073e', 220},
+	{'\u073f', '\u0741', 230},
+	{'\u0742', '\u0742', 220},
+	{'\u0743', '\u0743', 230},
+	{'\u0744', '\u0744', 220},
+	{'\u0745', '\u0745', 230},
+	{'\u0746', '\u0746', 220},
+	{'\u0747', '\u0747', 230},
+	{'\u0748', '\u0748', 220},
+	{'\u0749', '\u074a', 230},
+	{'\u07eb', '\u07f1', 230},
+	{'\u07f2', '\u07f2', 220},
+	{'\u07f3', '\u07f3', 230},
+	{'\u07fd', '\u07fd', 220},
+	{'\u0816', '\u0819', 230},
+	{'\u081b', '\u0823', 230},
+	{'\u0825', '\u0827', 230},
+	{'\u0829', '\u082d', 230},
+	{'\u0859', '\u085b', 220},
+	{'\u0898', '\u0898', 230},
+	{'\u0899', '\u089b', 220},
+	{'\u089c', '\u089f', 230},
+	{'\u08ca', '\u08ce', 230},
+	{'\u08cf', '\u08d3', 220},
+	{'\u08d4', '\u08e1', 230},
+	{'\u08e3', '\u08e3', 220},
+	{'\u08e4', '\u08e5', 230},
+	{'\u08e6', '\u08e6', 220},
+	{'\u08e7', '\u08e8', 230},
+	{'\u08e9', '\u08e9', 220},
+	{'\u08ea', '\u08ec', 230},
+	{'\u08ed', '\u08ef', 220},
+	{'\u08f0', '\u08f0', 27},
+	{'\u08f1', '\u08f1', 28},
+	{'\u08f2', '\u08f2', 29},
+	{'\u08f3', '\u08f5', 230},
+	{'\u08f6', '\u08f6', 220},
+	{'\u08f7', '\u08f8', 230},
+	{'\u08f9', '\u08fa', 220},
+	{'\u08fb', '\u08ff', 230},
+	{'\u093c', '\u093c', 7},
+	{'\u094d', '\u094d', 9},
+	{'\u0951', '\u0951', 230},
+	{'\u0952', '\u0952', 220},
+	{'\u0953', '\u0954', 230},
+	{'\u09bc', '\u09bc', 7},
+	{'\u09cd', '\u09cd', 9},
+	{'\u09fe', '\u09fe', 230},
+	{'\u0a3c', '\u0a3c', 7},
+	{'\u0a4d', '\u0a4d', 9},
+	{'\u0abc', '\u0abc', 7},
+	{'\u0acd', '\u0acd', 9},
+	{'\u0b3c', '\u0b3c', 7},
+	{'\u0b4d', '\u0b4d', 9},
+	{'\u0bcd', '\u0bcd', 9},
+	{'\u0c3c', '\u0c3c', 7},
+	{'\u0c4d', '\u0c4d', 9},
+	{'\u0c55', '\u0c55', 84},
+	{'\u0c56', '\u0c56', 91},
+	{'\u0cbc', '\u0cbc', 7},
+	{'\u0ccd', '\u0ccd', 9},
+	{'\u0d3b', '\u0d3c', 9},
+	{'\u0d4d', '\u0d4d', 9},
+	{'\u0dca', '\u0dca', 9},
+	{'\u0e38', '\u0e39', 103},
+	{'\u0e3a', '\u0e3a', 9},
+	{'\u0e48', '\u0e4b', 107},
+	{'\u0eb8', '\u0eb9', 118},
+	{'\u0eba', '\u0eba', 9},
+	{'\u0ec8', '\u0ecb', 122},
+	{'\u0f18', '\u0f19', 220},
+	{'\u0f35', '\u0f35', 220},
+	{'\u0f37', '\u0f37', 220},
+	{'\u0f39', '\u0f39', 216},
+	{'\u0f71', '\u0f71', 129},
+	{'\u0f72', '\u0f72', 130},
+	{'\u0f74', '\u0f74', 132},
+	{'\u0f7a', '\u0f7d', 130},
+	{'\u0f80', '\u0f80', 130},
+	{'\u0f82', '\u0f83', 230},
+	{'\u0f84', '\u0f84', 9},
+	{'\u0f86', '\u0f87', 230},
+	{'\u0fc6', '\u0fc6', 220},
+	{'\u1037', '\u1037', 7},
+	{'\u1039', '\u103a', 9},
+	{'\u108d', '\u108d', 220},
+	{'\u135d', '\u135f', 230},
+	{'\u1714', '\u1715', 9},
+	{'\u1734', '\u1734', 9},
+	{'\u17d2', '\u17d2', 9},
+	{'\u17dd', '\u17dd', 230},
+	{'\u18a9', '\u18a9', 228},
+	{'\u1939', '\u1939', 222},
+	{'\u193a', '\u193a', 230},
+	{'\u193b', '\u193b', 220},
+	{'\u1a17', '\u1a17', 230},
+	{'\u1a18', '\u1a18', 220},
+	{'\u1a60', '\u1a60', 9},
+	{'\u1a75', '\u1a7c', 230},
+	{'\u1a7f', '\u1a7f', 220},
+	{'\u1ab0', '\u1ab4', 230},
+	{'\u1ab5', '\u1aba', 220},
+	{'\u1abb', '\u1abc', 230},
+	{'\u1abd', '\u1abd', 220},
+	{'\u1abf', '\u1ac0', 220},
+	{'\u1ac1', '\u1ac2', 230},
+	{'\u1ac3', '\u1ac4', 220},
+	{'\u1ac5', '\u1ac9', 230},
+	{'\u1aca', '\u1aca', 220},
+	{'\u1acb', '\u1ace', 230},
+	{'\u1b34', '\u1b34', 7},
+	{'\u1b44', '\u1b44', 9},
+	{'\u1b6b', '\u1b6b', 230},
+	{'\u1b6c', '\u1b6c', 220},
+	{'\u1b6d', '\u1b73', 230},
+	{'\u1baa', '\u1bab', 9},
+	{'\u1be6', '\u1be6', 7},
+	{'\u1bf2', '\u1bf3', 9},
+	{'\u1c37', '\u1c37', 7},
+	{'\u1cd0', '\u1cd2', 230},
+	{'\u1cd4', '\u1cd4', 1},
+	{'\u1cd5', '\u1cd9', 220},
+	{'\u1cda', '\u1cdb', 230},
+	{'\u1cdc', '\u1cdf', 220},
+	{'\u1ce0', '\u1ce0', 230},
+	{'\u1ce2', '\u1ce8', 1},
+	{'\u1ced', '\u1ced', 220},
+	{'\u1cf4', '\u1cf4', 230},
+	{'\u1cf8', '\u1cf9', 230},
+	{'\u1dc0', '\u1dc1', 230},
+	{'\u1dc2', '\u1dc2', 220},
+	{'\u1dc3', '\u1dc9', 230},
+	{'\u1dca', '\u1dca', 220},
+	{'\u1dcb', '\u1dcc', 230},
+	{'\u1dcd', '\u1dcd', 234},
+	{'\u1dce', '\u1dce', 214},
+	{'\u1dcf', '\u1dcf', 220},
+	{'\u1dd0', '\u1dd0', 202},
+	{'\u1dd1', '\u1df5', 230},
+	{'\u1df6', '\u1df6', 232},
+	{'\u1df7', '\u1df8', 228},
+	{'\u1df9', '\u1df9', 220},
+	{'\u1dfa', '\u1dfa', 218},
+	{'\u1dfb', '\u1dfb', 230},
+	{'\u1dfc', '\u1dfc', 233},
+	{'\u1dfd', '\u1dfd', 220},
+	{'\u1dfe', '\u1dfe', 230},
+	{'\u1dff', '\u1dff', 220},
+	{'\u20d0', '\u20d1', 230},
+	{'\u20d2', '\u20d3', 1},
+	{'\u20d4', '\u20d7', 230},
+	{'\u20d8', '\u20da', 1},
+	{'\u20db', '\u20dc', 230},
+	{'\u20e1', '\u20e1', 230},
+	{'\u20e5', '\u20e6', 1},
+	{'\u20e7', '\u20e7', 230},
+	{'\u20e8', '\u20e8', 220},
+	{'\u20e9', '\u20e9', 230},
+	{'\u20ea', '\u20eb', 1},
+	{'\u20ec', '\u20ef', 220},
+	{'\u20f0', '\u20f0', 230},
+	{'\u2cef', '\u2cf1', 230},
+	{'\u2d7f', '\u2d7f', 9},
+	{'\u2de0', '\u2dff', 230},
+	{'\u302a', '\u302a', 218},
+	{'\u302b', '\u302b', 228},
+	{'\u302c', '\u302c', 232},
+	{'\u302d', '\u302d', 222},
+	{'\u302e', '\u302f', 224},
+	{'\u3099', '\u309a', 8},
+	{'\ua66f', '\ua66f', 230},
+	{'\ua674', '\ua67d', 230},
+	{'\ua69e', '\ua69f', 230},
+	{'\ua6f0', '\ua6f1', 230},
+	{'\ua806', '\ua806', 9},
+	{'\ua82c', '\ua82c', 9},
+	{'\ua8c4', '\ua8c4', 9},
+	{'\ua8e0', '\ua8f1', 230},
+	{'\ua92b', '\ua92d', 220},
+	{'\ua953', '\ua953', 9},
+	{'\ua9b3', '\ua9b3', 7},
+	{'\ua9c0', '\ua9c0', 9},
+	{'\uaab0', '\uaab0', 230},
+	{'\uaab2', '\uaab3', 230},
+	{'\uaab4', '\uaab4', 220},
+	{'\uaab7', '\uaab8', 230},
+	{'\uaabe', '\uaabf', 230},
+	{'\uaac1', '\uaac1', 230},
+	{'\uaaf6', '\uaaf6', 9},
+	{'\uabed', '\uabed', 9},
+	{'\ufb1e', '\ufb1e', 26},
+	{'\ufe20', '\ufe26', 230},
+	{'\ufe27', '\ufe2d', 220},
+	{'\ufe2e', '\ufe2f', 230},
+	{'\U000101fd', '\U000101fd', 220},
+	{'\U000102e0', '\U000102e0', 220},
+	{'\U00010376', '\U0001037a', 230},
+	{'\U00010a0d', '\U00010a0d', 220},
+	{'\U00010a0f', '\U00010a0f', 230},
+	{'\U00010a38', '\U00010a38', 230},
+	{'\U00010a39', '\U00010a39', 1},
+	{'\U00010a3a', '\U00010a3a', 220},
+	{'\U00010a3f', '\U00010a3f', 9},
+	{'\U00010ae5', '\U00010ae5', 230},
+	{'\U00010ae6', '\U00010ae6', 220},
+	{'\U00010d24', '\U00010d27', 230},
+	{'\U00010eab', '\U00010eac', 230},
+	{'\U00010f46', '\U00010f47', 220},
+	{'\U00010f48', '\U00010f4a', 230},
+	{'\U00010f4b', '\U00010f4b', 220},
+	{'\U00010f4c', '\U00010f4c', 230},
+	{'\U00010f4d', '\U00010f50', 220},
+	{'\U00010f82', '\U00010f82', 230},
+	{'\U00010f83', '\U00010f83', 220},
+	{'\U00010f84', '\U00010f84', 230},
+	{'\U00010f85', '\U00010f85', 220},
+	{'\U00011046', '\U00011046', 9},
+	{'\U00011070', '\U00011070', 9},
+	{'\U0001107f', '\U0001107f', 9},
+	{'\U000110b9', '\U000110b9', 9},
+	{'\U000110ba', '\U000110ba', 7},
+	{'\U00011100', '\U00011102', 230},
+	{'\U00011133', '\U00011134', 9},
+	{'\U00011173', '\U00011173', 7},
+	{'\U000111c0', '\U000111c0', 9},
+	{'\U000111ca', '\U000111ca', 7},
+	{'\U00011235', '\U00011235', 9},
+	{'\U00011236', '\U00011236', 7},
+	{'\U000112e9', '\U000112e9', 7},
+	{'\U000112ea', '\U000112ea', 9},
+	{'\U0001133b', '\U0001133c', 7},
+	{'\U0001134d', '\U0001134d', 9},
+	{'\U00011366', '\U0001136c', 230},
+	{'\U00011370', '\U00011374', 230},
+	{'\U00011442', '\U00011442', 9},
+	{'\U00011446', '\U00011446', 7},
+	{'\U0001145e', '\U0001145e', 230},
+	{'\U000114c2', '\U000114c2', 9},
+	{'\U000114c3', '\U000114c3', 7},
+	{'\U000115bf', '\U000115bf', 9},
+	{'\U000115c0', '\U000115c0', 7},
+	{'\U0001163f', '\U0001163f', 9},
+	{'\U000116b6', '\U000116b6', 9},
+	{'\U000116b7', '\U000116b7', 7},
+	{'\U0001172b', '\U0001172b', 9},
+	{'\U00011839', '\U00011839', 9},
+	{'\U0001183a', '\U0001183a', 7},
+	{'\U0001193d', '\U0001193e', 9},
+	{'\U00011943', '\U00011943', 7},
+	{'\U000119e0', '\U000119e0', 9},
+	{'\U00011a34', '\U00011a34', 9},
+	{'\U00011a47', '\U00011a47', 9},
+	{'\U00011a99', '\U00011a99', 9},
+	{'\U00011c3f', '\U00011c3f', 9},
+	{'\U00011d42', '\U00011d42', 7},
+	{'\U00011d44', '\U00011d45', 9},
+	{'\U00011d97', '\U00011d97', 9},
+	{'\U00016af0', '\U00016af4', 1},
+	{'\U00016b30', '\U00016b36', 230},
+	{'\U00016ff0', '\U00016ff1', 6},
+	{'\U0001bc9e', '\U0001bc9e', 1},
+	{'\U0001d165', '\U0001d166', 216},
+	{'\U0001d167', '\U0001d169', 1},
+	{'\U0001d16d', '\U0001d16d', 226},
+	{'\U0001d16e', '\U0001d172', 216},
+	{'\U0001d17b', '\U0001d182', 220},
+	{'\U0001d185', '\U0001d189', 230},
+	{'\U0001d18a', '\U0001d18b', 220},
+	{'\U0001d1aa', '\U0001d1ad', 230},
+	{'\U0001d242', '\U0001d244', 230},
+	{'\U0001e000', '\U0001e006', 230},
+	{'\U0001e008', '\U0001e018', 230},
+	{'\U0001e01b', '\U0001e021', 230},
+	{'\U0001e023', '\U0001e024', 230},
+	{'\U0001e026', '\U0001e02a', 230},
+	{'\U0001e130', '\U0001e136', 230},
+	{'\U0001e2ae', '\U0001e2ae', 230},
+	{'\U0001e2ec', '\U0001e2ef', 230},
+	{'\U0001e8d0', '\U0001e8d6', 220},
+	{'\U0001e944', '\U0001e949', 230},
+	{'\U0001e94a', '\U0001e94a', 7},
+}
@@ -0,0 +1,2048 @@
+package unicodetext
+
+// emojiNames holds short spoken names for emoji and pictographic symbols:
+// CLDR short names for common emoji, lower-cased Unicode character names
+// (Unicode 14) for the rest.
+var emojiNames = map[rune]string{
+	0xA9:    "copyright",
+	0xAE:    "registered",
+	0x203C:  "double exclamation mark",
+	0x2049:  "exclamation question mark",
+	0x2122:  "trade mark",
+	0x2139:  "information",
+	0x231A:  "watch",
+	0x231B:  "hourglass done",
+	0x2328:  "keyboard",
+	0x23CF:  "eject button",
+	0x23E9:  "fast-forward button",
+	0x23EA:  "fast reverse button",
+	0x23EB:  "fast up button",
+	0x23EC:  "fast down button",
+	0x23ED:  "next track button",
+	0x23EE:  "last track button",
+	0x23EF:  "play or pause button",
+	0x23F0:  "alarm clock",
+	0x23F1:  "stopwatch",
+	0x23F2:  "timer clock",
+	0x23F3:  "hourglass not done",
+	0x23F8:  "pause button",
+	0x23F9:  "stop button",
+	0x23FA:  "record button",
+	0x24C2:  "circled M",
+	0x2600:  "sun",
+	0x2601:  "cloud",
+	0x2602:  "umbrella",
+	0x2603:  "snowman",
+	0x2604:  "comet",
+	0x2605:  "star",
+	0x2606:  "star",
+	0x2607:  "lightning",
+	0x2608:  "thunderstorm",
+	0x2609:  "sun",
+	0x260A:  "ascending node",
+	0x260B:  "descending node",
+	0x260C:  "conjunction",
+	0x260D:  "opposition",
+	0x260E:  "telephone",
+	0x260F:  "telephone",
+	0x2610:  "ballot box",
+	0x2611:  "ballot box with check",
+	0x2612:  "ballot box with x",
+	0x2613:  "saltire",
+	0x2614:  "umbrella with rain drops",
+	0x2615:  "hot beverage",
+	0x2616:  "shogi piece",
+	0x2617:  "shogi piece",
+	0x2618:  "shamrock",
+	0x2619:  "reversed rotated floral heart bullet",
+	0x261A:  "left pointing index",
+	0x261B:  "right pointing index",
+	0x261C:  "left pointing index",
+	0x261D:  "index pointing up",
+	0x261E:  "right pointing index",
+	0x261F:  "down pointing index",
+	0x2620:  "skull and crossbones",
+	0x2621:  "caution",
+	0x2622:  "radioactive",
+	0x2623:  "biohazard",
+	0x2624:  "caduceus",
+	0x2625:  "ankh",
+	0x2626:  "orthodox cross",
+	0x2627:  "chi rho",
+	0x2628:  "cross of lorraine",
+	0x2629:  "cross of jerusalem",
+	0x262A:  "star and crescent",
+	0x262B:  "farsi",
+	0x262C:  "adi shakti",
+	0x262D:  "hammer and sickle",
+	0x262E:  "peace symbol",
+	0x262F:  "yin yang",
+	0x2630:  "trigram for heaven",
+	0x2631:  "trigram for lake",
+	0x2632:  "trigram for fire",
+	0x2633:  "trigram for thunder",
+	0x2634:  "trigram for wind",
+	0x2635:  "trigram for water",
+	0x2636:  "trigram for mountain",
+	0x2637:  "trigram for earth",
+	0x2638:  "wheel of dharma",
+	0x2639:  "frowning face",
+	0x263A:  "smiling face",
+	0x263B:  "smiling face",
+	0x263C:  "sun with rays",
+	0x263D:  "first quarter moon",
+	0x263E:  "last quarter moon",
+	0x263F:  "mercury",
+	0x2640:  "female",
+	0x2641:  "earth",
+	0x2642:  "male",
+	0x2643:  "jupiter",
+	0x2644:  "saturn",
+	0x2645:  "uranus",
+	0x2646:  "neptune",
+	0x2647:  "pluto",
+	0x2648:  "Aries",
+	0x2649:  "taurus",
+	0x264A:  "gemini",
+	0x264B:  "cancer",
+	0x264C:  "leo",
+	0x264D:  "virgo",
+	0x264E:  "libra",
+	0x264F:  "scorpius",
+	0x2650:  "sagittarius",
+	0x2651:  "capricorn",
+	0x2652:  "aquarius",
+	0x2653:  "pisces",
+	0x2654:  "chess king",
+	0x2655:  "chess queen",
+	0x2656:  "chess rook",
+	0x2657:  "chess bishop",
+	0x2658:  "chess knight",
+	0x2659:  "chess pawn",
+	0x265A:  "chess king",
+	0x265B:  "chess queen",
+	0x265C:  "chess rook",
+	0x265D:  "chess bishop",
+	0x265E:  "chess knight",
+	0x265F:  "chess pawn",
+	0x2660:  "spade suit",
+	0x2661:  "heart suit",
+	0x2662:  "diamond suit",
+	0x2663:  "club suit",
+	0x2664:  "spade suit",
+	0x2665:  "heart suit",
+	0x2666:  "diamond suit",
+	0x2667:  "club suit",
+	0x2668:  "hot springs",
+	0x2669:  "quarter note",
+	0x266A:  "eighth note",
+	0x266B:  "beamed eighth notes",
+	0x266C:  "beamed sixteenth notes",
+	0x266D:  "music flat",
+	0x266E:  "music natural",
+	0x266F:  "music sharp",
+	0x2670:  "west syriac cross",
+	0x2671:  "east syriac cross",
+	0x2672:  "universal recycling",
+	0x2673:  "recycling symbol for type-1 plastics",
+	0x2674:  "recycling symbol for type-2 plastics",
+	0x2675:  "recycling symbol for type-3 plastics",
+	0x2676:  "recycling symbol for type-4 plastics",
+	0x2677:  "recycling symbol for type-5 plastics",
+	0x2678:  "recycling symbol for type-6 plastics",
+	0x2679:  "recycling symbol for type-7 plastics",
+	0x267A:  "recycling symbol for generic materials",
+	0x267B:  "recycling symbol",
+	0x267C:  "recycled paper",
+	0x267D:  "partially-recycled paper",
+	0x267E:  "permanent paper",
+	0x267F:  "wheelchair",
+	0x2680:  "die face-1",
+	0x2681:  "die face-2",
+	0x2682:  "die face-3",
+	0x2683:  "die face-4",
+	0x2684:  "die face-5",
+	0x2685:  "die face-6",
+	0x2686:  "circle with dot right",
+	0x2687:  "circle with two dots",
+	0x2688:  "circle with white dot right",
+	0x2689:  "circle with two white dots",
+	0x268A:  "monogram for yang",
+	0x268B:  "monogram for yin",
+	0x268C:  "digram for greater yang",
+	0x268D:  "digram for lesser yin",
+	0x268E:  "digram for lesser yang",
+	0x268F:  "digram for greater yin",
+	0x2690:  "flag",
+	0x2691:  "flag",
+	0x2692:  "hammer and pick",
+	0x2693:  "anchor",
+	0x2694:  "crossed swords",
+	0x2695:  "staff of aesculapius",
+	0x2696:  "scales",
+	0x2697:  "alembic",
+	0x2698:  "flower",
+	0x2699:  "gear",
+	0x269A:  "staff of hermes",
+	0x269B:  "atom",
+	0x269C:  "fleur-de-lis",
+	0x269D:  "outlined white star",
+	0x269E:  "three lines converging right",
+	0x269F:  "three lines converging left",
+	0x26A0:  "warning",
+	0x26A1:  "high voltage",
+	0x26A2:  "doubled female",
+	0x26A3:  "doubled male",
+	0x26A4:  "interlocked female and male",
+	0x26A5:  "male and female",
+	0x26A6:  "male with stroke",
+	0x26A7:  "male with stroke and male and female",
+	0x26A8:  "vertical male with stroke",
+	0x26A9:  "horizontal male with stroke",
+	0x26AA:  "white circle",
+	0x26AB:  "black circle",
+	0x26AC:  "medium small white circle",
+	0x26AD:  "marriage",
+	0x26AE:  "divorce",
+	0x26AF:  "unmarried partnership",
+	0x26B0:  "coffin",
+	0x26B1:  "funeral urn",
+	0x26B2:  "neuter",
+	0x26B3:  "ceres",
+	0x26B4:  "pallas",
+	0x26B5:  "juno",
+	0x26B6:  "vesta",
+	0x26B7:  "chiron",
+	0x26B8:  "moon lilith",
+	0x26B9:  "sextile",
+	0x26BA:  "semisextile",
+	0x26BB:  "quincunx",
+	0x26BC:  "sesquiquadrate",
+	0x26BD:  "soccer ball",
+	0x26BE:  "baseball",
+	0x26BF:  "squared key",
+	0x26C0:  "draughts man",
+	0x26C1:  "draughts king",
+	0x26C2:  "draughts man",
+	0x26C3:  "draughts king",
+	0x26C4:  "snowman without snow",
+	0x26C5:  "sun behind cloud",
+	0x26C6:  "rain",
+	0x26C7:  "snowman",
+	0x26C8:  "thunder cloud and rain",
+	0x26C9:  "turned white shogi piece",
+	0x26CA:  "turned black shogi piece",
+	0x26CB:  "diamond in square",
+	0x26CC:  "crossing lanes",
+	0x26CD:  "disabled car",
+	0x26CE:  "ophiuchus",
+	0x26CF:  "pick",
+	0x26D0:  "car sliding",
+	0x26D1:  "helmet with white cross",
+	0x26D2:  "circled crossing lanes",
+	0x26D3:  "chains",
+	0x26D4:  "no entry",
+	0x26D5:  "alternate one-way left way traffic",
+	0x26D6:  "two-way left way traffic",
+	0x26D7:  "two-way left way traffic",
+	0x26D8:  "left lane merge",
+	0x26D9:  "left lane merge",
+	0x26DA:  "drive slow",
+	0x26DB:  "down-pointing triangle",
+	0x26DC:  "left closed entry",
+	0x26DD:  "squared saltire",
+	0x26DE:  "falling diagonal in white circle in black square",
+	0x26DF:  "truck",
+	0x26E0:  "restricted left entry-1",
+	0x26E1:  "restricted left entry-2",
+	0x26E2:  "astronomical symbol for uranus",
+	0x26E3:  "circle with stroke and two dots above",
+	0x26E4:  "pentagram",
+	0x26E5:  "right-handed interlaced pentagram",
+	0x26E6:  "left-handed interlaced pentagram",
+	0x26E7:  "inverted pentagram",
+	0x26E8:  "cross on shield",
+	0x26E9:  "shinto shrine",
+	0x26EA:  "church",
+	0x26EB:  "castle",
+	0x26EC:  "historic site",
+	0x26ED:  "gear without hub",
+	0x26EE:  "gear with handles",
+	0x26EF:  "map symbol for lighthouse",
+	0x26F0:  "mountain",
+	0x26F1:  "umbrella on ground",
+	0x26F2:  "fountain",
+	0x26F3:  "flag in hole",
+	0x26F4:  "ferry",
+	0x26F5:  "sailboat",
+	0x26F6:  "square four corners",
+	0x26F7:  "skier",
+	0x26F8:  "ice skate",
+	0x26F9:  "person with ball",
+	0x26FA:  "tent",
+	0x26FB:  "japanese bank",
+	0x26FC:  "headstone graveyard",
+	0x26FD:  "fuel pump",
+	0x26FE:  "cup on black square",
+	0x26FF:  "flag with horizontal middle black stripe",
+	0x2700:  "safety scissors",
+	0x2701:  "upper blade scissors",
+	0x2702:  "scissors",
+	0x2703:  "lower blade scissors",
+	0x2704:  "scissors",
+	0x2705:  "check mark button",
+	0x2706:  "telephone location",
+	0x2707:  "tape drive",
+	0x2708:  "airplane",
+	0x2709:  "envelope",
+	0x270A:  "raised fist",
+	0x270B:  "raised hand",
+	0x270C:  "victory hand",
+	0x270D:  "writing hand",
+	0x270E:  "lower right pencil",
+	0x270F:  "pencil",
+	0x2710:  "upper right pencil",
+	0x2711:  "white nib",
+	0x2712:  "black nib",
+	0x2713:  "check mark",
+	0x2714:  "check mark",
+	0x2715:  "multiplication x",
+	0x2716:  "multiply",
+	0x2717:  "ballot x",
+	0x2718:  "ballot x",
+	0x2719:  "outlined greek cross",
+	0x271A:  "greek cross",
+	0x271B:  "open centre cross",
+	0x271C:  "open centre cross",
+	0x271D:  "latin cross",
+	0x271E:  "shadowed white latin cross",
+	0x271F:  "outlined latin cross",
+	0x2720:  "maltese cross",
+	0x2721:  "star of david",
+	0x2722:  "four teardrop-spoked asterisk",
+	0x2723:  "four balloon-spoked asterisk",
+	0x2724:  "four balloon-spoked asterisk",
+	0x2725:  "four club-spoked asterisk",
+	0x2726:  "four pointed star",
+	0x2727:  "four pointed star",
+	0x2728:  "sparkles",
+	0x2729:  "stress outlined white star",
+	0x272A:  "circled white star",
+	0x272B:  "open centre black star",
+	0x272C:  "centre white star",
+	0x272D:  "outlined black star",
+	0x272E:  "outlined black star",
+	0x272F:  "pinwheel star",
+	0x2730:  "shadowed white star",
+	0x2731:  "asterisk",
+	0x2732:  "open centre asterisk",
+	0x2733:  "eight-spoked asterisk",
+	0x2734:  "eight-pointed star",
+	0x2735:  "eight pointed pinwheel star",
+	0x2736:  "six pointed black star",
+	0x2737:  "eight pointed rectilinear black star",
+	0x2738:  "eight pointed rectilinear black star",
+	0x2739:  "twelve pointed black star",
+	0x273A:  "sixteen pointed asterisk",
+	0x273B:  "teardrop-spoked asterisk",
+	0x273C:  "open centre teardrop-spoked asterisk",
+	0x273D:  "teardrop-spoked asterisk",
+	0x273E:  "six petalled black and white florette",
+	0x273F:  "florette",
+	0x2740:  "florette",
+	0x2741:  "eight petalled outlined black florette",
+	0x2742:  "circled open centre eight pointed star",
+	0x2743:  "teardrop-spoked pinwheel asterisk",
+	0x2744:  "snowflake",
+	0x2745:  "tight trifoliate snowflake",
+	0x2746:  "chevron snowflake",
+	0x2747:  "sparkle",
+	0x2748:  "sparkle",
+	0x2749:  "balloon-spoked asterisk",
+	0x274A:  "eight teardrop-spoked propeller asterisk",
+	0x274B:  "eight teardrop-spoked propeller asterisk",
+	0x274C:  "cross mark",
+	0x274D:  "shadowed white circle",
+	0x274E:  "cross mark button",
+	0x274F:  "lower right drop-shadowed white square",
+	0x2750:  "upper right drop-shadowed white square",
+	0x2751:  "lower right shadowed white square",
+	0x2752:  "upper right shadowed white square",
+	0x2753:  "question mark",
+	0x2754:  "question mark ornament",
+	0x2755:  "exclamation mark ornament",
+	0x2756:  "diamond minus white x",
+	0x2757:  "exclamation mark",
+	0x2758:  "light vertical bar",
+	0x2759:  "medium vertical bar",
+	0x275A:  "vertical bar",
+	0x275B:  "single turned comma quotation mark ornament",
+	0x275C:  "single comma quotation mark ornament",
+	0x275D:  "double turned comma quotation mark ornament",
+	0x275E:  "double comma quotation mark ornament",
+	0x275F:  "low single comma quotation mark ornament",
+	0x2760:  "low double comma quotation mark ornament",
+	0x2761:  "curved stem paragraph sign ornament",
+	0x2762:  "exclamation mark ornament",
+	0x2763:  "heart exclamation",
+	0x2764:  "red heart",
+	0x2765:  "rotated heavy black heart bullet",
+	0x2766:  "floral heart",
+	0x2767:  "rotated floral heart bullet",
+	0x2768:  "medium left parenthesis ornament",
+	0x2769:  "medium right parenthesis ornament",
+	0x276A:  "medium flattened left parenthesis ornament",
+	0x276B:  "medium flattened right parenthesis ornament",
+	0x276C:  "medium left-pointing angle bracket ornament",
+	0x276D:  "medium right-pointing angle bracket ornament",
+	0x276E:  "left-pointing angle quotation mark ornament",
+	0x276F:  "right-pointing angle quotation mark ornament",
+	0x2770:  "left-pointing angle bracket ornament",
+	0x2771:  "right-pointing angle bracket ornament",
+	0x2772:  "light left tortoise shell bracket ornament",
+	0x2773:  "light right tortoise shell bracket ornament",
+	0x2774:  "medium left curly bracket ornament",
+	0x2775:  "medium right curly bracket ornament",
+	0x2776:  "dingbat negative circled digit one",
+	0x2777:  "dingbat negative circled digit two",
+	0x2778:  "dingbat negative circled digit three",
+	0x2779:  "dingbat negative circled digit four",
+	0x277A:  "dingbat negative circled digit five",
+	0x277B:  "dingbat negative circled digit six",
+	0x277C:  "dingbat negative circled digit seven",
+	0x277D:  "dingbat negative circled digit eight",
+	0x277E:  "dingbat negative circled digit nine",
+	0x277F:  "dingbat negative circled number ten",
+	0x2780:  "dingbat circled sans-serif digit one",
+	0x2781:  "dingbat circled sans-serif digit two",
+	0x2782:  "dingbat circled sans-serif digit three",
+	0x2783:  "dingbat circled sans-serif digit four",
+	0x2784:  "dingbat circled sans-serif digit five",
+	0x2785:  "dingbat circled sans-serif digit six",
+	0x2786:  "dingbat circled sans-serif digit seven",
+	0x2787:  "dingbat circled sans-serif digit eight",
+	0x2788:  "dingbat circled sans-serif digit nine",
+	0x2789:  "dingbat circled sans-serif number ten",
+	0x278A:  "dingbat negative circled sans-serif digit one",
+	0x278B:  "dingbat negative circled sans-serif digit two",
+	0x278C:  "dingbat negative circled sans-serif digit three",
+	0x278D:  "dingbat negative circled sans-serif digit four",
+	0x278E:  "dingbat negative circled sans-serif digit five",
+	0x278F:  "dingbat negative circled sans-serif digit six",
+	0x2790:  "dingbat negative circled sans-serif digit seven",
+	0x2791:  "dingbat negative circled sans-serif digit eight",
+	0x2792:  "dingbat negative circled sans-serif digit nine",
+	0x2793:  "dingbat negative circled sans-serif number ten",
+	0x2794:  "wide-headed rightwards arrow",
+	0x2795:  "plus",
+	0x2796:  "minus",
+	0x2797:  "divide",
+	0x2798:  "south east arrow",
+	0x2799:  "rightwards arrow",
+	0x279A:  "north east arrow",
+	0x279B:  "drafting point rightwards arrow",
+	0x279C:  "round-tipped rightwards arrow",
+	0x279D:  "triangle-headed rightwards arrow",
+	0x279E:  "triangle-headed rightwards arrow",
+	0x279F:  "dashed triangle-headed rightwards arrow",
+	0x27A0:  "dashed triangle-headed rightwards arrow",
+	0x27A1:  "right arrow",
+	0x27A2:  "three-d top-lighted rightwards arrowhead",
+	0x27A3:  "three-d bottom-lighted rightwards arrowhead",
+	0x27A4:  "rightwards arrowhead",
+	0x27A5:  "curved downwards and rightwards arrow",
+	0x27A6:  "curved upwards and rightwards arrow",
+	0x27A7:  "squat black rightwards arrow",
+	0x27A8:  "concave-pointed black rightwards arrow",
+	0x27A9:  "right-shaded white rightwards arrow",
+	0x27AA:  "left-shaded white rightwards arrow",
+	0x27AB:  "back-tilted shadowed white rightwards arrow",
+	0x27AC:  "front-tilted shadowed white rightwards arrow",
+	0x27AD:  "lower right-shadowed white rightwards arrow",
+	0x27AE:  "upper right-shadowed white rightwards arrow",
+	0x27AF:  "notched lower right-shadowed white rightwards arrow",
+	0x27B0:  "curly loop",
+	0x27B1:  "notched upper right-shadowed white rightwards arrow",
+	0x27B2:  "circled heavy white rightwards arrow",
+	0x27B3:  "white-feathered rightwards arrow",
+	0x27B4:  "black-feathered south east arrow",
+	0x27B5:  "black-feathered rightwards arrow",
+	0x27B6:  "black-feathered north east arrow",
+	0x27B7:  "black-feathered south east arrow",
+	0x27B8:  "black-feathered rightwards arrow",
+	0x27B9:  "black-feathered north east arrow",
+	0x27BA:  "teardrop-barbed rightwards arrow",
+	0x27BB:  "teardrop-shanked rightwards arrow",
+	0x27BC:  "wedge-tailed rightwards arrow",
+	0x27BD:  "wedge-tailed rightwards arrow",
+	0x27BE:  "open-outlined rightwards arrow",
+	0x27BF:  "double curly loop",
+	0x2934:  "right arrow curving up",
+	0x2935:  "right arrow curving down",
+	0x2B00:  "north east white arrow",
+	0x2B01:  "north west white arrow",
+	0x2B02:  "south east white arrow",
+	0x2B03:  "south west white arrow",
+	0x2B04:  "left right white arrow",
+	0x2B05:  "left arrow",
+	0x2B06:  "up arrow",
+	0x2B07:  "down arrow",
+	0x2B08:  "north east black arrow",
+	0x2B09:  "north west black arrow",
+	0x2B0A:  "south east black arrow",
+	0x2B0B:  "south west black arrow",
+	0x2B0C:  "left right black arrow",
+	0x2B0D:  "up down black arrow",
+	0x2B0E:  "rightwards arrow with tip downwards",
+	0x2B0F:  "rightwards arrow with tip upwards",
+	0x2B10:  "leftwards arrow with tip downwards",
+	0x2B11:  "leftwards arrow with tip upwards",
+	0x2B12:  "square with top half black",
+	0x2B13:  "square with bottom half black",
+	0x2B14:  "square with upper right diagonal half black",
+	0x2B15:  "square with lower left diagonal half black",
+	0x2B16:  "diamond with left half black",
+	0x2B17:  "diamond with right half black",
+	0x2B18:  "diamond with top half black",
+	0x2B19:  "diamond with bottom half black",
+	0x2B1A:  "dotted square",
+	0x2B1B:  "large square",
+	0x2B1C:  "large square",
+	0x2B1D:  "very small square",
+	0x2B1E:  "very small square",
+	0x2B1F:  "pentagon",
+	0x2B20:  "pentagon",
+	0x2B21:  "hexagon",
+	0x2B22:  "hexagon",
+	0x2B23:  "horizontal black hexagon",
+	0x2B24:  "large circle",
+	0x2B25:  "medium diamond",
+	0x2B26:  "medium diamond",
+	0x2B27:  "medium lozenge",
+	0x2B28:  "medium lozenge",
+	0x2B29:  "small diamond",
+	0x2B2A:  "small lozenge",
+	0x2B2B:  "small lozenge",
+	0x2B2C:  "horizontal ellipse",
+	0x2B2D:  "horizontal ellipse",
+	0x2B2E:  "vertical ellipse",
+	0x2B2F:  "vertical ellipse",
+	0x2B30:  "left arrow with small circle",
+	0x2B31:  "three leftwards arrows",
+	0x2B32:  "left arrow with circled plus",
+	0x2B33:  "long leftwards squiggle arrow",
+	0x2B34:  "leftwards two-headed arrow with vertical stroke",
+	0x2B35:  "leftwards two-headed arrow with double vertical stroke",
+	0x2B36:  "leftwards two-headed arrow from bar",
+	0x2B37:  "leftwards two-headed triple dash arrow",
+	0x2B38:  "leftwards arrow with dotted stem",
+	0x2B39:  "leftwards arrow with tail with vertical stroke",
+	0x2B3A:  "leftwards arrow with tail with double vertical stroke",
+	0x2B3B:  "leftwards two-headed arrow with tail",
+	0x2B3C:  "leftwards two-headed arrow with tail with vertical stroke",
+	0x2B3D:  "leftwards two-headed arrow with tail with double vertical stroke",
+	0x2B3E:  "leftwards arrow through x",
+	0x2B3F:  "wave arrow pointing directly left",
+	0x2B40:  "equals sign above leftwards arrow",
+	0x2B41:  "reverse tilde operator above leftwards arrow",
+	0x2B42:  "leftwards arrow above reverse almost equal to",
+	0x2B43:  "rightwards arrow through greater-than",
+	0x2B44:  "rightwards arrow through superset",
+	0x2B45:  "leftwards quadruple arrow",
+	0x2B46:  "rightwards quadruple arrow",
+	0x2B47:  "reverse tilde operator above rightwards arrow",
+	0x2B48:  "rightwards arrow above reverse almost equal to",
+	0x2B49:  "tilde operator above leftwards arrow",
+	0x2B4A:  "leftwards arrow above almost equal to",
+	0x2B4B:  "leftwards arrow above reverse tilde operator",
+	0x2B4C:  "rightwards arrow above reverse tilde operator",
+	0x2B4D:  "downwards triangle-headed zigzag arrow",
+	0x2B4E:  "short slanted north arrow",
+	0x2B4F:  "short backslanted south arrow",
+	0x2B50:  "star",
+	0x2B51:  "small star",
+	0x2B52:  "small star",
+	0x2B53:  "right-pointing pentagon",
+	0x2B54:  "right-pointing pentagon",
+	0x2B55:  "hollow red circle",
+	0x2B56:  "oval with oval inside",
+	0x2B57:  "circle with circle inside",
+	0x2B58:  "circle",
+	0x2B59:  "circled saltire",
+	0x2B5A:  "slanted north arrow with hooked head",
+	0x2B5B:  "backslanted south arrow with hooked tail",
+	0x2B5C:  "slanted north arrow with horizontal tail",
+	0x2B5D:  "backslanted south arrow with horizontal tail",
+	0x2B5E:  "bent arrow pointing downwards then north east",
+	0x2B5F:  "short bent arrow pointing downwards then north east",
+	0x2B60:  "leftwards triangle-headed arrow",
+	0x2B61:  "upwards triangle-headed arrow",
+	0x2B62:  "rightwards triangle-headed arrow",
+	0x2B63:  "downwards triangle-headed arrow",
+	0x2B64:  "left right triangle-headed arrow",
+	0x2B65:  "up down triangle-headed arrow",
+	0x2B66:  "north west triangle-headed arrow",
+	0x2B67:  "north east triangle-headed arrow",
+	0x2B68:  "south east triangle-headed arrow",
+	0x2B69:  "south west triangle-headed arrow",
+	0x2B6A:  "leftwards triangle-headed dashed arrow",
+	0x2B6B:  "upwards triangle-headed dashed arrow",
+	0x2B6C:  "rightwards triangle-headed dashed arrow",
+	0x2B6D:  "downwards triangle-headed dashed arrow",
+	0x2B6E:  "clockwise triangle-headed open circle arrow",
+	0x2B6F:  "anticlockwise triangle-headed open circle arrow",
+	0x2B70:  "leftwards triangle-headed arrow to bar",
+	0x2B71:  "upwards triangle-headed arrow to bar",
+	0x2B72:  "rightwards triangle-headed arrow to bar",
+	0x2B73:  "downwards triangle-headed arrow to bar",
+	0x2B76:  "north west triangle-headed arrow to bar",
+	0x2B77:  "north east triangle-headed arrow to bar",
+	0x2B78:  "south east triangle-headed arrow to bar",
+	0x2B79:  "south west triangle-headed arrow to bar",
+	0x2B7A:  "leftwards triangle-headed arrow with double horizontal stroke",
+	0x2B7B:  "upwards triangle-headed arrow with double horizontal stroke",
+	0x2B7C:  "rightwards triangle-headed arrow with double horizontal stroke",
+	0x2B7D:  "downwards triangle-headed arrow with double horizontal stroke",
+	0x2B7E:  "horizontal tab key",
+	0x2B7F:  "vertical tab key",
+	0x2B80:  "leftwards triangle-headed arrow over rightwards triangle-headed arrow",
+	0x2B81:  "upwards triangle-headed arrow leftwards of downwards triangle-headed arrow",
+	0x2B82:  "rightwards triangle-headed arrow over leftwards triangle-headed arrow",
+	0x2B83:  "downwards triangle-headed arrow leftwards of upwards triangle-headed arrow",
+	0x2B84:  "leftwards triangle-headed paired arrows",
+	0x2B85:  "upwards triangle-headed paired arrows",
+	0x2B86:  "rightwards triangle-headed paired arrows",
+	0x2B87:  "downwards triangle-headed paired arrows",
+	0x2B88:  "leftwards black circled white arrow",
+	0x2B89:  "upwards black circled white arrow",
+	0x2B8A:  "rightwards black circled white arrow",
+	0x2B8B:  "downwards black circled white arrow",
+	0x2B8C:  "anticlockwise triangle-headed right u-shaped arrow",
+	0x2B8D:  "anticlockwise triangle-headed bottom u-shaped arrow",
+	0x2B8E:  "anticlockwise triangle-headed left u-shaped arrow",
+	0x2B8F:  "anticlockwise triangle-headed top u-shaped arrow",
+	0x2B90:  "return left",
+	0x2B91:  "return right",
+	0x2B92:  "newline left",
+	0x2B93:  "newline right",
+	0x2B94:  "four corner arrows circling anticlockwise",
+	0x2B95:  "rightwards black arrow",
+	0x2B97:  "symbol for type a electronics",
+	0x2B98:  "three-d top-lighted leftwards equilateral arrowhead",
+	0x2B99:  "three-d right-lighted upwards equilateral arrowhead",
+	0x2B9A:  "three-d top-lighted rightwards equilateral arrowhead",
+	0x2B9B:  "three-d left-lighted downwards equilateral arrowhead",
+	0x2B9C:  "leftwards equilateral arrowhead",
+	0x2B9D:  "upwards equilateral arrowhead",
+	0x2B9E:  "rightwards equilateral arrowhead",
+	0x2B9F:  "downwards equilateral arrowhead",
+	0x2BA0:  "downwards triangle-headed arrow with long tip leftwards",
+	0x2BA1:  "downwards triangle-headed arrow with long tip rightwards",
+	0x2BA2:  "upwards triangle-headed arrow with long tip leftwards",
+	0x2BA3:  "upwards triangle-headed arrow with long tip rightwards",
+	0x2BA4:  "leftwards triangle-headed arrow with long tip upwards",
+	0x2BA5:  "rightwards triangle-headed arrow with long tip upwards",
+	0x2BA6:  "leftwards triangle-headed arrow with long tip downwards",
+	0x2BA7:  "rightwards triangle-headed arrow with long tip downwards",
+	0x2BA8:  "curved downwards and leftwards arrow",
+	0x2BA9:  "curved downwards and rightwards arrow",
+	0x2BAA:  "curved upwards and leftwards arrow",
+	0x2BAB:  "curved upwards and rightwards arrow",
+	0x2BAC:  "curved leftwards and upwards arrow",
+	0x2BAD:  "curved rightwards and upwards arrow",
+	0x2BAE:  "curved leftwards and downwards arrow",
+	0x2BAF:  "curved rightwards and downwards arrow",
+	0x2BB0:  "ribbon arrow down left",
+	0x2BB1:  "ribbon arrow down right",
+	0x2BB2:  "ribbon arrow up left",
+	0x2BB3:  "ribbon arrow up right",
+	0x2BB4:  "ribbon arrow left up",
+	0x2BB5:  "ribbon arrow right up",
+	0x2BB6:  "ribbon arrow left down",
+	0x2BB7:  "ribbon arrow right down",
+	0x2BB8:  "upwards white arrow from bar with horizontal bar",
+	0x2BB9:  "up arrowhead in a rectangle box",
+	0x2BBA:  "overlapping white squares",
+	0x2BBB:  "overlapping white and black squares",
+	0x2BBC:  "overlapping black squares",
+	0x2BBD:  "ballot box with light x",
+	0x2BBE:  "circled x",
+	0x2BBF:  "circled bold x",
+	0x2BC0:  "square centred",
+	0x2BC1:  "diamond centred",
+	0x2BC2:  "turned black pentagon",
+	0x2BC3:  "horizontal black octagon",
+	0x2BC4:  "octagon",
+	0x2BC5:  "medium up-pointing triangle centred",
+	0x2BC6:  "medium down-pointing triangle centred",
+	0x2BC7:  "medium left-pointing triangle centred",
+	0x2BC8:  "medium right-pointing triangle centred",
+	0x2BC9:  "neptune form two",
+	0x2BCA:  "top half black circle",
+	0x2BCB:  "bottom half black circle",
+	0x2BCC:  "light four pointed black cusp",
+	0x2BCD:  "rotated light four pointed black cusp",
+	0x2BCE:  "four pointed cusp",
+	0x2BCF:  "rotated white four pointed cusp",
+	0x2BD0:  "square position indicator",
+	0x2BD1:  "uncertainty",
+	0x2BD2:  "group mark",
+	0x2BD3:  "pluto form two",
+	0x2BD4:  "pluto form three",
+	0x2BD5:  "pluto form four",
+	0x2BD6:  "pluto form five",
+	0x2BD7:  "transpluto",
+	0x2BD8:  "proserpina",
+	0x2BD9:  "astraea",
+	0x2BDA:  "hygiea",
+	0x2BDB:  "pholus",
+	0x2BDC:  "nessus",
+	0x2BDD:  "moon selena",
+	0x2BDE:  "diamond on cross",
+	0x2BDF:  "true light moon arta",
+	0x2BE0:  "cupido",
+	0x2BE1:  "hades",
+	0x2BE2:  "zeus",
+	0x2BE3:  "kronos",
+	0x2BE4:  "apollon",
+	0x2BE5:  "admetos",
+	0x2BE6:  "vulcanus",
+	0x2BE7:  "poseidon",
+	0x2BE8:  "left half black star",
+	0x2BE9:  "right half black star",
+	0x2BEA:  "star with left half black",
+	0x2BEB:  "star with right half black",
+	0x2BEC:  "leftwards two-headed arrow with triangle arrowheads",
+	0x2BED:  "upwards two-headed arrow with triangle arrowheads",
+	0x2BEE:  "rightwards two-headed arrow with triangle arrowheads",
+	0x2BEF:  "downwards two-headed arrow with triangle arrowheads",
+	0x2BF0:  "eris form one",
+	0x2BF1:  "eris form two",
+	0x2BF2:  "sedna",
+	0x2BF3:  "russian astrological symbol vigintile",
+	0x2BF4:  "russian astrological symbol novile",
+	0x2BF5:  "russian astrological symbol quintile",
+	0x2BF6:  "russian astrological symbol binovile",
+	0x2BF7:  "russian astrological symbol sentagon",
+	0x2BF8:  "russian astrological symbol tredecile",
+	0x2BF9:  "equals sign with infinity below",
+	0x2BFA:  "united",
+	0x2BFB:  "separated",
+	0x2BFC:  "doubled",
+	0x2BFD:  "passed",
+	0x2BFE:  "reversed right angle",
+	0x2BFF:  "hellschreiber pause",
+	0x3030:  "wavy dash",
+	0x303D:  "part alternation mark",
+	0x3297:  "Japanese congratulations button",
+	0x3299:  "Japanese secret button",
+	0x1F300: "cyclone",
+	0x1F301: "foggy",
+	0x1F302: "closed umbrella",
+	0x1F303: "night with stars",
+	0x1F304: "sunrise over mountains",
+	0x1F305: "sunrise",
+	0x1F306: "cityscape at dusk",
+	0x1F307: "sunset over buildings",
+	0x1F308: "rainbow",
+	0x1F309: "bridge at night",
+	0x1F30A: "water wave",
+	0x1F30B: "volcano",
+	0x1F30C: "milky way",
+	0x1F30D: "earth globe europe-africa",
+	0x1F30E: "earth globe americas",
+	0x1F30F: "earth globe asia-australia",
+	0x1F310: "globe with meridians",
+	0x1F311: "new moon",
+	0x1F312: "waxing crescent moon",
+	0x1F313: "first quarter moon",
+	0x1F314: "waxing gibbous moon",
+	0x1F315: "full moon",
+	0x1F316: "waning gibbous moon",
+	0x1F317: "last quarter moon",
+	0x1F318: "waning crescent moon",
+	0x1F319: "crescent moon",
+	0x1F31A: "new moon with face",
+	0x1F31B: "first quarter moon with face",
+	0x1F31C: "last quarter moon with face",
+	0x1F31D: "full moon with face",
+	0x1F31E: "sun with face",
+	0x1F31F: "glowing star",
+	0x1F320: "shooting star",
+	0x1F321: "thermometer",
+	0x1F322: "black droplet",
+	0x1F323: "white sun",
+	0x1F324: "white sun with small cloud",
+	0x1F325: "white sun behind cloud",
+	0x1F326: "white sun behind cloud with rain",
+	0x1F327: "cloud with rain",
+	0x1F328: "cloud with snow",
+	0x1F329: "cloud with lightning",
+	0x1F32A: "cloud with tornado",
+	0x1F32B: "fog",
+	0x1F32C: "wind blowing face",
+	0x1F32D: "hot dog",
+	0x1F32E: "taco",
+	0x1F32F: "burrito",
+	0x1F330: "chestnut",
+	0x1F331: "seedling",
+	0x1F332: "evergreen tree",
+	0x1F333: "deciduous tree",
+	0x1F334: "palm tree",
+	0x1F335: "cactus",
+	0x1F336: "hot pepper",
+	0x1F337: "tulip",
+	0x1F338: "cherry blossom",
+	0x1F339: "rose",
+	0x1F33A: "hibiscus",
+	0x1F33B: "sunflower",
+	0x1F33C: "blossom",
+	0x1F33D: "ear of maize",
+	0x1F33E: "ear of rice",
+	0x1F33F: "herb",
+	0x1F340: "four leaf clover",
+	0x1F341: "maple leaf",
+	0x1F342: "fallen leaf",
+	0x1F343: "leaf fluttering in wind",
+	0x1F344: "mushroom",
+	0x1F345: "tomato",
+	0x1F346: "aubergine",
+	0x1F347: "grapes",
+	0x1F348: "melon",
+	0x1F349: "watermelon",
+	0x1F34A: "tangerine",
+	0x1F34B: "lemon",
+	0x1F34C: "banana",
+	0x1F34D: "pineapple",
+	0x1F34E: "red apple",
+	0x1F34F: "green apple",
+	0x1F350: "pear",
+	0x1F351: "peach",
+	0x1F352: "cherries",
+	0x1F353: "strawberry",
+	0x1F354: "hamburger",
+	0x1F355: "slice of pizza",
+	0x1F356: "meat on bone",
+	0x1F357: "poultry leg",
+	0x1F358: "rice cracker",
+	0x1F359: "rice ball",
+	0x1F35A: "cooked rice",
+	0x1F35B: "curry and rice",
+	0x1F35C: "steaming bowl",
+	0x1F35D: "spaghetti",
+	0x1F35E: "bread",
+	0x1F35F: "french fries",
+	0x1F360: "roasted sweet potato",
+	0x1F361: "dango",
+	0x1F362: "oden",
+	0x1F363: "sushi",
+	0x1F364: "fried shrimp",
+	0x1F365: "fish cake with swirl design",
+	0x1F366: "soft ice cream",
+	0x1F367: "shaved ice",
+	0x1F368: "ice cream",
+	0x1F369: "doughnut",
+	0x1F36A: "cookie",
+	0x1F36B: "chocolate bar",
+	0x1F36C: "candy",
+	0x1F36D: "lollipop",
+	0x1F36E: "custard",
+	0x1F36F: "honey pot",
+	0x1F370: "shortcake",
+	0x1F371: "bento box",
+	0x1F372: "pot of food",
+	0x1F373: "cooking",
+	0x1F374: "fork and knife",
+	0x1F375: "teacup without handle",
+	0x1F376: "sake bottle and cup",
+	0x1F377: "wine glass",
+	0x1F378: "cocktail glass",
+	0x1F379: "tropical drink",
+	0x1F37A: "beer mug",
+	0x1F37B: "clinking beer mugs",
+	0x1F37C: "baby bottle",
+	0x1F37D: "fork and knife with plate",
+	0x1F37E: "bottle with popping cork",
+	0x1F37F: "popcorn",
+	0x1F380: "ribbon",
+	0x1F381: "wrapped present",
+	0x1F382: "birthday cake",
+	0x1F383: "jack-o-lantern",
+	0x1F384: "christmas tree",
+	0x1F385: "father christmas",
+	0x1F386: "fireworks",
+	0x1F387: "firework sparkler",
+	0x1F388: "balloon",
+	0x1F389: "party popper",
+	0x1F38A: "confetti ball",
+	0x1F38B: "tanabata tree",
+	0x1F38C: "crossed flags",
+	0x1F38D: "pine decoration",
+	0x1F38E: "japanese dolls",
+	0x1F38F: "carp streamer",
+	0x1F390: "wind chime",
+	0x1F391: "moon viewing ceremony",
+	0x1F392: "school satchel",
+	0x1F393: "graduation cap",
+	0x1F394: "heart with tip on the left",
+	0x1F395: "bouquet of flowers",
+	0x1F396: "military medal",
+	0x1F397: "reminder ribbon",
+	0x1F398: "musical keyboard with jacks",
+	0x1F399: "studio microphone",
+	0x1F39A: "level slider",
+	0x1F39B: "control knobs",
+	0x1F39C: "beamed ascending musical notes",
+	0x1F39D: "beamed descending musical notes",
+	0x1F39E: "film frames",
+	0x1F39F: "admission tickets",
+	0x1F3A0: "carousel horse",
+	0x1F3A1: "ferris wheel",
+	0x1F3A2: "roller coaster",
+	0x1F3A3: "fishing pole and fish",
+	0x1F3A4: "microphone",
+	0x1F3A5: "movie camera",
+	0x1F3A6: "cinema",
+	0x1F3A7: "headphone",
+	0x1F3A8: "artist palette",
+	0x1F3A9: "top hat",
+	0x1F3AA: "circus tent",
+	0x1F3AB: "ticket",
+	0x1F3AC: "clapper board",
+	0x1F3AD: "performing arts",
+	0x1F3AE: "video game",
+	0x1F3AF: "direct hit",
+	0x1F3B0: "slot machine",
+	0x1F3B1: "billiards",
+	0x1F3B2: "game die",
+	0x1F3B3: "bowling",
+	0x1F3B4: "flower playing cards",
+	0x1F3B5: "musical note",
+	0x1F3B6: "musical notes",
+	0x1F3B7: "saxophone",
+	0x1F3B8: "guitar",
+	0x1F3B9: "musical keyboard",
+	0x1F3BA: "trumpet",
+	0x1F3BB: "violin",
+	0x1F3BC: "musical score",
+	0x1F3BD: "running shirt with sash",
+	0x1F3BE: "tennis racquet and ball",
+	0x1F3BF: "ski and ski boot",
+	0x1F3C0: "basketball and hoop",
+	0x1F3C1: "chequered flag",
+	0x1F3C2: "snowboarder",
+	0x1F3C3: "runner",
+	0x1F3C4: "surfer",
+	0x1F3C5: "sports medal",
+	0x1F3C6: "trophy",
+	0x1F3C7: "horse racing",
+	0x1F3C8: "american football",
+	0x1F3C9: "rugby football",
+	0x1F3CA: "swimmer",
+	0x1F3CB: "weight lifter",
+	0x1F3CC: "golfer",
+	0x1F3CD: "racing motorcycle",
+	0x1F3CE: "racing car",
+	0x1F3CF: "cricket bat and ball",
+	0x1F3D0: "volleyball",
+	0x1F3D1: "field hockey stick and ball",
+	0x1F3D2: "ice hockey stick and puck",
+	0x1F3D3: "table tennis paddle and ball",
+	0x1F3D4: "snow capped mountain",
+	0x1F3D5: "camping",
+	0x1F3D6: "beach with umbrella",
+	0x1F3D7: "building construction",
+	0x1F3D8: "house buildings",
+	0x1F3D9: "cityscape",
+	0x1F3DA: "derelict house building",
+	0x1F3DB: "classical building",
+	0x1F3DC: "desert",
+	0x1F3DD: "desert island",
+	0x1F3DE: "national park",
+	0x1F3DF: "stadium",
+	0x1F3E0: "house",
+	0x1F3E1: "house with garden",
+	0x1F3E2: "office building",
+	0x1F3E3: "japanese post office",
+	0x1F3E4: "european post office",
+	0x1F3E5: "hospital",
+	0x1F3E6: "bank",
+	0x1F3E7: "automated teller machine",
+	0x1F3E8: "hotel",
+	0x1F3E9: "love hotel",
+	0x1F3EA: "convenience store",
+	0x1F3EB: "school",
+	0x1F3EC: "department store",
+	0x1F3ED: "factory",
+	0x1F3EE: "izakaya lantern",
+	0x1F3EF: "japanese castle",
+	0x1F3F0: "european castle",
+	0x1F3F1: "white pennant",
+	0x1F3F2: "black pennant",
+	0x1F3F3: "waving white flag",
+	0x1F3F4: "waving black flag",
+	0x1F3F5: "rosette",
+	0x1F3F6: "black rosette",
+	0x1F3F7: "label",
+	0x1F3F8: "badminton racquet and shuttlecock",
+	0x1F3F9: "bow and arrow",
+	0x1F3FA: "amphora",
+	0x1F400: "rat",
+	0x1F401: "mouse",
+	0x1F402: "ox",
+	0x1F403: "water buffalo",
+	0x1F404: "cow",
+	0x1F405: "tiger",
+	0x1F406: "leopard",
+	0x1F407: "rabbit",
+	0x1F408: "cat",
+	0x1F409: "dragon",
+	0x1F40A: "crocodile",
+	0x1F40B: "whale",
+	0x1F40C: "snail",
+	0x1F40D: "snake",
+	0x1F40E: "horse",
+	0x1F40F: "ram",
+	0x1F410: "goat",
+	0x1F411: "sheep",
+	0x1F412: "monkey",
+	0x1F413: "rooster",
+	0x1F414: "chicken",
+	0x1F415: "dog",
+	0x1F416: "pig",
+	0x1F417: "boar",
+	0x1F418: "elephant",
+	0x1F419: "octopus",
+	0x1F41A: "spiral shell",
+	0x1F41B: "bug",
+	0x1F41C: "ant",
+	0x1F41D: "honeybee",
+	0x1F41E: "lady beetle",
+	0x1F41F: "fish",
+	0x1F420: "tropical fish",
+	0x1F421: "blowfish",
+	0x1F422: "turtle",
+	0x1F423: "hatching chick",
+	0x1F424: "baby chick",
+	0x1F425: "front-facing baby chick",
+	0x1F426: "bird",
+	0x1F427: "penguin",
+	0x1F428: "koala",
+	0x1F429: "poodle",
+	0x1F42A: "dromedary camel",
+	0x1F42B: "bactrian camel",
+	0x1F42C: "dolphin",
+	0x1F42D: "mouse face",
+	0x1F42E: "cow face",
+	0x1F42F: "tiger face",
+	0x1F430: "rabbit face",
+	0x1F431: "cat face",
+	0x1F432: "dragon face",
+	0x1F433: "spouting whale",
+	0x1F434: "horse face",
+	0x1F435: "monkey face",
+	0x1F436: "dog face",
+	0x1F437: "pig face",
+	0x1F438: "frog face",
+	0x1F439: "hamster face",
+	0x1F43A: "wolf face",
+	0x1F43B: "bear face",
+	0x1F43C: "panda face",
+	0x1F43D: "pig nose",
+	0x1F43E: "paw prints",
+	0x1F43F: "chipmunk",
+	0x1F440: "eyes",
+	0x1F441: "eye",
+	0x1F442: "ear",
+	0x1F443: "nose",
+	0x1F444: "mouth",
+	0x1F445: "tongue",
+	0x1F446: "backhand index pointing up",
+	0x1F447: "backhand index pointing down",
+	0x1F448: "backhand index pointing left",
+	0x1F449: "backhand index pointing right",
+	0x1F44A: "oncoming fist",
+	0x1F44B: "waving hand",
+	0x1F44C: "OK hand",
+	0x1F44D: "thumbs up",
+	0x1F44E: "thumbs down",
+	0x1F44F: "clapping hands",
+	0x1F450: "open hands",
+	0x1F451: "crown",
+	0x1F452: "womans hat",
+	0x1F453: "eyeglasses",
+	0x1F454: "necktie",
+	0x1F455: "t-shirt",
+	0x1F456: "jeans",
+	0x1F457: "dress",
+	0x1F458: "kimono",
+	0x1F459: "bikini",
+	0x1F45A: "womans clothes",
+	0x1F45B: "purse",
+	0x1F45C: "handbag",
+	0x1F45D: "pouch",
+	0x1F45E: "mans shoe",
+	0x1F45F: "athletic shoe",
+	0x1F460: "high-heeled shoe",
+	0x1F461: "womans sandal",
+	0x1F462: "womans boots",
+	0x1F463: "footprints",
+	0x1F464: "bust in silhouette",
+	0x1F465: "busts in silhouette",
+	0x1F466: "boy",
+	0x1F467: "girl",
+	0x1F468: "man",
+	0x1F469: "woman",
+	0x1F46A: "family",
+	0x1F46B: "man and woman holding hands",
+	0x1F46C: "two men holding hands",
+	0x1F46D: "two women holding hands",
+	0x1F46E: "police officer",
+	0x1F46F: "woman with bunny ears",
+	0x1F470: "bride with veil",
+	0x1F471: "person with blond hair",
+	0x1F472: "man with gua pi mao",
+	0x1F473: "man with turban",
+	0x1F474: "older man",
+	0x1F475: "older woman",
+	0x1F476: "baby",
+	0x1F477: "construction worker",
+	0x1F478: "princess",
+	0x1F479: "japanese ogre",
+	0x1F47A: "japanese goblin",
+	0x1F47B: "ghost",
+	0x1F47C: "baby angel",
+	0x1F47D: "alien",
+	0x1F47E: "alien monster",
+	0x1F47F: "imp",
+	0x1F480: "skull",
+	0x1F481: "information desk person",
+	0x1F482: "guardsman",
+	0x1F483: "dancer",
+	0x1F484: "lipstick",
+	0x1F485: "nail polish",
+	0x1F486: "face massage",
+	0x1F487: "haircut",
+	0x1F488: "barber pole",
+	0x1F489: "syringe",
+	0x1F48A: "pill",
+	0x1F48B: "kiss mark",
+	0x1F48C: "love letter",
+	0x1F48D: "ring",
+	0x1F48E: "gem stone",
+	0x1F48F: "kiss",
+	0x1F490: "bouquet",
+	0x1F491: "couple with heart",
+	0x1F492: "wedding",
+	0x1F493: "beating heart",
+	0x1F494: "broken heart",
+	0x1F495: "two hearts",
+	0x1F496: "sparkling heart",
+	0x1F497: "growing heart",
+	0x1F498: "heart with arrow",
+	0x1F499: "blue heart",
+	0x1F49A: "green heart",
+	0x1F49B: "yellow heart",
+	0x1F49C: "purple heart",
+	0x1F49D: "heart with ribbon",
+	0x1F49E: "revolving hearts",
+	0x1F49F: "heart decoration",
+	0x1F4A0: "diamond shape with a dot inside",
+	0x1F4A1: "light bulb",
+	0x1F4A2: "anger",
+	0x1F4A3: "bomb",
+	0x1F4A4: "zzz",
+	0x1F4A5: "collision",
+	0x1F4A6: "splashing sweat",
+	0x1F4A7: "droplet",
+	0x1F4A8: "dash",
+	0x1F4A9: "pile of poo",
+	0x1F4AA: "flexed biceps",
+	0x1F4AB: "dizzy",
+	0x1F4AC: "speech balloon",
+	0x1F4AD: "thought balloon",
+	0x1F4AE: "white flower",
+	0x1F4AF: "hundred points",
+	0x1F4B0: "money bag",
+	0x1F4B1: "currency exchange",
+	0x1F4B2: "heavy dollar",
+	0x1F4B3: "credit card",
+	0x1F4B4: "banknote with yen",
+	0x1F4B5: "banknote with dollar",
+	0x1F4B6: "banknote with euro",
+	0x1F4B7: "banknote with pound",
+	0x1F4B8: "money with wings",
+	0x1F4B9: "chart with upwards trend and yen",
+	0x1F4BA: "seat",
+	0x1F4BB: "laptop",
+	0x1F4BC: "briefcase",
+	0x1F4BD: "minidisc",
+	0x1F4BE: "floppy disk",
+	0x1F4BF: "optical disc",
+	0x1F4C0: "dvd",
+	0x1F4C1: "file folder",
+	0x1F4C2: "open file folder",
+	0x1F4C3: "page with curl",
+	0x1F4C4: "page facing up",
+	0x1F4C5: "calendar",
+	0x1F4C6: "tear-off calendar",
+	0x1F4C7: "card index",
+	0x1F4C8: "chart increasing",
+	0x1F4C9: "chart decreasing",
+	0x1F4CA: "bar chart",
+	0x1F4CB: "clipboard",
+	0x1F4CC: "pushpin",
+	0x1F4CD: "round pushpin",
+	0x1F4CE: "paperclip",
+	0x1F4CF: "straight ruler",
+	0x1F4D0: "triangular ruler",
+	0x1F4D1: "bookmark tabs",
+	0x1F4D2: "ledger",
+	0x1F4D3: "notebook",
+	0x1F4D4: "notebook with decorative cover",
+	0x1F4D5: "closed book",
+	0x1F4D6: "open book",
+	0x1F4D7: "green book",
+	0x1F4D8: "blue book",
+	0x1F4D9: "orange book",
+	0x1F4DA: "books",
+	0x1F4DB: "name badge",
+	0x1F4DC: "scroll",
+	0x1F4DD: "memo",
+	0x1F4DE: "telephone receiver",
+	0x1F4DF: "pager",
+	0x1F4E0: "fax machine",
+	0x1F4E1: "satellite antenna",
+	0x1F4E2: "loudspeaker",
+	0x1F4E3: "cheering megaphone",
+	0x1F4E4: "outbox tray",
+	0x1F4E5: "inbox tray",
+	0x1F4E6: "package",
+	0x1F4E7: "e-mail",
+	0x1F4E8: "incoming envelope",
+	0x1F4E9: "envelope with downwards arrow above",
+	0x1F4EA: "closed mailbox with lowered flag",
+	0x1F4EB: "closed mailbox with raised flag",
+	0x1F4EC: "open mailbox with raised flag",
+	0x1F4ED: "open mailbox with lowered flag",
+	0x1F4EE: "postbox",
+	0x1F4EF: "postal horn",
+	0x1F4F0: "newspaper",
+	0x1F4F1: "mobile phone",
+	0x1F4F2: "mobile phone with rightwards arrow at left",
+	0x1F4F3: "vibration mode",
+	0x1F4F4: "mobile phone off",
+	0x1F4F5: "no mobile phones",
+	0x1F4F6: "antenna with bars",
+	0x1F4F7: "camera",
+	0x1F4F8: "camera with flash",
+	0x1F4F9: "video camera",
+	0x1F4FA: "television",
+	0x1F4FB: "radio",
+	0x1F4FC: "videocassette",
+	0x1F4FD: "film projector",
+	0x1F4FE: "portable stereo",
+	0x1F4FF: "prayer beads",
+	0x1F500: "twisted rightwards arrows",
+	0x1F501: "clockwise rightwards and leftwards open circle arrows",
+	0x1F502: "clockwise rightwards and leftwards open circle arrows with circled one overlay",
+	0x1F503: "clockwise downwards and upwards open circle arrows",
+	0x1F504: "anticlockwise downwards and upwards open circle arrows",
+	0x1F505: "low brightness",
+	0x1F506: "high brightness",
+	0x1F507: "muted speaker",
+	0x1F508: "speaker",
+	0x1F509: "speaker with one sound wave",
+	0x1F50A: "speaker high volume",
+	0x1F50B: "battery",
+	0x1F50C: "electric plug",
+	0x1F50D: "magnifying glass tilted left",
+	0x1F50E: "right-pointing magnifying glass",
+	0x1F50F: "lock with ink pen",
+	0x1F510: "closed lock with key",
+	0x1F511: "key",
+	0x1F512: "locked",
+	0x1F513: "unlocked",
+	0x1F514: "bell",
+	0x1F515: "bell with cancellation stroke",
+	0x1F516: "bookmark",
+	0x1F517: "link",
+	0x1F518: "radio button",
+	0x1F519: "back with leftwards arrow above",
+	0x1F51A: "end with leftwards arrow above",
+	0x1F51B: "on with exclamation mark with left right arrow above",
+	0x1F51C: "soon with rightwards arrow above",
+	0x1F51D: "top with upwards arrow above",
+	0x1F51E: "no one under eighteen",
+	0x1F51F: "keycap ten",
+	0x1F520: "input symbol for latin capital letters",
+	0x1F521: "input symbol for latin small letters",
+	0x1F522: "input symbol for numbers",
+	0x1F523: "input symbol for symbols",
+	0x1F524: "input symbol for latin letters",
+	0x1F525: "fire",
+	0x1F526: "electric torch",
+	0x1F527: "wrench",
+	0x1F528: "hammer",
+	0x1F529: "nut and bolt",
+	0x1F52A: "hocho",
+	0x1F52B: "pistol",
+	0x1F52C: "microscope",
+	0x1F52D: "telescope",
+	0x1F52E: "crystal ball",
+	0x1F52F: "six pointed star with middle dot",
+	0x1F530: "japanese symbol for beginner",
+	0x1F531: "trident emblem",
+	0x1F532: "black square button",
+	0x1F533: "white square button",
+	0x1F534: "red circle",
+	0x1F535: "blue circle",
+	0x1F536: "large orange diamond",
+	0x1F537: "large blue diamond",
+	0x1F538: "small orange diamond",
+	0x1F539: "small blue diamond",
+	0x1F53A: "up-pointing red triangle",
+	0x1F53B: "down-pointing red triangle",
+	0x1F53C: "up-pointing small red triangle",
+	0x1F53D: "down-pointing small red triangle",
+	0x1F53E: "lower right shadowed white circle",
+	0x1F53F: "upper right shadowed white circle",
+	0x1F540: "circled cross pommee",
+	0x1F541: "cross pommee with half-circle below",
+	0x1F542: "cross pommee",
+	0x1F543: "notched left semicircle with three dots",
+	0x1F544: "notched right semicircle with three dots",
+	0x1F545: "symbol for marks chapter",
+	0x1F546: "white latin cross",
+	0x1F547: "heavy latin cross",
+	0x1F548: "celtic cross",
+	0x1F549: "om symbol",
+	0x1F54A: "dove of peace",
+	0x1F54B: "kaaba",
+	0x1F54C: "mosque",
+	0x1F54D: "synagogue",
+	0x1F54E: "menorah with nine branches",
+	0x1F54F: "bowl of hygieia",
+	0x1F550: "clock face one oclock",
+	0x1F551: "clock face two oclock",
+	0x1F552: "clock face three oclock",
+	0x1F553: "clock face four oclock",
+	0x1F554: "clock face five oclock",
+	0x1F555: "clock face six oclock",
+	0x1F556: "clock face seven oclock",
+	0x1F557: "clock face eight oclock",
+	0x1F558: "clock face nine oclock",
+	0x1F559: "clock face ten oclock",
+	0x1F55A: "clock face eleven oclock",
+	0x1F55B: "clock face twelve oclock",
+	0x1F55C: "clock face one-thirty",
+	0x1F55D: "clock face two-thirty",
+	0x1F55E: "clock face three-thirty",
+	0x1F55F: "clock face four-thirty",
+	0x1F560: "clock face five-thirty",
+	0x1F561: "clock face six-thirty",
+	0x1F562: "clock face seven-thirty",
+	0x1F563: "clock face eight-thirty",
+	0x1F564: "clock face nine-thirty",
+	0x1F565: "clock face ten-thirty",
+	0x1F566: "clock face eleven-thirty",
+	0x1F567: "clock face twelve-thirty",
+	0x1F568: "right speaker",
+	0x1F569: "right speaker with one sound wave",
+	0x1F56A: "right speaker with three sound waves",
+	0x1F56B: "bullhorn",
+	0x1F56C: "bullhorn with sound waves",
+	0x1F56D: "ringing bell",
+	0x1F56E: "book",
+	0x1F56F: "candle",
+	0x1F570: "mantelpiece clock",
+	0x1F571: "black skull and crossbones",
+	0x1F572: "no piracy",
+	0x1F573: "hole",
+	0x1F574: "man in business suit levitating",
+	0x1F575: "sleuth or spy",
+	0x1F576: "dark sunglasses",
+	0x1F577: "spider",
+	0x1F578: "spider web",
+	0x1F579: "joystick",
+	0x1F57A: "man dancing",
+	0x1F57B: "left hand telephone receiver",
+	0x1F57C: "telephone receiver with page",
+	0x1F57D: "right hand telephone receiver",
+	0x1F57E: "white touchtone telephone",
+	0x1F57F: "black touchtone telephone",
+	0x1F580: "telephone on top of modem",
+	0x1F581: "clamshell mobile phone",
+	0x1F582: "back of envelope",
+	0x1F583: "stamped envelope",
+	0x1F584: "envelope with lightning",
+	0x1F585: "flying envelope",
+	0x1F586: "pen over stamped envelope",
+	0x1F587: "linked paperclips",
+	0x1F588: "black pushpin",
+	0x1F589: "lower left pencil",
+	0x1F58A: "lower left ballpoint pen",
+	0x1F58B: "lower left fountain pen",
+	0x1F58C: "lower left paintbrush",
+	0x1F58D: "lower left crayon",
+	0x1F58E: "left writing hand",
+	0x1F58F: "turned ok hand",
+	0x1F590: "raised hand with fingers splayed",
+	0x1F591: "reversed raised hand with fingers splayed",
+	0x1F592: "reversed thumbs up",
+	0x1F593: "reversed thumbs down",
+	0x1F594: "reversed victory hand",
+	0x1F595: "reversed hand with middle finger extended",
+	0x1F596: "raised hand with part between middle and ring fingers",
+	0x1F597: "white down pointing left hand index",
+	0x1F598: "sideways white left pointing index",
+	0x1F599: "sideways white right pointing index",
+	0x1F59A: "sideways black left pointing index",
+	0x1F59B: "sideways black right pointing index",
+	0x1F59C: "black left pointing backhand index",
+	0x1F59D: "black right pointing backhand index",
+	0x1F59E: "sideways white up pointing index",
+	0x1F59F: "sideways white down pointing index",
+	0x1F5A0: "sideways black up pointing index",
+	0x1F5A1: "sideways black down pointing index",
+	0x1F5A2: "black up pointing backhand index",
+	0x1F5A3: "black down pointing backhand index",
+	0x1F5A4: "black heart",
+	0x1F5A5: "desktop computer",
+	0x1F5A6: "keyboard and mouse",
+	0x1F5A7: "three networked computers",
+	0x1F5A8: "printer",
+	0x1F5A9: "pocket calculator",
+	0x1F5AA: "black hard shell floppy disk",
+	0x1F5AB: "white hard shell floppy disk",
+	0x1F5AC: "soft shell floppy disk",
+	0x1F5AD: "tape cartridge",
+	0x1F5AE: "wired keyboard",
+	0x1F5AF: "one button mouse",
+	0x1F5B0: "two button mouse",
+	0x1F5B1: "three button mouse",
+	0x1F5B2: "trackball",
+	0x1F5B3: "old personal computer",
+	0x1F5B4: "hard disk",
+	0x1F5B5: "screen",
+	0x1F5B6: "printer icon",
+	0x1F5B7: "fax icon",
+	0x1F5B8: "optical disc icon",
+	0x1F5B9: "document with text",
+	0x1F5BA: "document with text and picture",
+	0x1F5BB: "document with picture",
+	0x1F5BC: "frame with picture",
+	0x1F5BD: "frame with tiles",
+	0x1F5BE: "frame with an x",
+	0x1F5BF: "black folder",
+	0x1F5C0: "folder",
+	0x1F5C1: "open folder",
+	0x1F5C2: "card index dividers",
+	0x1F5C3: "card file box",
+	0x1F5C4: "file cabinet",
+	0x1F5C5: "empty note",
+	0x1F5C6: "empty note page",
+	0x1F5C7: "empty note pad",
+	0x1F5C8: "note",
+	0x1F5C9: "note page",
+	0x1F5CA: "note pad",
+	0x1F5CB: "empty document",
+	0x1F5CC: "empty page",
+	0x1F5CD: "empty pages",
+	0x1F5CE: "document",
+	0x1F5CF: "page",
+	0x1F5D0: "pages",
+	0x1F5D1: "wastebasket",
+	0x1F5D2: "spiral note pad",
+	0x1F5D3: "spiral calendar pad",
+	0x1F5D4: "desktop window",
+	0x1F5D5: "minimize",
+	0x1F5D6: "maximize",
+	0x1F5D7: "overlap",
+	0x1F5D8: "clockwise right and left semicircle arrows",
+	0x1F5D9: "cancellation x",
+	0x1F5DA: "increase font size",
+	0x1F5DB: "decrease font size",
+	0x1F5DC: "compression",
+	0x1F5DD: "old key",
+	0x1F5DE: "rolled-up newspaper",
+	0x1F5DF: "page with circled text",
+	0x1F5E0: "stock chart",
+	0x1F5E1: "dagger knife",
+	0x1F5E2: "lips",
+	0x1F5E3: "speaking head in silhouette",
+	0x1F5E4: "three rays above",
+	0x1F5E5: "three rays below",
+	0x1F5E6: "three rays left",
+	0x1F5E7: "three rays right",
+	0x1F5E8: "left speech bubble",
+	0x1F5E9: "right speech bubble",
+	0x1F5EA: "two speech bubbles",
+	0x1F5EB: "three speech bubbles",
+	0x1F5EC: "left thought bubble",
+	0x1F5ED: "right thought bubble",
+	0x1F5EE: "left anger bubble",
+	0x1F5EF: "right anger bubble",
+	0x1F5F0: "mood bubble",
+	0x1F5F1: "lightning mood bubble",
+	0x1F5F2: "lightning mood",
+	0x1F5F3: "ballot box with ballot",
+	0x1F5F4: "ballot script x",
+	0x1F5F5: "ballot box with script x",
+	0x1F5F6: "ballot bold script x",
+	0x1F5F7: "ballot box with bold script x",
+	0x1F5F8: "light check mark",
+	0x1F5F9: "ballot box with bold check",
+	0x1F5FA: "world map",
+	0x1F5FB: "mount fuji",
+	0x1F5FC: "tokyo tower",
+	0x1F5FD: "statue of liberty",
+	0x1F5FE: "silhouette of japan",
+	0x1F5FF: "moyai",
+	0x1F600: "grinning face",
+	0x1F601: "beaming face with smiling eyes",
+	0x1F602: "face with tears of joy",
+	0x1F603: "grinning face with big eyes",
+	0x1F604: "grinning face with smiling eyes",
+	0x1F605: "grinning face with sweat",
+	0x1F606: "grinning squinting face",
+	0x1F607: "smiling face with halo",
+	0x1F608: "smiling face with horns",
+	0x1F609: "winking face",
+	0x1F60A: "smiling face with smiling eyes",
+	0x1F60B: "face savouring delicious food",
+	0x1F60C: "relieved face",
+	0x1F60D: "smiling face with heart-eyes",
+	0x1F60E: "smiling face with sunglasses",
+	0x1F60F: "smirking face",
+	0x1F610: "neutral face",
+	0x1F611: "expressionless face",
+	0x1F612: "unamused face",
+	0x1F613: "downcast face with sweat",
+	0x1F614: "pensive face",
+	0x1F615: "confused face",
+	0x1F616: "confounded face",
+	0x1F617: "kissing face",
+	0x1F618: "face blowing a kiss",
+	0x1F619: "kissing face with smiling eyes",
+	0x1F61A: "kissing face with closed eyes",
+	0x1F61B: "face with stuck-out tongue",
+	0x1F61C: "winking face with tongue",
+	0x1F61D: "squinting face with tongue",
+	0x1F61E: "disappointed face",
+	0x1F61F: "worried face",
+	0x1F620: "angry face",
+	0x1F621: "enraged face",
+	0x1F622: "crying face",
+	0x1F623: "persevering face",
+	0x1F624: "face with look of triumph",
+	0x1F625: "sad but relieved face",
+	0x1F626: "frowning face with open mouth",
+	0x1F627: "anguished face",
+	0x1F628: "fearful face",
+	0x1F629: "weary face",
+	0x1F62A: "sleepy face",
+	0x1F62B: "tired face",
+	0x1F62C: "grimacing face",
+	0x1F62D: "loudly crying face",
+	0x1F62E: "face with open mouth",
+	0x1F62F: "hushed face",
+	0x1F630: "anxious face with sweat",
+	0x1F631: "face screaming in fear",
+	0x1F632: "astonished face",
+	0x1F633: "flushed face",
+	0x1F634: "sleeping face",
+	0x1F635: "face with crossed-out eyes",
+	0x1F636: "face without mouth",
+	0x1F637: "face with medical mask",
+	0x1F638: "grinning cat face with smiling eyes",
+	0x1F639: "cat face with tears of joy",
+	0x1F63A: "smiling cat face with open mouth",
+	0x1F63B: "smiling cat face with heart-shaped eyes",
+	0x1F63C: "cat face with wry smile",
+	0x1F63D: "kissing cat face with closed eyes",
+	0x1F63E: "pouting cat face",
+	0x1F63F: "crying cat face",
+	0x1F640: "weary cat face",
+	0x1F641: "slightly frowning face",
+	0x1F642: "slightly smiling face",
+	0x1F643: "upside-down face",
+	0x1F644: "face with rolling eyes",
+	0x1F645: "face with no good gesture",
+	0x1F646: "face with ok gesture",
+	0x1F647: "person bowing deeply",
+	0x1F648: "see-no-evil monkey",
+	0x1F649: "hear-no-evil monkey",
+	0x1F64A: "speak-no-evil monkey",
+	0x1F64B: "happy person raising one hand",
+	0x1F64C: "person raising both hands in celebration",
+	0x1F64D: "person frowning",
+	0x1F64E: "person with pouting face",
+	0x1F64F: "folded hands",
+	0x1F680: "rocket",
+	0x1F681: "helicopter",
+	0x1F682: "steam locomotive",
+	0x1F683: "railway car",
+	0x1F684: "high-speed train",
+	0x1F685: "high-speed train with bullet nose",
+	0x1F686: "train",
+	0x1F687: "metro",
+	0x1F688: "light rail",
+	0x1F689: "station",
+	0x1F68A: "tram",
+	0x1F68B: "tram car",
+	0x1F68C: "bus",
+	0x1F68D: "oncoming bus",
+	0x1F68E: "trolleybus",
+	0x1F68F: "bus stop",
+	0x1F690: "minibus",
+	0x1F691: "ambulance",
+	0x1F692: "fire engine",
+	0x1F693: "police car",
+	0x1F694: "oncoming police car",
+	0x1F695: "taxi",
+	0x1F696: "oncoming taxi",
+	0x1F697: "automobile",
+	0x1F698: "oncoming automobile",
+	0x1F699: "recreational vehicle",
+	0x1F69A: "delivery truck",
+	0x1F69B: "articulated lorry",
+	0x1F69C: "tractor",
+	0x1F69D: "monorail",
+	0x1F69E: "mountain railway",
+	0x1F69F: "suspension railway",
+	0x1F6A0: "mountain cableway",
+	0x1F6A1: "aerial tramway",
+	0x1F6A2: "ship",
+	0x1F6A3: "rowboat",
+	0x1F6A4: "speedboat",
+	0x1F6A5: "horizontal traffic light",
+	0x1F6A6: "vertical traffic light",
+	0x1F6A7: "construction",
+	0x1F6A8: "police car light",
+	0x1F6A9: "triangular flag on post",
+	0x1F6AA: "door",
+	0x1F6AB: "no entry",
+	0x1F6AC: "smoking",
+	0x1F6AD: "no smoking",
+	0x1F6AE: "put litter in its place",
+	0x1F6AF: "do not litter",
+	0x1F6B0: "potable water",
+	0x1F6B1: "non-potable water",
+	0x1F6B2: "bicycle",
+	0x1F6B3: "no bicycles",
+	0x1F6B4: "bicyclist",
+	0x1F6B5: "mountain bicyclist",
+	0x1F6B6: "pedestrian",
+	0x1F6B7: "no pedestrians",
+	0x1F6B8: "children crossing",
+	0x1F6B9: "mens",
+	0x1F6BA: "womens",
+	0x1F6BB: "restroom",
+	0x1F6BC: "baby",
+	0x1F6BD: "toilet",
+	0x1F6BE: "water closet",
+	0x1F6BF: "shower",
+	0x1F6C0: "bath",
+	0x1F6C1: "bathtub",
+	0x1F6C2: "passport control",
+	0x1F6C3: "customs",
+	0x1F6C4: "baggage claim",
+	0x1F6C5: "left luggage",
+	0x1F6C6: "triangle with rounded corners",
+	0x1F6C7: "prohibited",
+	0x1F6C8: "circled information source",
+	0x1F6C9: "boys",
+	0x1F6CA: "girls",
+	0x1F6CB: "couch and lamp",
+	0x1F6CC: "sleeping accommodation",
+	0x1F6CD: "shopping bags",
+	0x1F6CE: "bellhop bell",
+	0x1F6CF: "bed",
+	0x1F6D0: "place of worship",
+	0x1F6D1: "stop sign",
+	0x1F6D2: "shopping trolley",
+	0x1F6D3: "stupa",
+	0x1F6D4: "pagoda",
+	0x1F6D5: "hindu temple",
+	0x1F6D6: "hut",
+	0x1F6D7: "elevator",
+	0x1F6DD: "playground slide",
+	0x1F6DE: "wheel",
+	0x1F6DF: "ring buoy",
+	0x1F6E0: "hammer and wrench",
+	0x1F6E1: "shield",
+	0x1F6E2: "oil drum",
+	0x1F6E3: "motorway",
+	0x1F6E4: "railway track",
+	0x1F6E5: "motor boat",
+	0x1F6E6: "up-pointing military airplane",
+	0x1F6E7: "up-pointing airplane",
+	0x1F6E8: "up-pointing small airplane",
+	0x1F6E9: "small airplane",
+	0x1F6EA: "northeast-pointing airplane",
+	0x1F6EB: "airplane departure",
+	0x1F6EC: "airplane arriving",
+	0x1F6F0: "satellite",
+	0x1F6F1: "oncoming fire engine",
+	0x1F6F2: "diesel locomotive",
+	0x1F6F3: "passenger ship",
+	0x1F6F4: "scooter",
+	0x1F6F5: "motor scooter",
+	0x1F6F6: "canoe",
+	0x1F6F7: "sled",
+	0x1F6F8: "flying saucer",
+	0x1F6F9: "skateboard",
+	0x1F6FA: "auto rickshaw",
+	0x1F6FB: "pickup truck",
+	0x1F6FC: "roller skate",
+	0x1F7E0: "orange circle",
+	0x1F7E1: "yellow circle",
+	0x1F7E2: "green circle",
+	0x1F7E3: "purple circle",
+	0x1F7E4: "brown circle",
+	0x1F900: "circled cross formee with four dots",
+	0x1F901: "circled cross formee with two dots",
+	0x1F902: "circled cross formee",
+	0x1F903: "left half circle with four dots",
+	0x1F904: "left half circle with three dots",
+	0x1F905: "left half circle with two dots",
+	0x1F906: "left half circle with dot",
+	0x1F907: "left half circle",
+	0x1F908: "downward facing hook",
+	0x1F909: "downward facing notched hook",
+	0x1F90A: "downward facing hook with dot",
+	0x1F90B: "downward facing notched hook with dot",
+	0x1F90C: "pinched fingers",
+	0x1F90D: "white heart",
+	0x1F90E: "brown heart",
+	0x1F90F: "pinching hand",
+	0x1F910: "zipper-mouth face",
+	0x1F911: "money-mouth face",
+	0x1F912: "face with thermometer",
+	0x1F913: "nerd face",
+	0x1F914: "thinking face",
+	0x1F915: "face with head-bandage",
+	0x1F916: "robot",
+	0x1F917: "smiling face with open hands",
+	0x1F918: "sign of the horns",
+	0x1F919: "call me hand",
+	0x1F91A: "raised back of hand",
+	0x1F91B: "left-facing fist",
+	0x1F91C: "right-facing fist",
+	0x1F91D: "handshake",
+	0x1F91E: "crossed fingers",
+	0x1F91F: "i love you hand",
+	0x1F920: "face with cowboy hat",
+	0x1F921: "clown face",
+	0x1F922: "nauseated face",
+	0x1F923: "rolling on the floor laughing",
+	0x1F924: "drooling face",
+	0x1F925: "lying face",
+	0x1F926: "person facepalming",
+	0x1F927: "sneezing face",
+	0x1F928: "face with one eyebrow raised",
+	0x1F929: "star-struck",
+	0x1F92A: "grinning face with one large and one small eye",
+	0x1F92B: "face with finger covering closed lips",
+	0x1F92C: "serious face with symbols covering mouth",
+	0x1F92D: "smiling face with smiling eyes and hand covering mouth",
+	0x1F92E: "face with open mouth vomiting",
+	0x1F92F: "exploding head",
+	0x1F930: "pregnant woman",
+	0x1F931: "breast-feeding",
+	0x1F932: "palms up together",
+	0x1F933: "selfie",
+	0x1F934: "prince",
+	0x1F935: "man in tuxedo",
+	0x1F936: "mother christmas",
+	0x1F937: "person shrugging",
+	0x1F938: "person doing cartwheel",
+	0x1F939: "juggling",
+	0x1F93A: "fencer",
+	0x1F93B: "modern pentathlon",
+	0x1F93C: "wrestlers",
+	0x1F93D: "water polo",
+	0x1F93E: "handball",
+	0x1F93F: "diving mask",
+	0x1F940: "wilted flower",
+	0x1F941: "drum with drumsticks",
+	0x1F942: "clinking glasses",
+	0x1F943: "tumbler glass",
+	0x1F944: "spoon",
+	0x1F945: "goal net",
+	0x1F946: "rifle",
+	0x1F947: "first place medal",
+	0x1F948: "second place medal",
+	0x1F949: "third place medal",
+	0x1F94A: "boxing glove",
+	0x1F94B: "martial arts uniform",
+	0x1F94C: "curling stone",
+	0x1F94D: "lacrosse stick and ball",
+	0x1F94E: "softball",
+	0x1F94F: "flying disc",
+	0x1F950: "croissant",
+	0x1F951: "avocado",
+	0x1F952: "cucumber",
+	0x1F953: "bacon",
+	0x1F954: "potato",
+	0x1F955: "carrot",
+	0x1F956: "baguette bread",
+	0x1F957: "green salad",
+	0x1F958: "shallow pan of food",
+	0x1F959: "stuffed flatbread",
+	0x1F95A: "egg",
+	0x1F95B: "glass of milk",
+	0x1F95C: "peanuts",
+	0x1F95D: "kiwifruit",
+	0x1F95E: "pancakes",
+	0x1F95F: "dumpling",
+	0x1F960: "fortune cookie",
+	0x1F961: "takeout box",
+	0x1F962: "chopsticks",
+	0x1F963: "bowl with spoon",
+	0x1F964: "cup with straw",
+	0x1F965: "coconut",
+	0x1F966: "broccoli",
+	0x1F967: "pie",
+	0x1F968: "pretzel",
+	0x1F969: "cut of meat",
+	0x1F96A: "sandwich",
+	0x1F96B: "canned food",
+	0x1F96C: "leafy green",
+	0x1F96D: "mango",
+	0x1F96E: "moon cake",
+	0x1F96F: "bagel",
+	0x1F970: "smiling face with hearts",
+	0x1F971: "yawning face",
+	0x1F972: "smiling face with tear",
+	0x1F973: "partying face",
+	0x1F974: "face with uneven eyes and wavy mouth",
+	0x1F975: "overheated face",
+	0x1F976: "freezing face",
+	0x1F977: "ninja",
+	0x1F978: "disguised face",
+	0x1F979: "face holding back tears",
+	0x1F97A: "pleading face",
+	0x1F97B: "sari",
+	0x1F97C: "lab coat",
+	0x1F97D: "goggles",
+	0x1F97E: "hiking boot",
+	0x1F97F: "flat shoe",
+	0x1F980: "crab",
+	0x1F981: "lion face",
+	0x1F982: "scorpion",
+	0x1F983: "turkey",
+	0x1F984: "unicorn face",
+	0x1F985: "eagle",
+	0x1F986: "duck",
+	0x1F987: "bat",
+	0x1F988: "shark",
+	0x1F989: "owl",
+	0x1F98A: "fox face",
+	0x1F98B: "butterfly",
+	0x1F98C: "deer",
+	0x1F98D: "gorilla",
+	0x1F98E: "lizard",
+	0x1F98F: "rhinoceros",
+	0x1F990: "shrimp",
+	0x1F991: "squid",
+	0x1F992: "giraffe face",
+	0x1F993: "zebra face",
+	0x1F994: "hedgehog",
+	0x1F995: "sauropod",
+	0x1F996: "t-rex",
+	0x1F997: "cricket",
+	0x1F998: "kangaroo",
+	0x1F999: "llama",
+	0x1F99A: "peacock",
+	0x1F99B: "hippopotamus",
+	0x1F99C: "parrot",
+	0x1F99D: "raccoon",
+	0x1F99E: "lobster",
+	0x1F99F: "mosquito",
+	0x1F9A0: "microbe",
+	0x1F9A1: "badger",
+	0x1F9A2: "swan",
+	0x1F9A3: "mammoth",
+	0x1F9A4: "dodo",
+	0x1F9A5: "sloth",
+	0x1F9A6: "otter",
+	0x1F9A7: "orangutan",
+	0x1F9A8: "skunk",
+	0x1F9A9: "flamingo",
+	0x1F9AA: "oyster",
+	0x1F9AB: "beaver",
+	0x1F9AC: "bison",
+	0x1F9AD: "seal",
+	0x1F9AE: "guide dog",
+	0x1F9AF: "probing cane",
+	0x1F9B0: "emoji component red hair",
+	0x1F9B1: "emoji component curly hair",
+	0x1F9B2: "emoji component bald",
+	0x1F9B3: "emoji component white hair",
+	0x1F9B4: "bone",
+	0x1F9B5: "leg",
+	0x1F9B6: "foot",
+	0x1F9B7: "tooth",
+	0x1F9B8: "superhero",
+	0x1F9B9: "supervillain",
+	0x1F9BA: "safety vest",
+	0x1F9BB: "ear with hearing aid",
+	0x1F9BC: "motorized wheelchair",
+	0x1F9BD: "manual wheelchair",
+	0x1F9BE: "mechanical arm",
+	0x1F9BF: "mechanical leg",
+	0x1F9C0: "cheese wedge",
+	0x1F9C1: "cupcake",
+	0x1F9C2: "salt shaker",
+	0x1F9C3: "beverage box",
+	0x1F9C4: "garlic",
+	0x1F9C5: "onion",
+	0x1F9C6: "falafel",
+	0x1F9C7: "waffle",
+	0x1F9C8: "butter",
+	0x1F9C9: "mate drink",
+	0x1F9CA: "ice cube",
+	0x1F9CB: "bubble tea",
+	0x1F9CC: "troll",
+	0x1F9CD: "standing person",
+	0x1F9CE: "kneeling person",
+	0x1F9CF: "deaf person",
+	0x1F9D0: "face with monocle",
+	0x1F9D1: "adult",
+	0x1F9D2: "child",
+	0x1F9D3: "older adult",
+	0x1F9D4: "bearded person",
+	0x1F9D5: "person with headscarf",
+	0x1F9D6: "person in steamy room",
+	0x1F9D7: "person climbing",
+	0x1F9D8: "person in lotus position",
+	0x1F9D9: "mage",
+	0x1F9DA: "fairy",
+	0x1F9DB: "vampire",
+	0x1F9DC: "merperson",
+	0x1F9DD: "elf",
+	0x1F9DE: "genie",
+	0x1F9DF: "zombie",
+	0x1F9E0: "brain",
+	0x1F9E1: "orange heart",
+	0x1F9E2: "billed cap",
+	0x1F9E3: "scarf",
+	0x1F9E4: "gloves",
+	0x1F9E5: "coat",
+	0x1F9E6: "socks",
+	0x1F9E7: "red gift envelope",
+	0x1F9E8: "firecracker",
+	0x1F9E9: "jigsaw puzzle piece",
+	0x1F9EA: "test tube",
+	0x1F9EB: "petri dish",
+	0x1F9EC: "dna double helix",
+	0x1F9ED: "compass",
+	0x1F9EE: "abacus",
+	0x1F9EF: "fire extinguisher",
+	0x1F9F0: "toolbox",
+	0x1F9F1: "brick",
+	0x1F9F2: "magnet",
+	0x1F9F3: "luggage",
+	0x1F9F4: "lotion bottle",
+	0x1F9F5: "spool of thread",
+	0x1F9F6: "ball of yarn",
+	0x1F9F7: "safety pin",
+	0x1F9F8: "teddy bear",
+	0x1F9F9: "broom",
+	0x1F9FA: "basket",
+	0x1F9FB: "roll of paper",
+	0x1F9FC: "bar of soap",
+	0x1F9FD: "sponge",
+	0x1F9FE: "receipt",
+	0x1F9FF: "nazar amulet",
+	0x1FA70: "ballet shoes",
+	0x1FA71: "one-piece swimsuit",
+	0x1FA72: "briefs",
+	0x1FA73: "shorts",
+	0x1FA74: "thong sandal",
+	0x1FA78: "drop of blood",
+	0x1FA79: "adhesive bandage",
+	0x1FA7A: "stethoscope",
+	0x1FA7B: "x-ray",
+	0x1FA7C: "crutch",
+	0x1FA80: "yo-yo",
+	0x1FA81: "kite",
+	0x1FA82: "parachute",
+	0x1FA83: "boomerang",
+	0x1FA84: "magic wand",
+	0x1FA85: "pinata",
+	0x1FA86: "nesting dolls",
+	0x1FA90: "ringed planet",
+	0x1FA91: "chair",
+	0x1FA92: "razor",
+	0x1FA93: "axe",
+	0x1FA94: "diya lamp",
+	0x1FA95: "banjo",
+	0x1FA96: "military helmet",
+	0x1FA97: "accordion",
+	0x1FA98: "long drum",
+	0x1FA99: "coin",
+	0x1FA9A: "carpentry saw",
+	0x1FA9B: "screwdriver",
+	0x1FA9C: "ladder",
+	0x1FA9D: "hook",
+	0x1FA9E: "mirror",
+	0x1FA9F: "window",
+	0x1FAA0: "plunger",
+	0x1FAA1: "sewing needle",
+	0x1FAA2: "knot",
+	0x1FAA3: "bucket",
+	0x1FAA4: "mouse trap",
+	0x1FAA5: "toothbrush",
+	0x1FAA6: "headstone",
+	0x1FAA7: "placard",
+	0x1FAA8: "rock",
+	0x1FAA9: "mirror ball",
+	0x1FAAA: "identification card",
+	0x1FAAB: "low battery",
+	0x1FAAC: "hamsa",
+	0x1FAB0: "fly",
+	0x1FAB1: "worm",
+	0x1FAB2: "beetle",
+	0x1FAB3: "cockroach",
+	0x1FAB4: "potted plant",
+	0x1FAB5: "wood",
+	0x1FAB6: "feather",
+	0x1FAB7: "lotus",
+	0x1FAB8: "coral",
+	0x1FAB9: "empty nest",
+	0x1FABA: "nest with eggs",
+	0x1FAC0: "anatomical heart",
+	0x1FAC1: "lungs",
+	0x1FAC2: "people hugging",
+	0x1FAC3: "pregnant man",
+	0x1FAC4: "pregnant person",
+	0x1FAC5: "person with crown",
+	0x1FAD0: "blueberries",
+	0x1FAD1: "bell pepper",
+	0x1FAD2: "olive",
+	0x1FAD3: "flatbread",
+	0x1FAD4: "tamale",
+	0x1FAD5: "fondue",
+	0x1FAD6: "teapot",
+	0x1FAD7: "pouring liquid",
+	0x1FAD8: "beans",
+	0x1FAD9: "jar",
+	0x1FAE0: "melting face",
+	0x1FAE1: "saluting face",
+	0x1FAE2: "face with open eyes and hand over mouth",
+	0x1FAE3: "face with peeking eye",
+	0x1FAE4: "face with diagonal mouth",
+	0x1FAE5: "dotted line face",
+	0x1FAE6: "biting lip",
+	0x1FAE7: "bubbles",
+	0x1FAF0: "hand with index finger and thumb crossed",
+	0x1FAF1: "rightwards hand",
+	0x1FAF2: "leftwards hand",
+	0x1FAF3: "palm down hand",
+	0x1FAF4: "palm up hand",
+	0x1FAF5: "index pointing at the viewer",
+	0x1FAF6: "heart hands",
+}
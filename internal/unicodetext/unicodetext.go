@@ -0,0 +1,292 @@
+// Package unicodetext makes Unicode text speakable. Clean canonicalizes
+// equivalent spellings so they share a cache key; Symbols verbalizes or strips
+// emoji and symbols.
+package unicodetext
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Emoji modes for Symbols.
+const (
+	// EmojiVerbalize speaks emoji by their short names ("thumbs up").
+	EmojiVerbalize = "verbalize"
+	// EmojiStrip drops emoji.
+	EmojiStrip = "strip"
+	// EmojiKeep leaves emoji and symbols for the engine.
+	EmojiKeep = "keep"
+)
+
+// ValidMode reports whether mode is one of the emoji modes.
+func ValidMode(mode string) bool {
+	return mode == EmojiVerbalize || mode == EmojiStrip || mode == EmojiKeep
+}
+
+const zwj = '\u200d'
+
+// Clean composes combining marks onto their base characters (NFC), turns
+// smart quotes into ASCII quotes and unusual spaces into plain ones, and drops
+// zero-width and bidi control characters. A zero-width joiner is kept only
+// between two symbols, where it joins an emoji sequence.
+func Clean(text string) string {
+	runes := []rune(text)
+	out := make([]rune, 0, len(runes))
+	for i, r := range runes {
+		if s, ok := singletons[r]; ok {
+			r = s
+		}
+		switch {
+		case r == '‘' || r == '’' || r == '‚' || r == '‛':
+			r = '\''
+		case r == '“' || r == '”' || r == '„' || r == '‟':
+			r = '"'
+		case r == '\u00a0' || r == '\u202f' || r == '\u205f' || r == '\u3000' || (r >= '\u2000' && r <= '\u200a'):
+			r = ' '
+		case r == zwj:
+			if i == 0 || i == len(runes)-1 || isText(runes[i-1]) || isText(runes[i+1]) {
+				continue
+			}
+		case r == '\u00ad' || r == '\u200b' || r == '\u200c' || r == '\u2060' || r == '\ufeff',
+			r == '\u200e' || r == '\u200f' || (r >= '\u202a' && r <= '\u202e') || (r >= '\u2066' && r <= '\u2069'):
+			continue
+		}
+		out = decompose(out, r)
+	}
+	reorder(out)
+	return string(compose(out))
+}
+
+// decompositions inverts compositions.
+var decompositions = func() map[rune][2]rune {
+	m := make(map[rune][2]rune, len(compositions))
+	for pair, c := range compositions {
+		m[c] = pair
+	}
+	return m
+}()
+
+// decompose appends the canonical decomposition of r to out.
+func decompose(out []rune, r rune) []rune {
+	d, ok := decompositions[r]
+	if !ok {
+		d, ok = exclusions[r]
+	}
+	if ok {
+		return decompose(decompose(out, d[0]), d[1])
+	}
+	return append(out, r)
+}
+
+// combiningClass returns the canonical combining class of r, 0 for starters.
+func combiningClass(r rune) uint8 {
+	i := sort.Search(len(combiningClasses), func(i int) bool { return combiningClasses[i].hi >= r })
+	if i < len(combiningClasses) && combiningClasses[i].lo <= r {
+		return combiningClasses[i].class
+	}
+	return 0
+}
+
+// reorder sorts each run of combining marks by combining class, keeping marks
+// of the same class in order.
+func reorder(runes []rune) {
+	for i := 1; i < len(runes); i++ {
+		c := combiningClass(runes[i])
+		if c == 0 {
+			continue
+		}
+		for j := i; j > 0 && combiningClass(runes[j-1]) > c; j-- {
+			runes[j-1], runes[j] = runes[j], runes[j-1]
+		}
+	}
+}
+
+// compose combines each starter with the following characters it composes
+// with, unless a mark of the same or a higher class comes between them.
+func compose(runes []rune) []rune {
+	out := runes[:0]
+	starter, last := -1, uint8(0)
+	for _, r := range runes {
+		c := combiningClass(r)
+		if starter >= 0 && (len(out)-1 == starter || (last != 0 && last < c)) {
+			if comp, ok := composePair(out[starter], r); ok {
+				out[starter] = comp
+				continue
+			}
+		}
+		if c == 0 {
+			starter = len(out)
+		}
+		last = c
+		out = append(out, r)
+	}
+	return out
+}
+
+// Hangul syllables are composed arithmetically rather than from a table.
+const (
+	hangulBase   = 0xac00
+	hangulCount  = 11172
+	jamoLBase    = 0x1100
+	jamoVBase    = 0x1161
+	jamoTBase    = 0x11a7
+	jamoLCount   = 19
+	jamoVCount   = 21
+	jamoTCount   = 28
+	hangulLVSpan = jamoVCount * jamoTCount
+)
+
+// composePair returns the canonical composition of a and b.
+func composePair(a, b rune) (rune, bool) {
+	switch {
+	case a >= jamoLBase && a < jamoLBase+jamoLCount && b >= jamoVBase && b < jamoVBase+jamoVCount:
+		return hangulBase + (a-jamoLBase)*hangulLVSpan + (b-jamoVBase)*jamoTCount, true
+	case a >= hangulBase && a < hangulBase+hangulCount && (a-hangulBase)%jamoTCount == 0 &&
+		b > jamoTBase && b < jamoTBase+jamoTCount:
+		return a + b - jamoTBase, true
+	}
+	c, ok := compositions[[2]rune{a, b}]
+	return c, ok
+}
+
+func isText(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r)
+}
+
+// symbolWords are spoken in place of arrows, math and other symbols, by
+// primary language subtag. Other languages keep the symbols for the engine.
+var symbolWords = map[string]map[rune]string{"en": {
+	'→': "to", '⟶': "to", '➔': "to", '➜': "to", '➡': "to", '⇒': "so", '⟹': "so",
+	'←': "from", '⟵': "from", '⬅': "from", '↔': "and", '⇔': "if and only if",
+	'↑': "up", '⬆': "up", '↓': "down", '⬇': "down",
+	'≤': "less than or equal to", '≥': "greater than or equal to", '≠': "not equal to",
+	'≈': "approximately", '±': "plus or minus", '×': "times", '÷': "divided by", '−': "minus",
+	'∞': "infinity", '√': "square root of", '∑': "sum of", '∆': "delta", '‰': "per mille",
+	'§': "section", '¶': "paragraph", '&': "and",
+}}
+
+// bullets start list items; each becomes a sentence break.
+var bullets = map[rune]bool{
+	'•': true, '◦': true, '‣': true, '⁃': true, '∙': true, '▪': true, '▫': true,
+	'●': true, '○': true, '■': true, '□': true, '◆': true, '◇': true, '➢': true, '➤': true,
+}
+
+var (
+	spacePunct   = regexp.MustCompile(`\s+([.,!?;:])`)
+	bulletMarker = "\x00"
+)
+
+// Symbols rewrites emoji and symbols for speech. With EmojiVerbalize emoji are
+// replaced by their names (repeats spoken once) and flags by "flag" and their
+// letters; with EmojiStrip they are dropped; with EmojiKeep they are left in
+// place. In every mode bullets become sentence breaks, box drawing is removed
+// and, for a lang with symbolWords, arrows and math symbols become words. An
+// empty mode returns text unchanged.
+func Symbols(text, mode, lang string) string {
+	if mode == "" {
+		return text
+	}
+	words := symbolWords[lang]
+	runes := []rune(text)
+	var b strings.Builder
+	lastEmoji := ""
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if word, ok := words[r]; ok {
+			b.WriteString(" " + word + " ")
+			lastEmoji = ""
+			continue
+		}
+		switch {
+		case r == '\ufe0e' || r == '\ufe0f' || r == '\u20e3' || r == zwj,
+			r >= 0x1F3FB && r <= 0x1F3FF, r >= 0xE0020 && r <= 0xE007F:
+			// Presentation selectors, keycaps, joiners, skin tones and tags
+			// qualify the emoji around them.
+			// Kept emoji keep them; rewritten symbols such as ➡️ drop them.
+			if mode == EmojiKeep && (i == 0 || !isSymbol(runes[i-1], words)) {
+				b.WriteRune(r)
+			} else {
+				b.WriteString(" ")
+			}
+			continue
+		case isRegional(r) && mode == EmojiKeep:
+			b.WriteRune(r)
+			continue
+		case isRegional(r):
+			if mode == EmojiVerbalize && i+1 < len(runes) && isRegional(runes[i+1]) {
+				b.WriteString(" flag " + string('A'+r-0x1F1E6) + " " + string('A'+runes[i+1]-0x1F1E6) + " ")
+				i++
+			}
+			b.WriteString(" ")
+			lastEmoji = ""
+			continue
+		case isBoxDrawing(r):
+			b.WriteString(" ")
+			continue
+		case bullets[r]:
+			b.WriteString(bulletMarker)
+			lastEmoji = ""
+			continue
+		}
+		name, ok := emojiNames[r]
+		if !ok && !isPictograph(r) {
+			b.WriteRune(r)
+			if !unicode.IsSpace(r) {
+				lastEmoji = ""
+			}
+			continue
+		}
+		switch {
+		case mode == EmojiKeep:
+			b.WriteRune(r)
+		case mode == EmojiVerbalize && name != "" && name != lastEmoji:
+			b.WriteString(" " + name + " ")
+		default:
+			b.WriteString(" ")
+		}
+		lastEmoji = name
+	}
+	out := b.String()
+	if strings.Contains(out, bulletMarker) {
+		out = joinItems(strings.Split(out, bulletMarker))
+	}
+	return spacePunct.ReplaceAllString(strings.Join(strings.Fields(out), " "), "$1")
+}
+
+// isSymbol reports whether Symbols rewrites r in every mode, given the
+// symbol words in use.
+func isSymbol(r rune, words map[rune]string) bool {
+	_, ok := words[r]
+	return ok || bullets[r] || isBoxDrawing(r)
+}
+
+func isBoxDrawing(r rune) bool {
+	return r >= 0x2500 && r <= 0x259F
+}
+
+func isRegional(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isPictograph reports whether r lies in a block of emoji and pictographs.
+func isPictograph(r rune) bool {
+	return (r >= 0x1F300 && r <= 0x1FAFF) || (r >= 0x2600 && r <= 0x27BF)
+}
+
+// joinItems joins bulleted items into sentences.
+func joinItems(items []string) string {
+	var out []string
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if n := len(out); n > 0 && !strings.ContainsAny(out[n-1][len(out[n-1])-1:], ".!?:;") {
+			out[n-1] += "."
+		}
+		out = append(out, item)
+	}
+	return strings.Join(out, " ")
+}
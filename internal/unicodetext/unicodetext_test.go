@@ -0,0 +1,47 @@
+package unicodetext
+
+import "testing"
+
+func TestClean(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"Cafe\u0301 cre\u0300me bru\u0302le\u0301e", "Café crème brûlée"},
+		{"\u212b and A\u030a", "\u00c5 and \u00c5"},
+		{"“It’s here”", `"It's here"`},
+		{"zero\u200bwidth\u00a0space\ufeff", "zerowidth space"},
+		{"soft\u00adhyphen a\u200db", "softhyphen ab"},
+		{"\U0001F468\u200d\U0001F4BB", "\U0001F468\u200d\U0001F4BB"},
+		// Marks are reordered by combining class before composing.
+		{"a\u0302\u0323 a\u0323\u0302 \u00e2\u0323", "\u1ead \u1ead \u1ead"},
+		{"q\u0307\u0323 \u1e0b\u0323", "q\u0323\u0307 \u1e0d\u0307"},
+		{"\u0344 \u0958", "\u0308\u0301 \u0915\u093c"},
+		{"\u1100\u1161 \u1100\u1161\u11a8 \uac00\u11a8", "\uac00 \uac01 \uac01"},
+	}
+	for _, c := range cases {
+		if got := Clean(c.in); got != c.want {
+			t.Fatalf("Clean(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestSymbols(t *testing.T) {
+	cases := []struct{ in, mode, lang, want string }{
+		{"Deploy done \U0001F44D\U0001F44D\U0001F3FD!", EmojiVerbalize, "en", "Deploy done thumbs up!"},
+		{"Deploy done \U0001F44D\U0001F44D\U0001F3FD!", EmojiStrip, "en", "Deploy done!"},
+		{"Deploy done \U0001F44D", EmojiKeep, "en", "Deploy done \U0001F44D"},
+		{"\U0001F468\u200d\U0001F4BB \U0001F1E9\U0001F1EA: a ➡\ufe0f b • c ≥ d", EmojiKeep, "en", "\U0001F468\u200d\U0001F4BB \U0001F1E9\U0001F1EA: a to b. c greater than or equal to d"},
+		{"\u2764\ufe0f it \U0001F525", EmojiVerbalize, "en", "red heart it fire"},
+		{"Trip \U0001F1E9\U0001F1EA", EmojiVerbalize, "en", "Trip flag D E"},
+		{"Press 1\ufe0f\u20e3", EmojiStrip, "en", "Press 1"},
+		{"staging → prod, x ≤ 5, 20°C", EmojiStrip, "en", "staging to prod, x less than or equal to 5, 20°C"},
+		{"Test → Prod & mehr, 20°C • fertig", EmojiStrip, "de", "Test → Prod & mehr, 20°C. fertig"},
+		{"x ≥ 5 & y", EmojiStrip, "", "x ≥ 5 & y"},
+		{"• build ok • tests failed • deploy skipped.", EmojiVerbalize, "en", "build ok. tests failed. deploy skipped."},
+		{"┌──┐ table │ row │", EmojiStrip, "en", "table row"},
+		{"\U0001F468\u200d\U0001F4BB at work", EmojiVerbalize, "en", "man laptop at work"},
+	}
+	for _, c := range cases {
+		if got := Symbols(c.in, c.mode, c.lang); got != c.want {
+			t.Fatalf("Symbols(%q, %s, %s) = %q, want %q", c.in, c.mode, c.lang, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package warmup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ErrEmpty is returned when a phrasebook holds no entries.
+var ErrEmpty = errors.New("phrasebook has no entries")
+
+// Entry is a phrase to precompute. Voice defaults to the server's voice when empty;
+// Pin exempts the cached wav from eviction.
+type Entry struct {
+	Text  string `json:"text"`
+	Voice string `json:"voice,omitempty"`
+	Pin   bool   `json:"pin,omitempty"`
+}
+
+// LoadPhrasebook reads a phrasebook file; see ParsePhrasebook for the formats.
+func LoadPhrasebook(path string) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ext := strings.ToLower(filepath.Ext(path))
+	return ParsePhrasebook(f, ext == ".yaml" || ext == ".yml")
+}
+
+// ParsePhrasebook parses either a plain phrasebook (one phrase per line, blank
+// lines and '#' comments ignored) or, when yaml is set or the content starts like
+// a YAML list, a YAML list whose items are phrases or text/voice/pin mappings:
+//
+//	phrases:
+//	  - Front door opened
+//	  - text: "Smoke detected in the kitchen"
+//	    voice: amy
+//	    pin: true
+func ParsePhrasebook(r io.Reader, yaml bool) ([]Entry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if !yaml {
+		yaml = looksLikeYAML(lines)
+	}
+
+	var entries []Entry
+	if yaml {
+		if entries, err = parseYAML(lines); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, line := range lines {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			entries = append(entries, Entry{Text: line})
+		}
+	}
+	if len(entries) == 0 {
+		return nil, ErrEmpty
+	}
+	return entries, nil
+}
+
+func looksLikeYAML(lines []string) bool {
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		return trimmed == "---" || trimmed == "phrases:" || strings.HasPrefix(trimmed, "- ")
+	}
+	return false
+}
+
+// parseYAML handles the small YAML subset phrasebooks need: an optional
+// top-level "phrases:" key holding a block list of scalars or flat mappings.
+func parseYAML(lines []string) ([]Entry, error) {
+	var entries []Entry
+	var cur *Entry
+	itemIndent := -1
+
+	flush := func(lineNo int) error {
+		if cur == nil {
+			return nil
+		}
+		if strings.TrimSpace(cur.Text) == "" {
+			return fmt.Errorf("line %d: entry has no text", lineNo)
+		}
+		entries = append(entries, *cur)
+		cur = nil
+		return nil
+	}
+
+	for i, raw := range lines {
+		lineNo := i + 1
+		line := stripComment(raw)
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if trimmed == "phrases:" && indent == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			if err := flush(lineNo); err != nil {
+				return nil, err
+			}
+			itemIndent = indent
+			cur = &Entry{}
+			rest := strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			if rest == "" {
+				continue
+			}
+			if key, val, ok := splitKeyValue(rest); ok {
+				if err := setField(cur, key, val, lineNo); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			text, err := unquote(rest, lineNo)
+			if err != nil {
+				return nil, err
+			}
+			cur.Text = text
+			if err := flush(lineNo); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if cur == nil || indent <= itemIndent {
+			return nil, fmt.Errorf("line %d: expected list item", lineNo)
+		}
+		key, val, ok := splitKeyValue(trimmed)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key: value", lineNo)
+		}
+		if err := setField(cur, key, val, lineNo); err != nil {
+			return nil, err
+		}
+	}
+	if err := flush(len(lines)); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func setField(e *Entry, key, val string, lineNo int) error {
+	v, err := unquote(val, lineNo)
+	if err != nil {
+		return err
+	}
+	switch key {
+	case "text":
+		e.Text = v
+	case "voice":
+		e.Voice = v
+	case "pin":
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid pin value %q", lineNo, v)
+		}
+		e.Pin = b
+	default:
+		return fmt.Errorf("line %d: unknown field %q", lineNo, key)
+	}
+	return nil
+}
+
+func splitKeyValue(s string) (string, string, bool) {
+	if strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "'") {
+		return "", "", false
+	}
+	idx := strings.Index(s, ":")
+	if idx <= 0 || (idx+1 < len(s) && s[idx+1] != ' ') {
+		return "", "", false
+	}
+	key := strings.TrimSpace(s[:idx])
+	if strings.ContainsAny(key, " \t") {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(s[idx+1:]), true
+}
+
+func unquote(s string, lineNo int) (string, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return "", fmt.Errorf("line %d: invalid quoted string", lineNo)
+		}
+		return v, nil
+	case strings.HasPrefix(s, "'"):
+		if len(s) < 2 || !strings.HasSuffix(s, "'") {
+			return "", fmt.Errorf("line %d: invalid quoted string", lineNo)
+		}
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'"), nil
+	}
+	return s, nil
+}
+
+// stripComment drops a trailing "# comment" that is not inside quotes.
+func stripComment(line string) string {
+	var quote rune
+	for i, c := range line {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return strings.TrimRight(line[:i], " \t")
+		}
+	}
+	return strings.TrimRight(line, " \t")
+}
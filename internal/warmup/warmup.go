@@ -0,0 +1,147 @@
+package warmup
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// SynthFunc makes sure an entry is cached, returning the cache status
+// ("cache_hit", "cache_miss", ...) on success.
+type SynthFunc func(ctx context.Context, e Entry) (string, error)
+
+// Failure records an entry that could not be warmed.
+type Failure struct {
+	Text  string `json:"text"`
+	Error string `json:"error"`
+}
+
+// Snapshot is a point-in-time view of warmup progress.
+type Snapshot struct {
+	Running     bool       `json:"running"`
+	Total       int        `json:"total"`
+	Done        int        `json:"done"`
+	Cached      int        `json:"cached"`
+	Synthesized int        `json:"synthesized"`
+	Failed      int        `json:"failed"`
+	Failures    []Failure  `json:"failures,omitempty"`
+	Started     time.Time  `json:"started,omitempty"`
+	Finished    *time.Time `json:"finished,omitempty"`
+}
+
+// Progress tracks the most recent warmup run; safe for concurrent use.
+type Progress struct {
+	mu   sync.Mutex
+	snap Snapshot
+}
+
+// Snapshot returns a copy of the current progress.
+func (p *Progress) Snapshot() Snapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	snap := p.snap
+	snap.Failures = append([]Failure(nil), p.snap.Failures...)
+	return snap
+}
+
+// begin resets progress for a new run; it fails if a run is already active.
+func (p *Progress) begin(total int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.snap.Running {
+		return false
+	}
+	p.snap = Snapshot{Running: true, Total: total, Started: time.Now().UTC()}
+	return true
+}
+
+func (p *Progress) record(e Entry, status string, err error) Snapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.snap.Done++
+	switch {
+	case err != nil:
+		p.snap.Failed++
+		p.snap.Failures = append(p.snap.Failures, Failure{Text: e.Text, Error: err.Error()})
+	case status == "cache_miss":
+		p.snap.Synthesized++
+	default:
+		p.snap.Cached++
+	}
+	return p.snap
+}
+
+func (p *Progress) finish() Snapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now().UTC()
+	p.snap.Running = false
+	p.snap.Finished = &now
+	return p.snap
+}
+
+// Run synthesizes entries with at most concurrency calls in flight and
+// returns the final progress. It returns false without doing anything if
+// another run is already tracked by progress.
+func Run(ctx context.Context, entries []Entry, concurrency int, synth SynthFunc, progress *Progress, logger *log.Logger) (Snapshot, bool) {
+	if !progress.begin(len(entries)) {
+		return progress.Snapshot(), false
+	}
+	return execute(ctx, entries, concurrency, synth, progress, logger), true
+}
+
+// Start is like Run but returns as soon as the run is registered, leaving the
+// work to a background goroutine.
+func Start(ctx context.Context, entries []Entry, concurrency int, synth SynthFunc, progress *Progress, logger *log.Logger) bool {
+	if !progress.begin(len(entries)) {
+		return false
+	}
+	go execute(ctx, entries, concurrency, synth, progress, logger)
+	return true
+}
+
+func execute(ctx context.Context, entries []Entry, concurrency int, synth SynthFunc, progress *Progress, logger *log.Logger) Snapshot {
+	if logger == nil {
+		logger = log.Default()
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	logger.Printf("INFO: warmup started entries=%d concurrency=%d", len(entries), concurrency)
+
+	jobs := make(chan Entry)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range jobs {
+				status, err := synth(ctx, e)
+				snap := progress.record(e, status, err)
+				if err != nil {
+					logger.Printf("ERROR: warmup failed for %q: %v", e.Text, err)
+				}
+				if snap.Done%25 == 0 && snap.Done < snap.Total {
+					logger.Printf("INFO: warmup progress %d/%d (synthesized=%d failed=%d)", snap.Done, snap.Total, snap.Synthesized, snap.Failed)
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, e := range entries {
+		select {
+		case jobs <- e:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	snap := progress.finish()
+	logger.Printf("INFO: warmup finished total=%d done=%d cached=%d synthesized=%d failed=%d",
+		snap.Total, snap.Done, snap.Cached, snap.Synthesized, snap.Failed)
+	return snap
+}
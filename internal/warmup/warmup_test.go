@@ -0,0 +1,130 @@
+package warmup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParsePhrasebookPlain(t *testing.T) {
+	in := "# announcements\nFront door opened\n\n  Back door opened  \n"
+	entries, err := ParsePhrasebook(strings.NewReader(in), false)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []Entry{{Text: "Front door opened"}, {Text: "Back door opened"}}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+}
+
+func TestParsePhrasebookYAML(t *testing.T) {
+	in := `phrases:
+  - Front door opened  # bare item
+  - text: "Smoke detected: leave now"
+    voice: amy
+    pin: true
+  - text: 'It''s lunch time'
+`
+	entries, err := ParsePhrasebook(strings.NewReader(in), false)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []Entry{
+		{Text: "Front door opened"},
+		{Text: "Smoke detected: leave now", Voice: "amy", Pin: true},
+		{Text: "It's lunch time"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+}
+
+func TestParsePhrasebookErrors(t *testing.T) {
+	for _, in := range []string{"", "- voice: amy\n", "- text: hi\n  colour: red\n", "- text: hi\n  pin: maybe\n"} {
+		if _, err := ParsePhrasebook(strings.NewReader(in), true); err == nil {
+			t.Fatalf("expected error for %q", in)
+		}
+	}
+}
+
+func TestRunBoundsConcurrencyAndReports(t *testing.T) {
+	entries := []Entry{{Text: "a"}, {Text: "b"}, {Text: "c"}, {Text: "d"}, {Text: "e"}}
+	var inFlight, peak int32
+	var mu sync.Mutex
+	seen := map[string]bool{}
+
+	synth := func(_ context.Context, e Entry) (string, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		seen[e.Text] = true
+		mu.Unlock()
+		switch e.Text {
+		case "a":
+			return "cache_hit", nil
+		case "b":
+			return "", errors.New("boom")
+		}
+		return "cache_miss", nil
+	}
+
+	var progress Progress
+	snap, ok := Run(context.Background(), entries, 2, synth, &progress, logDiscard)
+	if !ok {
+		t.Fatalf("run refused")
+	}
+	if peak > 2 {
+		t.Fatalf("concurrency exceeded: %d", peak)
+	}
+	if len(seen) != 5 {
+		t.Fatalf("not all entries attempted: %v", seen)
+	}
+	if snap.Running || snap.Done != 5 || snap.Cached != 1 || snap.Synthesized != 3 || snap.Failed != 1 {
+		t.Fatalf("unexpected snapshot %+v", snap)
+	}
+	if len(snap.Failures) != 1 || snap.Failures[0].Text != "b" {
+		t.Fatalf("unexpected failures %+v", snap.Failures)
+	}
+}
+
+func TestStartRejectsConcurrentRuns(t *testing.T) {
+	release := make(chan struct{})
+	synth := func(context.Context, Entry) (string, error) {
+		<-release
+		return "cache_miss", nil
+	}
+
+	var progress Progress
+	if !Start(context.Background(), []Entry{{Text: "a"}}, 1, synth, &progress, logDiscard) {
+		t.Fatalf("first start refused")
+	}
+	if Start(context.Background(), []Entry{{Text: "b"}}, 1, synth, &progress, logDiscard) {
+		t.Fatalf("second start should be refused while running")
+	}
+	close(release)
+
+	deadline := time.Now().Add(time.Second)
+	for progress.Snapshot().Running {
+		if time.Now().After(deadline) {
+			t.Fatalf("warmup did not finish")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+var logDiscard = log.New(io.Discard, "", 0)